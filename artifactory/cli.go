@@ -15,23 +15,32 @@ import (
 	"github.com/jfrog/jfrog-cli-go/artifactory/commands/mvn"
 	"github.com/jfrog/jfrog-cli-go/artifactory/commands/npm"
 	"github.com/jfrog/jfrog-cli-go/artifactory/commands/nuget"
+	"github.com/jfrog/jfrog-cli-go/artifactory/commands/pip"
+	"github.com/jfrog/jfrog-cli-go/artifactory/commands/yarn"
 	"github.com/jfrog/jfrog-cli-go/artifactory/spec"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	golangutils "github.com/jfrog/jfrog-cli-go/artifactory/utils/golang"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/xray"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildadddependencies"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildaddgit"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildclean"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildcollectenv"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/builddiff"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/builddistribute"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildexport"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildimport"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/builduploadfile"
 	configdocs "github.com/jfrog/jfrog-cli-go/docs/artifactory/config"
 	copydocs "github.com/jfrog/jfrog-cli-go/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli-go/docs/artifactory/curl"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/delete"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/deleteprops"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/dockerbuild"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/dockerpromote"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/dockerpull"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/dockerpush"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/download"
@@ -51,10 +60,18 @@ import (
 	nugetdocs "github.com/jfrog/jfrog-cli-go/docs/artifactory/nuget"
 	nugettree "github.com/jfrog/jfrog-cli-go/docs/artifactory/nugetdepstree"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/ping"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/pipdepstree"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/pipinstall"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/pippublish"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/search"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/setprops"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/specschema"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/specvalidate"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/syncdownload"
+	"github.com/jfrog/jfrog-cli-go/docs/artifactory/syncupload"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/upload"
 	"github.com/jfrog/jfrog-cli-go/docs/artifactory/use"
+	yarndocs "github.com/jfrog/jfrog-cli-go/docs/artifactory/yarn"
 	"github.com/jfrog/jfrog-cli-go/docs/common"
 	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
@@ -155,6 +172,57 @@ func GetCommands() []cli.Command {
 				deleteCmd(c)
 			},
 		},
+		{
+			Name:      "sync-upload",
+			Flags:     getSyncUploadFlags(),
+			Aliases:   []string{"su"},
+			Usage:     syncupload.Description,
+			HelpName:  common.CreateUsage("rt sync-upload", syncupload.Description, syncupload.Usage),
+			UsageText: syncupload.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				syncUploadCmd(c)
+			},
+		},
+		{
+			Name:      "sync-download",
+			Flags:     getSyncDownloadFlags(),
+			Aliases:   []string{"sd"},
+			Usage:     syncdownload.Description,
+			HelpName:  common.CreateUsage("rt sync-download", syncdownload.Description, syncdownload.Usage),
+			UsageText: syncdownload.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				syncDownloadCmd(c)
+			},
+		},
+		{
+			Name:  "spec",
+			Usage: "File Spec commands",
+			Subcommands: []cli.Command{
+				{
+					Name:      "validate",
+					Flags:     getSpecValidateFlags(),
+					Usage:     specvalidate.Description,
+					HelpName:  common.CreateUsage("rt spec validate", specvalidate.Description, specvalidate.Usage),
+					UsageText: specvalidate.Arguments,
+					ArgsUsage: common.CreateEnvVars(),
+					Action: func(c *cli.Context) {
+						specValidateCmd(c)
+					},
+				},
+				{
+					Name:      "schema",
+					Usage:     specschema.Description,
+					HelpName:  common.CreateUsage("rt spec schema", specschema.Description, specschema.Usage),
+					UsageText: specschema.Arguments,
+					ArgsUsage: common.CreateEnvVars(),
+					Action: func(c *cli.Context) {
+						specSchemaCmd(c)
+					},
+				},
+			},
+		},
 		{
 			Name:      "search",
 			Flags:     getSearchFlags(),
@@ -203,6 +271,18 @@ func GetCommands() []cli.Command {
 				buildPublishCmd(c)
 			},
 		},
+		{
+			Name:      "build-upload-file",
+			Flags:     getBuildInfoPublishFlags(),
+			Aliases:   []string{"buf"},
+			Usage:     builduploadfile.Description,
+			HelpName:  common.CreateUsage("rt build-upload-file", builduploadfile.Description, builduploadfile.Usage),
+			UsageText: builduploadfile.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildUploadFileCmd(c)
+			},
+		},
 		{
 			Name:      "build-collect-env",
 			Flags:     []cli.Flag{},
@@ -215,6 +295,30 @@ func GetCommands() []cli.Command {
 				buildCollectEnvCmd(c)
 			},
 		},
+		{
+			Name:      "build-export",
+			Flags:     []cli.Flag{},
+			Aliases:   []string{"bex"},
+			Usage:     buildexport.Description,
+			HelpName:  common.CreateUsage("rt build-export", buildexport.Description, buildexport.Usage),
+			UsageText: buildexport.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildExportCmd(c)
+			},
+		},
+		{
+			Name:      "build-import",
+			Flags:     getBuildImportFlags(),
+			Aliases:   []string{"bim"},
+			Usage:     buildimport.Description,
+			HelpName:  common.CreateUsage("rt build-import", buildimport.Description, buildimport.Usage),
+			UsageText: buildimport.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildImportCmd(c)
+			},
+		},
 		{
 			Name:      "build-add-dependencies",
 			Flags:     getBuildAddDependenciesFlags(),
@@ -299,6 +403,18 @@ func GetCommands() []cli.Command {
 				buildDiscardCmd(c)
 			},
 		},
+		{
+			Name:      "build-diff",
+			Flags:     getBuildDiffFlags(),
+			Aliases:   []string{"bdf"},
+			Usage:     builddiff.Description,
+			HelpName:  common.CreateUsage("rt build-diff", builddiff.Description, builddiff.Usage),
+			UsageText: builddiff.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				buildDiffCmd(c)
+			},
+		},
 		{
 			Name:      "git-lfs-clean",
 			Flags:     getGitLfsCleanFlags(),
@@ -379,6 +495,30 @@ func GetCommands() []cli.Command {
 				dockerPullCmd(c)
 			},
 		},
+		{
+			Name:      "docker-build",
+			Flags:     getDockerBuildFlags(),
+			Aliases:   []string{"db"},
+			Usage:     dockerbuild.Description,
+			HelpName:  common.CreateUsage("rt docker-build", dockerbuild.Description, dockerbuild.Usage),
+			UsageText: dockerbuild.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				dockerBuildCmd(c)
+			},
+		},
+		{
+			Name:      "docker-promote",
+			Flags:     getDockerPromoteFlags(),
+			Aliases:   []string{"dpr"},
+			Usage:     dockerpromote.Description,
+			HelpName:  common.CreateUsage("rt docker-promote", dockerpromote.Description, dockerpromote.Usage),
+			UsageText: dockerpromote.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) {
+				dockerPromoteCmd(c)
+			},
+		},
 		{
 			Name:      "npm-install",
 			Flags:     getNpmFlags(),
@@ -405,7 +545,7 @@ func GetCommands() []cli.Command {
 		},
 		{
 			Name:      "npm-publish",
-			Flags:     getNpmCommonFlags(),
+			Flags:     getNpmPublishFlags(),
 			Aliases:   []string{"npmp"},
 			Usage:     npmpublish.Description,
 			HelpName:  common.CreateUsage("rt npm-publish", npmpublish.Description, npmpublish.Usage),
@@ -415,6 +555,53 @@ func GetCommands() []cli.Command {
 				npmPublishCmd(c)
 			},
 		},
+		{
+			Name:      "yarn",
+			Flags:     getYarnFlags(),
+			Usage:     yarndocs.Description,
+			HelpName:  common.CreateUsage("rt yarn", yarndocs.Description, yarndocs.Usage),
+			UsageText: yarndocs.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) error {
+				return yarnCmd(c)
+			},
+		},
+		{
+			Name:      "pip-install",
+			Flags:     getPipInstallFlags(),
+			Aliases:   []string{"pipi"},
+			Usage:     pipinstall.Description,
+			HelpName:  common.CreateUsage("rt pip-install", pipinstall.Description, pipinstall.Usage),
+			UsageText: pipinstall.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) error {
+				return pipInstallCmd(c)
+			},
+		},
+		{
+			Name:      "pip-publish",
+			Flags:     getPipPublishFlags(),
+			Aliases:   []string{"pipp"},
+			Usage:     pippublish.Description,
+			HelpName:  common.CreateUsage("rt pip-publish", pippublish.Description, pippublish.Usage),
+			UsageText: pippublish.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) error {
+				return pipPublishCmd(c)
+			},
+		},
+		{
+			Name:      "pip-deps-tree",
+			Flags:     getPipDepsTreeFlags(),
+			Aliases:   []string{"pdt"},
+			Usage:     pipdepstree.Description,
+			HelpName:  common.CreateUsage("rt pip-deps-tree", pipdepstree.Description, pipdepstree.Usage),
+			UsageText: pipdepstree.Arguments,
+			ArgsUsage: common.CreateEnvVars(),
+			Action: func(c *cli.Context) error {
+				return pipDepsTreeCmd(c)
+			},
+		},
 		{
 			Name:      "nuget",
 			Flags:     getNugetFlags(),
@@ -567,6 +754,11 @@ func getServerFlags() []cli.Flag {
 	return append(getCommonFlags(), getServerIdFlag())
 }
 
+// The server flags of the commands which print their result as JSON if --format=json is set.
+func getServerAndFormatFlags() []cli.Flag {
+	return append(getServerFlags(), getFormatFlag())
+}
+
 func getSortLimitFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
@@ -589,7 +781,7 @@ func getSortLimitFlags() []cli.Flag {
 }
 
 func getUploadFlags() []cli.Flag {
-	uploadFlags := append(getServerFlags(), getSpecFlags()...)
+	uploadFlags := append(getServerAndFormatFlags(), getSpecFlags()...)
 	uploadFlags = append(uploadFlags, getBuildToolAndModuleFlags()...)
 	return append(uploadFlags, []cli.Flag{
 		cli.StringFlag{
@@ -632,11 +824,12 @@ func getUploadFlags() []cli.Flag {
 		getUploadExcludePatternsFlag(),
 		getFailNoOpFlag(),
 		getThreadsFlag(),
+		getResumeFlag("uploaded"),
 	}...)
 }
 
 func getDownloadFlags() []cli.Flag {
-	downloadFlags := append(getServerFlags(), getSortLimitFlags()...)
+	downloadFlags := append(getServerAndFormatFlags(), getSortLimitFlags()...)
 	downloadFlags = append(downloadFlags, getSpecFlags()...)
 	downloadFlags = append(downloadFlags, getBuildToolAndModuleFlags()...)
 	return append(downloadFlags, []cli.Flag{
@@ -687,6 +880,7 @@ func getDownloadFlags() []cli.Flag {
 		getExcludePatternsFlag(),
 		getThreadsFlag(),
 		getArchiveEntriesFlag(),
+		getResumeFlag("downloaded"),
 	}...)
 }
 
@@ -724,6 +918,20 @@ func getServerIdFlag() cli.Flag {
 	}
 }
 
+func getFormatFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  "format",
+		Usage: "[Default: text] Set to json to print the command result, including the affected paths and build-info modules, as a JSON object.` `",
+	}
+}
+
+func getResumeFlag(transferred string) cli.Flag {
+	return cli.BoolFlag{
+		Name:  "resume",
+		Usage: "[Default: false] Set to true to resume an interrupted run of this command. Files which were already " + transferred + " by the interrupted run, and were not modified since, are skipped.` `",
+	}
+}
+
 func getFailNoOpFlag() cli.Flag {
 	return cli.BoolFlag{
 		Name:  "fail-no-op",
@@ -745,6 +953,19 @@ func getUploadExcludePatternsFlag() cli.Flag {
 	}
 }
 
+func getSpecValidateFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "spec-vars",
+			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1} or ${key1:-default}.` `",
+		},
+		cli.StringFlag{
+			Name:  "command",
+			Usage: "[Optional] Validate the spec only for this command. Supported commands: " + strings.Join(generic.SpecValidationCommands(), ", ") + ".` `",
+		},
+	}
+}
+
 func getSpecFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
@@ -753,7 +974,7 @@ func getSpecFlags() []cli.Flag {
 		},
 		cli.StringFlag{
 			Name:  "spec-vars",
-			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1}.` `",
+			Usage: "[Optional] List of variables in the form of \"key1=value1;key2=value2;...\" to be replaced in the File Spec. In the File Spec, the variables should be used as follows: ${key1} or ${key1:-default}.` `",
 		},
 	}
 }
@@ -762,18 +983,80 @@ func getDockerPushFlags() []cli.Flag {
 	var flags []cli.Flag
 	flags = append(flags, getDockerFlags()...)
 	flags = append(flags, getThreadsFlag())
+	flags = append(flags, cli.StringFlag{
+		Name:  "source",
+		Usage: "[Optional] Path to an OCI image layout dir or a 'docker save' tarball. If set, the image is pushed through the registry API, without a Docker daemon.` `",
+	})
 	return flags
 }
 
 func getDockerPullFlags() []cli.Flag {
-	return getDockerFlags()
+	return append(getDockerFlags(), cli.StringFlag{
+		Name:  "oci-layout",
+		Usage: "[Optional] Path to a dir to pull the image into as an OCI image layout. If set, the image is pulled through the registry API, without a Docker daemon.` `",
+	})
+}
+
+func getDockerBuildFlags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, getBuildToolAndModuleFlags()...)
+	flags = append(flags, getServerAndFormatFlags()...)
+	flags = append(flags, getSkipLoginFlag(), getThreadsFlag())
+	flags = append(flags, []cli.Flag{
+		cli.StringFlag{
+			Name:  "file",
+			Usage: "[Default: <build context>/Dockerfile] Path to the Dockerfile.` `",
+		},
+		cli.StringFlag{
+			Name:  "build-context",
+			Usage: "[Default: .] Path to the docker build context.` `",
+		},
+		cli.StringFlag{
+			Name:  "docker-build-args",
+			Usage: "[Optional] A list of docker build options, such as \"--build-arg VERSION=1.0 --no-cache\". Build args are also used to resolve the base images of the Dockerfile.` `",
+		},
+		cli.StringFlag{
+			Name:  "resolver-repo",
+			Usage: "[Optional] The repository the base images are pulled from. If not set, the target repository is used.` `",
+		},
+		cli.BoolFlag{
+			Name:  "skip-build",
+			Usage: "[Default: false] Set to true to skip docker build, if the image was already built from the Dockerfile.` `",
+		},
+	}...)
+	return flags
+}
+
+func getDockerPromoteFlags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, getBuildToolAndModuleFlags()...)
+	flags = append(flags, getServerAndFormatFlags()...)
+	flags = append(flags, []cli.Flag{
+		cli.StringFlag{
+			Name:  "target-image",
+			Usage: "[Optional] The image name in the target repository. If not set, the source image name is used.` `",
+		},
+		cli.StringFlag{
+			Name:  "target-tag",
+			Usage: "[Optional] The image tag in the target repository. If not set, the source image tag is used.` `",
+		},
+		cli.BoolFlag{
+			Name:  "copy",
+			Usage: "[Default: false] Set to true to copy the image rather than move it.` `",
+		},
+	}...)
+	return flags
 }
 
 func getDockerFlags() []cli.Flag {
 	var flags []cli.Flag
 	flags = append(flags, getBuildToolAndModuleFlags()...)
-	flags = append(flags, getServerFlags()...)
+	flags = append(flags, getServerAndFormatFlags()...)
 	flags = append(flags, getSkipLoginFlag())
+	flags = append(flags, cli.BoolFlag{
+		Name:  "insecure-registry",
+		Usage: "[Default: false] Set to true to access the registry over HTTP rather than HTTPS. Applies only when the --source or --oci-layout options are used.` `",
+	})
 	return flags
 }
 
@@ -785,7 +1068,7 @@ func getNpmCommonFlags() []cli.Flag {
 		},
 	}
 	npmFlags = append(npmFlags, getBaseFlags()...)
-	npmFlags = append(npmFlags, getServerIdFlag())
+	npmFlags = append(npmFlags, getServerIdFlag(), getFormatFlag())
 	return append(npmFlags, getBuildToolAndModuleFlags()...)
 }
 
@@ -795,9 +1078,59 @@ func getNpmFlags() []cli.Flag {
 		Name:  "threads",
 		Value: "",
 		Usage: "[Default: 3] Number of working threads for build-info collection.` `",
+	}, cli.BoolFlag{
+		Name:  "use-package-lock",
+		Usage: "[Default: false] Set to true to collect the build-info dependencies and their checksums from package-lock.json, rather than by running npm ls and an AQL query per dependency. The dependency paths are also added to the build-info.` `",
+	})
+}
+
+func getNpmPublishFlags() []cli.Flag {
+	npmFlags := getNpmCommonFlags()
+	return append(npmFlags,
+		cli.BoolFlag{
+			Name:  "workspaces",
+			Usage: "[Default: false] Set to true to publish all the packages of the workspace. The packages are found using the 'workspaces' of the root package.json. Private packages and package versions which already exist in the repository are skipped.` `",
+		},
+		cli.StringFlag{
+			Name:  "workspaces-globs",
+			Usage: "[Optional] Semicolon separated glob patterns of the workspace package directories, such as 'packages/*'. Patterns starting with '!' exclude directories. Implies --workspaces.` `",
+		},
+		getThreadsFlag())
+}
+
+func getYarnFlags() []cli.Flag {
+	yarnFlags := append(getBaseFlags(), getServerIdFlag())
+	yarnFlags = append(yarnFlags, getBuildToolAndModuleFlags()...)
+	return append(yarnFlags, cli.StringFlag{
+		Name:  "threads",
+		Value: "",
+		Usage: "[Default: 3] Number of working threads for build-info collection.` `",
 	})
 }
 
+func getPipInstallFlags() []cli.Flag {
+	pipFlags := append(getBaseFlags(), getServerIdFlag())
+	return append(pipFlags, getBuildToolAndModuleFlags()...)
+}
+
+func getPipPublishFlags() []cli.Flag {
+	pipFlags := append(getBaseFlags(), getServerIdFlag(), getFormatFlag())
+	return append(pipFlags, getBuildToolAndModuleFlags()...)
+}
+
+func getPipDepsTreeFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "site-packages",
+			Usage: "[Optional] Path to the site-packages directory of the Python environment. If not set, the site-packages directories of the python executable are used.` `",
+		},
+		cli.StringFlag{
+			Name:  "requirements-file",
+			Usage: "[Default: requirements.txt if exists] Path to a requirements file. Its requirements are the roots of the tree. If no requirements file is found, the installed distributions which aren't required by other distributions are the roots.` `",
+		},
+	}
+}
+
 func getNugetFlags() []cli.Flag {
 	nugetFlags := []cli.Flag{
 		cli.StringFlag{
@@ -810,7 +1143,7 @@ func getNugetFlags() []cli.Flag {
 		},
 	}
 	nugetFlags = append(nugetFlags, getBaseFlags()...)
-	nugetFlags = append(nugetFlags, getServerIdFlag())
+	nugetFlags = append(nugetFlags, getServerIdFlag(), getFormatFlag())
 	return append(nugetFlags, getBuildToolAndModuleFlags()...)
 }
 
@@ -851,15 +1184,19 @@ func getGoPublishFlags() []cli.Flag {
 			Name:  "self",
 			Usage: "[Default: true] Set false to skip publishing the project package zip file to Artifactory..` `",
 		},
+		cli.BoolFlag{
+			Name:  "all-tags",
+			Usage: "[Default: false] Set to true to publish the versions of all the semver git tags of the module, which are missing from the target repository. The project version argument is not used in this case.` `",
+		},
 	}
 	flags = append(flags, getBaseFlags()...)
-	flags = append(flags, getServerIdFlag())
+	flags = append(flags, getServerIdFlag(), getFormatFlag())
 	flags = append(flags, getBuildToolAndModuleFlags()...)
 	return flags
 }
 
 func getMoveFlags() []cli.Flag {
-	moveFlags := append(getServerFlags(), getSortLimitFlags()...)
+	moveFlags := append(getServerAndFormatFlags(), getSortLimitFlags()...)
 	moveFlags = append(moveFlags, getSpecFlags()...)
 	return append(moveFlags, []cli.Flag{
 		cli.BoolTFlag{
@@ -887,7 +1224,7 @@ func getMoveFlags() []cli.Flag {
 }
 
 func getCopyFlags() []cli.Flag {
-	copyFlags := append(getServerFlags(), getSortLimitFlags()...)
+	copyFlags := append(getServerAndFormatFlags(), getSortLimitFlags()...)
 	copyFlags = append(copyFlags, getSpecFlags()...)
 	return append(copyFlags, []cli.Flag{
 		cli.BoolTFlag{
@@ -914,7 +1251,7 @@ func getCopyFlags() []cli.Flag {
 }
 
 func getDeleteFlags() []cli.Flag {
-	deleteFlags := append(getServerFlags(), getSortLimitFlags()...)
+	deleteFlags := append(getServerAndFormatFlags(), getSortLimitFlags()...)
 	deleteFlags = append(deleteFlags, getSpecFlags()...)
 	return append(deleteFlags, []cli.Flag{
 		cli.BoolTFlag{
@@ -940,6 +1277,51 @@ func getDeleteFlags() []cli.Flag {
 	}...)
 }
 
+func getSyncFlags(destination string) []cli.Flag {
+	return append(getServerAndFormatFlags(), []cli.Flag{
+		cli.BoolFlag{
+			Name:  "delete",
+			Usage: "[Default: false] Set to true to delete files which exist only in the " + destination + ".` `",
+		},
+		cli.BoolFlag{
+			Name:  "quiet",
+			Usage: "[Default: false] Set to true to skip the delete confirmation message.` `",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "[Default: false] Set to true to only list the files which would be transferred and deleted.` `",
+		},
+		cli.StringFlag{
+			Name:  "retries",
+			Usage: "[Default: " + strconv.Itoa(cliutils.Retries) + "] Number of transfer retries.` `",
+		},
+		getFailNoOpFlag(),
+		getThreadsFlag(),
+	}...)
+}
+
+func getSyncUploadFlags() []cli.Flag {
+	return append(getSyncFlags("target path"), cli.BoolFlag{
+		Name:  "symlinks",
+		Usage: "[Default: false] Set to true to preserve symbolic links structure in Artifactory.` `",
+	})
+}
+
+func getSyncDownloadFlags() []cli.Flag {
+	return append(getSyncFlags("local directory"), []cli.Flag{
+		cli.StringFlag{
+			Name:  "min-split",
+			Value: "",
+			Usage: "[Default: " + strconv.Itoa(cliutils.DownloadMinSplitKb) + "] Minimum file size in KB to split into ranges when downloading. Set to -1 for no splits.` `",
+		},
+		cli.StringFlag{
+			Name:  "split-count",
+			Value: "",
+			Usage: "[Default: " + strconv.Itoa(cliutils.DownloadSplitCount) + "] Number of parts to split a file when downloading. Set to 0 for no splits.` `",
+		},
+	}...)
+}
+
 func getSearchFlags() []cli.Flag {
 	searchFlags := append(getServerFlags(), getSortLimitFlags()...)
 	searchFlags = append(searchFlags, getSpecFlags()...)
@@ -984,7 +1366,7 @@ func getDeletePropertiesFlags() []cli.Flag {
 }
 
 func getPropertiesFlags() []cli.Flag {
-	propsFlags := append(getServerFlags(), getSortLimitFlags()...)
+	propsFlags := append(getServerAndFormatFlags(), getSortLimitFlags()...)
 	return append(propsFlags, []cli.Flag{
 		cli.BoolTFlag{
 			Name:  "recursive",
@@ -1021,7 +1403,14 @@ func getThreadsFlag() cli.Flag {
 }
 
 func getBuildPublishFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getBuildInfoPublishFlags(), cli.StringFlag{
+		Name:  "output-file",
+		Usage: "[Optional] Path to a file, to which the build-info is written instead of being published to Artifactory. The file can be published later using the build-upload-file command.` `",
+	})
+}
+
+func getBuildInfoPublishFlags() []cli.Flag {
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.StringFlag{
 			Name:  "build-url",
 			Usage: "[Optional] Can be used for setting the CI server build URL in the build-info.` `",
@@ -1056,11 +1445,12 @@ func getBuildAddDependenciesFlags() []cli.Flag {
 			Usage: "[Default: false] Set to true to only get a summery of the dependencies that will be added to the build info.` `",
 		},
 		getUploadExcludePatternsFlag(),
+		getFormatFlag(),
 	}...)
 }
 
 func getBuildPromotionFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.StringFlag{
 			Name:  "status",
 			Usage: "[Optional] Build promotion status.` `",
@@ -1089,7 +1479,7 @@ func getBuildPromotionFlags() []cli.Flag {
 }
 
 func getBuildDistributeFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.StringFlag{
 			Name:  "source-repos",
 			Usage: "[Optional] List of local repositories in the form of \"repo1,repo2,...\" from which build artifacts should be deployed.` `",
@@ -1118,7 +1508,7 @@ func getBuildDistributeFlags() []cli.Flag {
 }
 
 func getGitLfsCleanFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.StringFlag{
 			Name:  "refs",
 			Usage: "[Default: refs/remotes/*] List of Git references in the form of \"ref1,ref2,...\" which should be preserved.` `",
@@ -1150,6 +1540,10 @@ func getConfigFlags() []cli.Flag {
 		},
 	}
 	flags = append(flags, getBaseFlags()...)
+	flags = append(flags, cli.StringFlag{
+		Name:  "credentials-helper",
+		Usage: "[Optional] An executable which prints the server credentials in JSON format, when invoked with the server URL as an argument. If set, the credentials are not stored in the config file.` `",
+	})
 	return append(flags,
 		getSshKeyPathFlag()...)
 }
@@ -1164,7 +1558,7 @@ func getSshKeyPathFlag() []cli.Flag {
 }
 
 func getBuildDiscardFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.StringFlag{
 			Name:  "max-days",
 			Usage: "[Optional] The maximum number of days to keep builds in Artifactory.` `",
@@ -1188,12 +1582,50 @@ func getBuildDiscardFlags() []cli.Flag {
 	}...)
 }
 
+func getBuildImportFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "conflict",
+			Usage: "[Default: merge] Determines how the bundle is imported if the build was already collected locally. Set to merge to add the bundle to the local build, overwrite to replace the local build, or fail to abort the import.` `",
+		},
+	}
+}
+
+func getBuildDiffFlags() []cli.Flag {
+	return append(getServerAndFormatFlags(), []cli.Flag{
+		cli.BoolFlag{
+			Name:  "local-a",
+			Usage: "[Default: false] Set to true to read the first build from the builds collected locally and not yet published, instead of from Artifactory.` `",
+		},
+		cli.BoolFlag{
+			Name:  "local-b",
+			Usage: "[Default: false] Set to true to read the second build from the builds collected locally and not yet published, instead of from Artifactory.` `",
+		},
+	}...)
+}
+
 func getBuildScanFlags() []cli.Flag {
-	return append(getServerFlags(), []cli.Flag{
+	return append(getServerAndFormatFlags(), []cli.Flag{
 		cli.BoolTFlag{
 			Name:  "fail",
 			Usage: "[Default: true] Set to false if you do not wish the command to return exit code 3, even if the 'Fail Build' rule is matched by Xray.` `",
 		},
+		cli.StringFlag{
+			Name:  "min-severity",
+			Usage: "[Optional] Ignore violations with a lower severity. The build fails only if the violations which are not ignored match the 'Fail Build' rule. Supported values: Low, Medium, High and Critical.` `",
+		},
+		cli.StringFlag{
+			Name:  "ignore-file",
+			Usage: "[Optional] Path to a JSON file of accepted issues to ignore, in the format {\"ignore\": [{\"cve\": \"CVE-2019-10744\", \"component\": \"lodash:4.17.11\", \"expires\": \"2019-12-31\", \"reason\": \"...\"}]}. The component and expires fields are optional.` `",
+		},
+		cli.StringFlag{
+			Name:  "junit-file",
+			Usage: "[Optional] Path to a file to which a JUnit XML report of the violations is written.` `",
+		},
+		cli.StringFlag{
+			Name:  "sarif-file",
+			Usage: "[Optional] Path to a file to which a SARIF 2.1.0 report of the violations is written.` `",
+		},
 	}...)
 }
 
@@ -1382,6 +1814,8 @@ func dockerPushCmd(c *cli.Context) {
 	if c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	artDetails := createArtifactoryDetailsByFlags(c, true)
 	imageTag := c.Args().Get(0)
 	targetRepo := c.Args().Get(1)
@@ -1389,8 +1823,10 @@ func dockerPushCmd(c *cli.Context) {
 
 	buildConfiguration := createBuildToolConfiguration(c)
 	dockerPushCommand := docker.NewDockerPushCommand()
-	dockerPushCommand.SetThreads(getThreadsCount(c)).SetBuildConfiguration(buildConfiguration).SetRepo(targetRepo).SetSkipLogin(skipLogin).SetRtDetails(artDetails).SetImageTag(imageTag)
-	err := commands.Exec(dockerPushCommand)
+	dockerPushCommand.SetThreads(getThreadsCount(c)).SetSource(c.String("source")).
+		SetBuildConfiguration(buildConfiguration).SetRepo(targetRepo).SetSkipLogin(skipLogin).SetInsecureRegistry(c.Bool("insecure-registry")).SetRtDetails(artDetails).SetImageTag(imageTag)
+	err = commands.Exec(dockerPushCommand)
+	err = printResultIfNeeded(jsonFormat, dockerPushCommand, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1398,14 +1834,56 @@ func dockerPullCmd(c *cli.Context) {
 	if c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	artDetails := createArtifactoryDetailsByFlags(c, true)
 	imageTag := c.Args().Get(0)
 	sourceRepo := c.Args().Get(1)
 	skipLogin := c.Bool("skip-login")
 	buildConfiguration := createBuildToolConfiguration(c)
 	dockerPullCommand := docker.NewDockerPullCommand()
-	dockerPullCommand.SetImageTag(imageTag).SetRepo(sourceRepo).SetSkipLogin(skipLogin).SetRtDetails(artDetails).SetBuildConfiguration(buildConfiguration)
-	err := commands.Exec(dockerPullCommand)
+	dockerPullCommand.SetOciLayout(c.String("oci-layout")).
+		SetImageTag(imageTag).SetRepo(sourceRepo).SetSkipLogin(skipLogin).SetInsecureRegistry(c.Bool("insecure-registry")).SetRtDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	err = commands.Exec(dockerPullCommand)
+	err = printResultIfNeeded(jsonFormat, dockerPullCommand, err)
+	cliutils.ExitOnErr(err)
+}
+
+func dockerBuildCmd(c *cli.Context) {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	buildArgs, err := shellwords.Parse(c.String("docker-build-args"))
+	if err != nil {
+		cliutils.ExitOnErr(errorutils.CheckError(err))
+	}
+	artDetails := createArtifactoryDetailsByFlags(c, true)
+	buildConfiguration := createBuildToolConfiguration(c)
+	dockerBuildCommand := docker.NewDockerBuildCommand()
+	dockerBuildCommand.SetDockerfilePath(c.String("file")).SetContextPath(c.String("build-context")).SetBuildArgs(buildArgs).
+		SetResolverRepo(c.String("resolver-repo")).SetSkipBuild(c.Bool("skip-build")).
+		SetThreads(getThreadsCount(c)).
+		SetImageTag(c.Args().Get(0)).SetRepo(c.Args().Get(1)).SetSkipLogin(c.Bool("skip-login")).SetRtDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	err = commands.Exec(dockerBuildCommand)
+	err = printResultIfNeeded(jsonFormat, dockerBuildCommand, err)
+	cliutils.ExitOnErr(err)
+}
+
+func dockerPromoteCmd(c *cli.Context) {
+	if c.NArg() != 3 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	artDetails := createArtifactoryDetailsByFlags(c, true)
+	buildConfiguration := createBuildToolConfiguration(c)
+	dockerPromoteCommand := docker.NewDockerPromoteCommand()
+	dockerPromoteCommand.SetTargetRepo(c.Args().Get(2)).SetTargetImage(c.String("target-image")).SetTargetTag(c.String("target-tag")).SetCopy(c.Bool("copy")).
+		SetImageTag(c.Args().Get(0)).SetRepo(c.Args().Get(1)).SetRtDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	err = commands.Exec(dockerPromoteCommand)
+	err = printResultIfNeeded(jsonFormat, dockerPromoteCommand, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1413,6 +1891,8 @@ func nugetCmd(c *cli.Context) {
 	if c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	nugetCmd := nuget.NewNugetCommand()
 	buildConfiguration := createBuildToolConfiguration(c)
 	nugetCmd.SetArgs(c.Args().Get(0)).SetFlags(c.String("nuget-args")).
@@ -1421,7 +1901,8 @@ func nugetCmd(c *cli.Context) {
 		SetSolutionPath(c.String("solution-root")).
 		SetRtDetails(createArtifactoryDetailsByFlags(c, true))
 
-	err := commands.Exec(nugetCmd)
+	err = commands.Exec(nugetCmd)
+	err = printResultIfNeeded(jsonFormat, nugetCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1438,10 +1919,13 @@ func npmInstallCmd(c *cli.Context) {
 	if c.NArg() != 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	buildConfiguration := createBuildToolConfiguration(c)
 	npmCmd := npm.NewNpmInstallCommand()
-	npmCmd.SetThreads(getThreadsCount(c)).SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetNpmArgs(c.String("npm-args")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
-	err := commands.Exec(npmCmd)
+	npmCmd.SetThreads(getThreadsCount(c)).SetUsePackageLock(c.Bool("use-package-lock")).SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetNpmArgs(c.String("npm-args")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	err = commands.Exec(npmCmd)
+	err = printResultIfNeeded(jsonFormat, npmCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1449,26 +1933,93 @@ func npmCiCmd(c *cli.Context) error {
 	if c.NArg() != 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	if err != nil {
+		return err
+	}
 	buildConfiguration := createBuildToolConfiguration(c)
 	npmCmd := npm.NewNpmCiCommand()
-	npmCmd.SetThreads(getThreadsCount(c)).SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
-	return commands.Exec(npmCmd)
+	npmCmd.SetThreads(getThreadsCount(c)).SetUsePackageLock(c.Bool("use-package-lock")).SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	err = commands.Exec(npmCmd)
+	return printResultIfNeeded(jsonFormat, npmCmd, err)
 }
 
 func npmPublishCmd(c *cli.Context) {
 	if c.NArg() != 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	buildConfiguration := createBuildToolConfiguration(c)
 	npmPublicCmd := npm.NewNpmPublishCommand()
+	var workspacesGlobs []string
+	if c.String("workspaces-globs") != "" {
+		workspacesGlobs = strings.Split(c.String("workspaces-globs"), ";")
+	}
+	npmPublicCmd.SetWorkspaces(c.Bool("workspaces") || len(workspacesGlobs) > 0).SetWorkspacesGlobs(workspacesGlobs).SetThreads(getThreadsCount(c))
 	npmPublicCmd.SetBuildConfiguration(buildConfiguration).SetRepo(c.Args().Get(0)).SetNpmArgs(c.String("npm-args")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
-	err := commands.Exec(npmPublicCmd)
+	err = commands.Exec(npmPublicCmd)
+	err = printResultIfNeeded(jsonFormat, npmPublicCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
+func yarnCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	yarnArgs, err := shellwords.Parse(c.Args().Get(0))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	yarnCommand := yarn.NewYarnCommand()
+	yarnCommand.SetYarnArgs(yarnArgs).SetRepo(c.Args().Get(1)).SetThreads(getThreadsCount(c)).
+		SetBuildConfiguration(createBuildToolConfiguration(c)).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	return commands.Exec(yarnCommand)
+}
+
+func pipInstallCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	pipArgs, err := shellwords.Parse(c.Args().Get(0))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	pipInstallCommand := pip.NewPipInstallCommand()
+	pipInstallCommand.SetPipArgs(pipArgs).SetRepo(c.Args().Get(1)).
+		SetBuildConfiguration(createBuildToolConfiguration(c)).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	return commands.Exec(pipInstallCommand)
+}
+
+func pipPublishCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	if err != nil {
+		return err
+	}
+	pipPublishCommand := pip.NewPipPublishCommand()
+	pipPublishCommand.SetFilesPattern(c.Args().Get(0)).SetRepo(c.Args().Get(1)).
+		SetBuildConfiguration(createBuildToolConfiguration(c)).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	err = commands.Exec(pipPublishCommand)
+	return printResultIfNeeded(jsonFormat, pipPublishCommand, err)
+}
+
+func pipDepsTreeCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	return pip.DependencyTreeCmd(c.String("site-packages"), c.String("requirements-file"))
+}
+
 func goPublishCmd(c *cli.Context) {
+	// When "all-tags" set to true, the versions are taken from the git tags, so only the target repo is passed
+	if c.Bool("all-tags") && c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
 	// When "self" set to true (default), there must be two arguments passed: target repo and the version
-	if c.BoolT("self") && c.NArg() != 2 {
+	if !c.Bool("all-tags") && c.BoolT("self") && c.NArg() != 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 	// When "self" set to false, the target repository is mandatory but the version is not.
@@ -1477,16 +2028,17 @@ func goPublishCmd(c *cli.Context) {
 	if !c.BoolT("self") && c.NArg() > 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 
 	buildConfiguration := createBuildToolConfiguration(c)
 	targetRepo := c.Args().Get(0)
 	version := c.Args().Get(1)
 	details := createArtifactoryDetailsByFlags(c, true)
 	goPublishCmd := golang.NewGoPublishCommand()
-	goPublishCmd.SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDependencies(c.String("deps")).SetPublishPackage(c.BoolT("self")).SetTargetRepo(targetRepo).SetRtDetails(details)
-	err := commands.Exec(goPublishCmd)
-	result := goPublishCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	goPublishCmd.SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDependencies(c.String("deps")).SetAllTags(c.Bool("all-tags")).SetPublishPackage(c.BoolT("self")).SetTargetRepo(targetRepo).SetRtDetails(details)
+	err = commands.Exec(goPublishCmd)
+	err = printSummaryReport(jsonFormat, goPublishCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1622,6 +2174,23 @@ func pingCmd(c *cli.Context) {
 	log.Output(resString)
 }
 
+func specValidateCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	specValidateCmd := generic.NewSpecValidateCommand()
+	specValidateCmd.SetSpecPath(c.Args().Get(0)).SetSpecVars(cliutils.SpecVarsStringToMap(c.String("spec-vars"))).SetCommandName(c.String("command"))
+	err := commands.Exec(specValidateCmd)
+	cliutils.ExitOnErr(err)
+}
+
+func specSchemaCmd(c *cli.Context) {
+	if c.NArg() > 0 {
+		cliutils.PrintHelpAndExitWithError("No arguments should be sent.", c)
+	}
+	log.Output(spec.FileSpecSchema)
+}
+
 func downloadCmd(c *cli.Context) {
 	if c.NArg() > 0 && c.IsSet("spec") {
 		cliutils.PrintHelpAndExitWithError("No arguments should be sent when the spec option is used.", c)
@@ -1630,6 +2199,8 @@ func downloadCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	var downloadSpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		downloadSpec = getDownloadSpec(c)
@@ -1643,10 +2214,10 @@ func downloadCmd(c *cli.Context) {
 	buildConfiguration := createBuildToolConfiguration(c)
 	downloadCommand := generic.NewDownloadCommand()
 	downloadCommand.SetConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(downloadSpec).SetRtDetails(rtDetails).SetDryRun(c.Bool("dry-run"))
-	err := commands.Exec(downloadCommand)
+	err = commands.Exec(downloadCommand)
 	defer logUtils.CloseLogFile(downloadCommand.LogFile())
 	result := downloadCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, downloadCommand, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1658,6 +2229,8 @@ func uploadCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	var uploadSpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		uploadSpec = getFileSystemSpec(c, true)
@@ -1669,10 +2242,10 @@ func uploadCmd(c *cli.Context) {
 	buildConfiguration := createBuildToolConfiguration(c)
 	uploadCmd := generic.NewUploadCommand()
 	uploadCmd.SetUploadConfiguration(configuration).SetBuildConfiguration(buildConfiguration).SetSpec(uploadSpec).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetDryRun(c.Bool("dry-run"))
-	err := commands.Exec(uploadCmd)
+	err = commands.Exec(uploadCmd)
 	defer logUtils.CloseLogFile(uploadCmd.LogFile())
 	result := uploadCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, uploadCmd, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1684,6 +2257,8 @@ func moveCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	var moveSpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		moveSpec = getCopyMoveSpec(c)
@@ -1694,9 +2269,9 @@ func moveCmd(c *cli.Context) {
 
 	moveCmd := generic.NewMoveCommand()
 	moveCmd.SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetSpec(moveSpec)
-	err := commands.Exec(moveCmd)
+	err = commands.Exec(moveCmd)
 	result := moveCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, moveCmd, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1708,6 +2283,8 @@ func copyCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	var copySpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		copySpec = getCopyMoveSpec(c)
@@ -1718,9 +2295,9 @@ func copyCmd(c *cli.Context) {
 
 	copyCommand := generic.NewCopyCommand()
 	copyCommand.SetSpec(copySpec).SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
-	err := commands.Exec(copyCommand)
+	err = commands.Exec(copyCommand)
 	result := copyCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, copyCommand, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1732,6 +2309,8 @@ func deleteCmd(c *cli.Context) {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	var deleteSpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		deleteSpec = getDeleteSpec(c)
@@ -1742,9 +2321,41 @@ func deleteCmd(c *cli.Context) {
 
 	deleteCommand := generic.NewDeleteCommand()
 	deleteCommand.SetQuiet(c.Bool("quiet")).SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetSpec(deleteSpec)
-	err := commands.Exec(deleteCommand)
+	err = commands.Exec(deleteCommand)
 	result := deleteCommand.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, deleteCommand, err)
+	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+func syncUploadCmd(c *cli.Context) {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	syncUploadCommand := generic.NewSyncUploadCommand()
+	syncUploadCommand.SetUploadConfiguration(createUploadConfiguration(c))
+	syncUploadCommand.SetLocalDir(c.Args().Get(0)).SetRemotePath(c.Args().Get(1)).SetDeleteExtras(c.Bool("delete")).SetQuiet(c.Bool("quiet")).
+		SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	err = commands.Exec(syncUploadCommand)
+	result := syncUploadCommand.Result()
+	err = printSummaryReport(jsonFormat, syncUploadCommand, err)
+	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
+}
+
+func syncDownloadCmd(c *cli.Context) {
+	if c.NArg() != 2 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	syncDownloadCommand := generic.NewSyncDownloadCommand()
+	syncDownloadCommand.SetDownloadConfiguration(createDownloadConfiguration(c))
+	syncDownloadCommand.SetLocalDir(c.Args().Get(1)).SetRemotePath(c.Args().Get(0)).SetDeleteExtras(c.Bool("delete")).SetQuiet(c.Bool("quiet")).
+		SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	err = commands.Exec(syncDownloadCommand)
+	result := syncDownloadCommand.Result()
+	err = printSummaryReport(jsonFormat, syncDownloadCommand, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
@@ -1776,27 +2387,51 @@ func searchCmd(c *cli.Context) {
 
 func setPropsCmd(c *cli.Context) {
 	validatePropsCommand(c)
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	propsCmd := generic.NewSetPropsCommand().SetPropsCommand(*createPropsCommand(c))
-	err := commands.Exec(propsCmd)
+	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, propsCmd, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
 func deletePropsCmd(c *cli.Context) {
 	validatePropsCommand(c)
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	propsCmd := generic.NewDeletePropsCommand().SetPropsCommand(*createPropsCommand(c))
-	err := commands.Exec(propsCmd)
+	err = commands.Exec(propsCmd)
 	result := propsCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, propsCmd, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
 func buildPublishCmd(c *cli.Context) {
 	validateBuildInfoArgument(c)
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	configuration := createBuildInfoConfiguration(c)
-	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetBuildConfiguration(createBuildConfiguration(c)).SetConfig(configuration)
-	err := commands.Exec(buildPublishCmd)
+	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetBuildConfiguration(createBuildConfiguration(c)).SetConfig(configuration).SetOutputFile(c.String("output-file"))
+	// Writing the build-info to a file doesn't require an Artifactory server, which may not be configured on air-gapped machines.
+	if !c.IsSet("output-file") || c.IsSet("server-id") || c.IsSet("url") {
+		buildPublishCmd.SetRtDetails(createArtifactoryDetailsByFlags(c, true))
+	}
+	err = commands.Exec(buildPublishCmd)
+	err = printResultIfNeeded(jsonFormat, buildPublishCmd, err)
+	cliutils.ExitOnErr(err)
+}
+
+func buildUploadFileCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	configuration := createBuildInfoConfiguration(c)
+	buildUploadFileCmd := buildinfo.NewBuildUploadFileCommand().SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetConfig(configuration).SetFilePath(c.Args().Get(0))
+	err = commands.Exec(buildUploadFileCmd)
+	err = printResultIfNeeded(jsonFormat, buildUploadFileCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1808,6 +2443,10 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
 
+	jsonFormat, err := isJsonFormat(c)
+	if err != nil {
+		return err
+	}
 	var dependenciesSpec *spec.SpecFiles
 	if c.IsSet("spec") {
 		dependenciesSpec = getFileSystemSpec(c, false)
@@ -1817,9 +2456,9 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 	fixWinPathsForFileSystemSourcedCmds(dependenciesSpec, c)
 	buildConfiguration := createBuildConfiguration(c)
 	buildAddDependenciesCmd := buildinfo.NewBuildAddDependenciesCommand().SetDryRun(c.Bool("dry-run")).SetBuildConfiguration(buildConfiguration).SetDependenciesSpec(dependenciesSpec)
-	err := commands.Exec(buildAddDependenciesCmd)
+	err = commands.Exec(buildAddDependenciesCmd)
 	result := buildAddDependenciesCmd.Result()
-	err = cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+	err = printSummaryReport(jsonFormat, buildAddDependenciesCmd, err)
 	cliutils.FailNoOp(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 	return nil
 }
@@ -1831,6 +2470,26 @@ func buildCollectEnvCmd(c *cli.Context) {
 	cliutils.ExitOnErr(err)
 }
 
+func buildExportCmd(c *cli.Context) {
+	if c.NArg() != 3 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	buildExportCmd := buildinfo.NewBuildExportCommand().SetBuildConfiguration(createBuildConfiguration(c)).SetBundlePath(c.Args().Get(2))
+	err := commands.Exec(buildExportCmd)
+	cliutils.ExitOnErr(err)
+}
+
+func buildImportCmd(c *cli.Context) {
+	if c.NArg() != 1 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	conflictPolicy, err := utils.GetImportConflictPolicy(c.String("conflict"))
+	cliutils.ExitOnErr(err)
+	buildImportCmd := buildinfo.NewBuildImportCommand().SetBundlePath(c.Args().Get(0)).SetConflictPolicy(conflictPolicy)
+	err = commands.Exec(buildImportCmd)
+	cliutils.ExitOnErr(err)
+}
+
 func buildAddGitCmd(c *cli.Context) error {
 	if c.NArg() > 3 || c.NArg() < 2 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
@@ -1845,9 +2504,18 @@ func buildAddGitCmd(c *cli.Context) error {
 
 func buildScanCmd(c *cli.Context) {
 	validateBuildInfoArgument(c)
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	rtDetails := createArtifactoryDetailsByFlags(c, true)
-	buildScanCmd := buildinfo.NewBuildScanCommand().SetRtDetails(rtDetails).SetFailBuild(c.BoolT("fail")).SetBuildConfiguration(createBuildConfiguration(c))
-	err := commands.Exec(buildScanCmd)
+	minSeverity := xray.Unknown
+	if c.String("min-severity") != "" {
+		var err error
+		minSeverity, err = xray.ParseSeverity(c.String("min-severity"))
+		cliutils.ExitOnErr(err)
+	}
+	buildScanCmd := buildinfo.NewBuildScanCommand().SetRtDetails(rtDetails).SetFailBuild(c.BoolT("fail")).SetBuildConfiguration(createBuildConfiguration(c)).
+		SetMinSeverity(minSeverity).SetIgnoreFile(c.String("ignore-file")).SetJUnitFile(c.String("junit-file")).SetSarifFile(c.String("sarif-file")).SetJsonOutput(jsonFormat)
+	err = commands.Exec(buildScanCmd)
 	cliutils.ExitBuildScan(buildScanCmd.BuildFailed(), err)
 }
 
@@ -1862,9 +2530,12 @@ func buildPromoteCmd(c *cli.Context) {
 	if c.NArg() != 3 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	configuration := createBuildPromoteConfiguration(c)
 	buildPromotionCmd := buildinfo.NewBuildPromotionCommand().SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetPromotionParams(configuration)
-	err := commands.Exec(buildPromotionCmd)
+	err = commands.Exec(buildPromotionCmd)
+	err = printResultIfNeeded(jsonFormat, buildPromotionCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1872,9 +2543,12 @@ func buildDistributeCmd(c *cli.Context) {
 	if c.NArg() != 3 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	configuration := createBuildDistributionConfiguration(c)
 	buildDistributeCmd := buildinfo.NewBuildDistributeCommnad().SetDryRun(c.Bool("dry-run")).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetBuildDistributionParams(configuration)
-	err := commands.Exec(buildDistributeCmd)
+	err = commands.Exec(buildDistributeCmd)
+	err = printResultIfNeeded(jsonFormat, buildDistributeCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1882,21 +2556,54 @@ func buildDiscardCmd(c *cli.Context) {
 	if c.NArg() != 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	configuration := createBuildDiscardConfiguration(c)
 	buildDiscardCmd := buildinfo.NewBuildDiscardCommand()
 	buildDiscardCmd.SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetDiscardBuildsParams(configuration)
-	err := commands.Exec(buildDiscardCmd)
+	err = commands.Exec(buildDiscardCmd)
+	err = printResultIfNeeded(jsonFormat, buildDiscardCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
+func buildDiffCmd(c *cli.Context) {
+	if c.NArg() != 3 && c.NArg() != 4 {
+		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
+	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
+	buildA := &utils.BuildConfiguration{BuildName: c.Args().Get(0), BuildNumber: c.Args().Get(1)}
+	buildB := &utils.BuildConfiguration{BuildName: buildA.BuildName, BuildNumber: c.Args().Get(2)}
+	if c.NArg() == 4 {
+		buildB = &utils.BuildConfiguration{BuildName: c.Args().Get(2), BuildNumber: c.Args().Get(3)}
+	}
+	var rtDetails *config.ArtifactoryDetails
+	if !c.Bool("local-a") || !c.Bool("local-b") {
+		rtDetails = createArtifactoryDetailsByFlags(c, true)
+	}
+	buildDiffCmd := buildinfo.NewBuildDiffCommand().SetRtDetails(rtDetails).SetBuildA(buildA).SetBuildB(buildB).SetLocalA(c.Bool("local-a")).SetLocalB(c.Bool("local-b"))
+	err = commands.Exec(buildDiffCmd)
+	cliutils.ExitOnErr(err)
+	if !jsonFormat {
+		log.Output(buildDiffCmd.Diff().String())
+		return
+	}
+	content, err := json.Marshal(buildDiffCmd.Diff())
+	cliutils.ExitOnErr(errorutils.CheckError(err))
+	log.Output(clientutils.IndentJson(content))
+}
+
 func gitLfsCleanCmd(c *cli.Context) {
 	if c.NArg() > 1 {
 		cliutils.PrintHelpAndExitWithError("Wrong number of arguments.", c)
 	}
+	jsonFormat, err := isJsonFormat(c)
+	cliutils.ExitOnErr(err)
 	configuration := createGitLfsCleanConfiguration(c)
 	gitLfsCmd := generic.NewGitLfsCommand()
 	gitLfsCmd.SetConfiguration(configuration).SetRtDetails(createArtifactoryDetailsByFlags(c, true)).SetDryRun(c.Bool("dry-run"))
-	err := commands.Exec(gitLfsCmd)
+	err = commands.Exec(gitLfsCmd)
+	err = printResultIfNeeded(jsonFormat, gitLfsCmd, err)
 	cliutils.ExitOnErr(err)
 }
 
@@ -1970,6 +2677,7 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 	details.SshKeyPath = c.String("ssh-key-path")
 	details.SshPassphrase = c.String("ssh-passphrase")
 	details.AccessToken = c.String("access-token")
+	details.CredentialsHelper = c.String("credentials-helper")
 	details.ServerId = c.String("server-id")
 	details.InsecureTls = c.Bool("insecure-tls")
 
@@ -1988,6 +2696,7 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 		}
 
 		if !isAuthMethodSet(details) {
+			explicitCredentials := details.HasCredentials()
 			if details.ApiKey == "" {
 				details.ApiKey = confDetails.ApiKey
 			}
@@ -2003,6 +2712,10 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 			if details.AccessToken == "" {
 				details.AccessToken = confDetails.AccessToken
 			}
+			// Credentials given in the command line aren't replaced by the credentials helper.
+			if details.CredentialsHelper == "" && !explicitCredentials {
+				details.CredentialsHelper = confDetails.CredentialsHelper
+			}
 		}
 	}
 	details.Url = clientutils.AddTrailingSlashIfNeeded(details.Url)
@@ -2012,11 +2725,11 @@ func createArtifactoryDetails(c *cli.Context, includeConfig bool) (details *conf
 func credentialsChanged(details *config.ArtifactoryDetails) bool {
 	return details.Url != "" || details.User != "" || details.Password != "" ||
 		details.ApiKey != "" || details.SshKeyPath != "" || details.SshAuthHeaderSet() ||
-		details.AccessToken != ""
+		details.AccessToken != "" || details.CredentialsHelper != ""
 }
 
 func isAuthMethodSet(details *config.ArtifactoryDetails) bool {
-	return (details.User != "" && details.Password != "") || details.SshKeyPath != "" || details.ApiKey != "" || details.AccessToken != ""
+	return (details.User != "" && details.Password != "") || details.SshKeyPath != "" || details.ApiKey != "" || details.AccessToken != "" || details.CredentialsHelper != ""
 }
 
 func getDebFlag(c *cli.Context) (deb string) {
@@ -2141,7 +2854,7 @@ func createBuildInfoConfiguration(c *cli.Context) *buildinfocmd.Configuration {
 	}
 	// Allow to use `env-exclude=""` and get no filters
 	if !c.IsSet("env-exclude") {
-		flags.EnvExclude = "*password*;*secret*;*key*;*token*"
+		flags.EnvExclude = buildinfo.DefaultEnvExclude
 	}
 	return flags
 }
@@ -2242,6 +2955,7 @@ func createDownloadConfiguration(c *cli.Context) (downloadConfiguration *utils.D
 	downloadConfiguration.Threads = getThreadsCount(c)
 	downloadConfiguration.Retries = getRetries(c)
 	downloadConfiguration.Symlink = true
+	downloadConfiguration.Resume = c.Bool("resume")
 	return
 }
 
@@ -2324,6 +3038,7 @@ func createUploadConfiguration(c *cli.Context) (uploadConfiguration *utils.Uploa
 	uploadConfiguration.Retries = getRetries(c)
 	uploadConfiguration.Threads = getThreadsCount(c)
 	uploadConfiguration.Deb = getDebFlag(c)
+	uploadConfiguration.Resume = c.Bool("resume")
 	return
 }
 
@@ -2443,6 +3158,38 @@ func getIntValue(key string, c *cli.Context) int {
 	return value
 }
 
+// Print the summary report of the command.
+// If the --format option is set to json, the report also includes the affected paths and build-info modules.
+// The given error will pass through and be returned as is if no other errors are raised.
+func printSummaryReport(jsonFormat bool, command commands.ResultCommand, err error) error {
+	if jsonFormat {
+		return commands.PrintResult(command, err)
+	}
+	result := command.Result()
+	return cliutils.PrintSummaryReport(result.SuccessCount(), result.FailCount(), err)
+}
+
+// Print the command result as a JSON object, if the --format option is set to json.
+// The given error will pass through and be returned as is if no other errors are raised.
+func printResultIfNeeded(jsonFormat bool, command commands.Command, err error) error {
+	if jsonFormat {
+		return commands.PrintResult(command, err)
+	}
+	return err
+}
+
+// Returns true if the --format option is set to json.
+// Should be called before running the command, so that an unsupported value fails the command before it makes any change.
+func isJsonFormat(c *cli.Context) (bool, error) {
+	switch strings.ToLower(c.String("format")) {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	}
+	return false, errorutils.CheckError(errors.New("The --format option accepts the following values: text, json."))
+}
+
 func isFailNoOp(context *cli.Context) bool {
 	if context == nil {
 		return false
@@ -1,6 +1,7 @@
 package buildinfo
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -9,10 +10,16 @@ import (
 type BuildDiscardCommand struct {
 	rtDetails *config.ArtifactoryDetails
 	services.DiscardBuildsParams
+	result *commandsutils.Result
 }
 
 func NewBuildDiscardCommand() *BuildDiscardCommand {
-	return &BuildDiscardCommand{}
+	return &BuildDiscardCommand{result: new(commandsutils.Result)}
+}
+
+// The discard request is counted as a single item, since Artifactory doesn't return the discarded builds.
+func (buildDiscard *BuildDiscardCommand) Result() *commandsutils.Result {
+	return buildDiscard.result
 }
 
 func (buildDiscard *BuildDiscardCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildDiscardCommand {
@@ -30,7 +37,18 @@ func (buildDiscard *BuildDiscardCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return servicesManager.DiscardBuilds(buildDiscard.DiscardBuildsParams)
+	return countBuild(buildDiscard.result, servicesManager.DiscardBuilds(buildDiscard.DiscardBuildsParams))
+}
+
+// Counts the build the command was applied to as a success or a failure, according to the error.
+// The error is returned as is.
+func countBuild(result *commandsutils.Result, err error) error {
+	if err != nil {
+		result.SetFailCount(1)
+		return err
+	}
+	result.SetSuccessCount(1)
+	return nil
 }
 
 func (buildDiscard *BuildDiscardCommand) RtDetails() (*config.ArtifactoryDetails, error) {
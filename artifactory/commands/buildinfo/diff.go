@@ -0,0 +1,426 @@
+package buildinfo
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"sort"
+	"strings"
+)
+
+// Compares two builds - published or collected locally and not yet published.
+type BuildDiffCommand struct {
+	rtDetails *config.ArtifactoryDetails
+	buildA    *utils.BuildConfiguration
+	buildB    *utils.BuildConfiguration
+	localA    bool
+	localB    bool
+	diff      *BuildDiff
+}
+
+func NewBuildDiffCommand() *BuildDiffCommand {
+	return &BuildDiffCommand{}
+}
+
+func (bdc *BuildDiffCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildDiffCommand {
+	bdc.rtDetails = rtDetails
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetBuildA(buildA *utils.BuildConfiguration) *BuildDiffCommand {
+	bdc.buildA = buildA
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) SetBuildB(buildB *utils.BuildConfiguration) *BuildDiffCommand {
+	bdc.buildB = buildB
+	return bdc
+}
+
+// Read the first build from the local build dir, instead of fetching it from Artifactory.
+func (bdc *BuildDiffCommand) SetLocalA(localA bool) *BuildDiffCommand {
+	bdc.localA = localA
+	return bdc
+}
+
+// Read the second build from the local build dir, instead of fetching it from Artifactory.
+func (bdc *BuildDiffCommand) SetLocalB(localB bool) *BuildDiffCommand {
+	bdc.localB = localB
+	return bdc
+}
+
+func (bdc *BuildDiffCommand) Diff() *BuildDiff {
+	return bdc.diff
+}
+
+func (bdc *BuildDiffCommand) CommandName() string {
+	return "rt_build_diff"
+}
+
+func (bdc *BuildDiffCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	if bdc.localA && bdc.localB {
+		return nil, nil
+	}
+	return bdc.rtDetails, nil
+}
+
+func (bdc *BuildDiffCommand) Run() error {
+	buildInfoA, err := bdc.getBuildInfo(bdc.buildA, bdc.localA)
+	if err != nil {
+		return err
+	}
+	buildInfoB, err := bdc.getBuildInfo(bdc.buildB, bdc.localB)
+	if err != nil {
+		return err
+	}
+	bdc.diff = DiffBuildInfo(buildInfoA, buildInfoB)
+	return nil
+}
+
+func (bdc *BuildDiffCommand) getBuildInfo(buildConfiguration *utils.BuildConfiguration, local bool) (*buildinfo.BuildInfo, error) {
+	if !local {
+		return utils.GetBuildInfoFromArtifactory(bdc.rtDetails, buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	}
+	exists, err := utils.LocalBuildExists(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckError(fmt.Errorf("Build %s/%s was not found locally.", buildConfiguration.BuildName, buildConfiguration.BuildNumber))
+	}
+	snapshot, err := utils.ReadBuildSnapshot(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	if err != nil {
+		return nil, err
+	}
+	// The local build is rendered as build-publish renders it by default, so that it can be compared with a published build.
+	publishCommand := NewBuildPublishCommand().SetBuildConfiguration(buildConfiguration).SetConfig(NewDefaultConfiguration()).SetRtDetails(bdc.rtDetails)
+	return publishCommand.createBuildInfo(snapshot)
+}
+
+type BuildDiff struct {
+	BuildA     string          `json:"buildA"`
+	BuildB     string          `json:"buildB"`
+	Modules    []ModuleDiff    `json:"modules,omitempty"`
+	Properties *PropertiesDiff `json:"properties,omitempty"`
+	Vcs        *VcsDiff        `json:"vcs,omitempty"`
+}
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+type ModuleDiff struct {
+	Id           string    `json:"id"`
+	Status       string    `json:"status"`
+	Artifacts    ItemsDiff `json:"artifacts"`
+	Dependencies ItemsDiff `json:"dependencies"`
+}
+
+type ItemsDiff struct {
+	Added   []DiffItem       `json:"added,omitempty"`
+	Removed []DiffItem       `json:"removed,omitempty"`
+	Changed []DiffItemChange `json:"changed,omitempty"`
+}
+
+func (id ItemsDiff) isEmpty() bool {
+	return len(id.Added) == 0 && len(id.Removed) == 0 && len(id.Changed) == 0
+}
+
+// An artifact or a dependency. The version is set only for dependencies, whose ids end with their version.
+type DiffItem struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Sha1    string `json:"sha1,omitempty"`
+}
+
+type DiffItemChange struct {
+	Name string   `json:"name"`
+	From DiffItem `json:"from"`
+	To   DiffItem `json:"to"`
+}
+
+type PropertiesDiff struct {
+	Added   map[string]string      `json:"added,omitempty"`
+	Removed map[string]string      `json:"removed,omitempty"`
+	Changed map[string]ValueChange `json:"changed,omitempty"`
+}
+
+type ValueChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type VcsDiff struct {
+	Url      *ValueChange `json:"url,omitempty"`
+	Revision *ValueChange `json:"revision,omitempty"`
+}
+
+func (bd *BuildDiff) IsEmpty() bool {
+	return len(bd.Modules) == 0 && bd.Properties == nil && bd.Vcs == nil
+}
+
+// Compares the modules, properties and VCS details of two build-infos.
+func DiffBuildInfo(buildInfoA, buildInfoB *buildinfo.BuildInfo) *BuildDiff {
+	diff := &BuildDiff{BuildA: buildInfoA.Name + "/" + buildInfoA.Number, BuildB: buildInfoB.Name + "/" + buildInfoB.Number}
+	modulesA := modulesById(buildInfoA.Modules)
+	modulesB := modulesById(buildInfoB.Modules)
+	for _, id := range sortedUnion(moduleIds(modulesA), moduleIds(modulesB)) {
+		moduleA, inA := modulesA[id]
+		moduleB, inB := modulesB[id]
+		moduleDiff := ModuleDiff{Id: id, Status: DiffChanged}
+		switch {
+		case !inA:
+			moduleDiff.Status = DiffAdded
+		case !inB:
+			moduleDiff.Status = DiffRemoved
+		}
+		moduleDiff.Artifacts = diffItems(artifactItems(moduleA.Artifacts), artifactItems(moduleB.Artifacts))
+		moduleDiff.Dependencies = diffDependencies(moduleA.Dependencies, moduleB.Dependencies)
+		if moduleDiff.Status != DiffChanged || !moduleDiff.Artifacts.isEmpty() || !moduleDiff.Dependencies.isEmpty() {
+			diff.Modules = append(diff.Modules, moduleDiff)
+		}
+	}
+	diff.Properties = diffProperties(buildInfoA.Properties, buildInfoB.Properties)
+	diff.Vcs = diffVcs(buildInfoA.Vcs, buildInfoB.Vcs)
+	return diff
+}
+
+func modulesById(modules []buildinfo.Module) map[string]buildinfo.Module {
+	result := make(map[string]buildinfo.Module)
+	for _, module := range modules {
+		result[module.Id] = module
+	}
+	return result
+}
+
+func artifactItems(artifacts []buildinfo.Artifact) map[string]DiffItem {
+	items := make(map[string]DiffItem)
+	for _, artifact := range artifacts {
+		items[artifact.Name] = DiffItem{Name: artifact.Name, Sha1: getSha1(artifact.Checksum)}
+	}
+	return items
+}
+
+// Dependencies are matched by their full id, since a module may depend on several versions of the same package.
+// If a single version of a package was removed and a single other version was added, it is reported as a change, rather than as an addition and a removal.
+func diffDependencies(dependenciesA, dependenciesB []buildinfo.Dependency) ItemsDiff {
+	diff := diffItems(dependencyItems(dependenciesA), dependencyItems(dependenciesB))
+	removedByName := make(map[string][]DiffItem)
+	for _, item := range diff.Removed {
+		removedByName[item.Name] = append(removedByName[item.Name], item)
+	}
+	addedByName := make(map[string][]DiffItem)
+	for _, item := range diff.Added {
+		addedByName[item.Name] = append(addedByName[item.Name], item)
+	}
+	result := ItemsDiff{Changed: diff.Changed}
+	for _, item := range diff.Removed {
+		if len(removedByName[item.Name]) != 1 || len(addedByName[item.Name]) != 1 {
+			result.Removed = append(result.Removed, item)
+		}
+	}
+	for _, item := range diff.Added {
+		if len(removedByName[item.Name]) != 1 || len(addedByName[item.Name]) != 1 {
+			result.Added = append(result.Added, item)
+			continue
+		}
+		result.Changed = append(result.Changed, DiffItemChange{Name: item.Name, From: removedByName[item.Name][0], To: item})
+	}
+	sort.SliceStable(result.Changed, func(i, j int) bool {
+		if result.Changed[i].Name != result.Changed[j].Name {
+			return result.Changed[i].Name < result.Changed[j].Name
+		}
+		return result.Changed[i].From.Version < result.Changed[j].From.Version
+	})
+	return result
+}
+
+// Returns the dependencies by their ids. The version, which is the last part of the id, is kept separately from the name.
+func dependencyItems(dependencies []buildinfo.Dependency) map[string]DiffItem {
+	items := make(map[string]DiffItem)
+	for _, dependency := range dependencies {
+		name, version := dependency.Id, ""
+		if index := strings.LastIndex(dependency.Id, ":"); index > 0 {
+			name, version = dependency.Id[:index], dependency.Id[index+1:]
+		}
+		items[dependency.Id] = DiffItem{Name: name, Version: version, Sha1: getSha1(dependency.Checksum)}
+	}
+	return items
+}
+
+func getSha1(checksum *buildinfo.Checksum) string {
+	if checksum == nil {
+		return ""
+	}
+	return checksum.Sha1
+}
+
+func diffItems(itemsA, itemsB map[string]DiffItem) ItemsDiff {
+	var diff ItemsDiff
+	for _, key := range sortedUnion(itemKeys(itemsA), itemKeys(itemsB)) {
+		itemA, inA := itemsA[key]
+		itemB, inB := itemsB[key]
+		switch {
+		case !inA:
+			diff.Added = append(diff.Added, itemB)
+		case !inB:
+			diff.Removed = append(diff.Removed, itemA)
+		case itemA != itemB:
+			diff.Changed = append(diff.Changed, DiffItemChange{Name: itemA.Name, From: itemA, To: itemB})
+		}
+	}
+	return diff
+}
+
+func diffProperties(propertiesA, propertiesB buildinfo.Env) *PropertiesDiff {
+	diff := &PropertiesDiff{Added: map[string]string{}, Removed: map[string]string{}, Changed: map[string]ValueChange{}}
+	for key, valueA := range propertiesA {
+		valueB, ok := propertiesB[key]
+		if !ok {
+			diff.Removed[key] = valueA
+		} else if valueA != valueB {
+			diff.Changed[key] = ValueChange{From: valueA, To: valueB}
+		}
+	}
+	for key, valueB := range propertiesB {
+		if _, ok := propertiesA[key]; !ok {
+			diff.Added[key] = valueB
+		}
+	}
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		return nil
+	}
+	return diff
+}
+
+func diffVcs(vcsA, vcsB *buildinfo.Vcs) *VcsDiff {
+	if vcsA == nil {
+		vcsA = &buildinfo.Vcs{}
+	}
+	if vcsB == nil {
+		vcsB = &buildinfo.Vcs{}
+	}
+	diff := &VcsDiff{}
+	if vcsA.Url != vcsB.Url {
+		diff.Url = &ValueChange{From: vcsA.Url, To: vcsB.Url}
+	}
+	if vcsA.Revision != vcsB.Revision {
+		diff.Revision = &ValueChange{From: vcsA.Revision, To: vcsB.Revision}
+	}
+	if diff.Url == nil && diff.Revision == nil {
+		return nil
+	}
+	return diff
+}
+
+// Returns the sorted union of both key lists.
+func sortedUnion(keysA, keysB []string) []string {
+	keys := make(map[string]bool)
+	for _, key := range append(keysA, keysB...) {
+		keys[key] = true
+	}
+	var sorted []string
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func moduleIds(modules map[string]buildinfo.Module) []string {
+	var ids []string
+	for id := range modules {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func itemKeys(items map[string]DiffItem) []string {
+	var keys []string
+	for key := range items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Returns a human-readable representation of the diff, similar to a unified diff.
+func (bd *BuildDiff) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", bd.BuildA, bd.BuildB))
+	if bd.IsEmpty() {
+		sb.WriteString("The builds are identical.\n")
+		return sb.String()
+	}
+	for _, module := range bd.Modules {
+		sb.WriteString(fmt.Sprintf("\nModule %s (%s)\n", module.Id, module.Status))
+		writeItemsDiff(&sb, "Artifacts", module.Artifacts)
+		writeItemsDiff(&sb, "Dependencies", module.Dependencies)
+	}
+	if bd.Properties != nil {
+		sb.WriteString("\nProperties\n")
+		for _, key := range sortedKeys(bd.Properties.Added) {
+			sb.WriteString(fmt.Sprintf("  + %s=%s\n", key, bd.Properties.Added[key]))
+		}
+		for _, key := range sortedKeys(bd.Properties.Removed) {
+			sb.WriteString(fmt.Sprintf("  - %s=%s\n", key, bd.Properties.Removed[key]))
+		}
+		var changed []string
+		for key := range bd.Properties.Changed {
+			changed = append(changed, key)
+		}
+		sort.Strings(changed)
+		for _, key := range changed {
+			sb.WriteString(fmt.Sprintf("  ~ %s: %s -> %s\n", key, bd.Properties.Changed[key].From, bd.Properties.Changed[key].To))
+		}
+	}
+	if bd.Vcs != nil {
+		sb.WriteString("\nVCS\n")
+		if bd.Vcs.Url != nil {
+			sb.WriteString(fmt.Sprintf("  ~ url: %s -> %s\n", bd.Vcs.Url.From, bd.Vcs.Url.To))
+		}
+		if bd.Vcs.Revision != nil {
+			sb.WriteString(fmt.Sprintf("  ~ revision: %s -> %s\n", bd.Vcs.Revision.From, bd.Vcs.Revision.To))
+		}
+	}
+	return sb.String()
+}
+
+func writeItemsDiff(sb *strings.Builder, title string, diff ItemsDiff) {
+	if diff.isEmpty() {
+		return
+	}
+	sb.WriteString("  " + title + "\n")
+	for _, item := range diff.Added {
+		sb.WriteString("    + " + item.String() + "\n")
+	}
+	for _, item := range diff.Removed {
+		sb.WriteString("    - " + item.String() + "\n")
+	}
+	for _, change := range diff.Changed {
+		sb.WriteString(fmt.Sprintf("    ~ %s -> %s\n", change.From.String(), change.To.String()))
+	}
+}
+
+func (di DiffItem) String() string {
+	s := di.Name
+	if di.Version != "" {
+		s += ":" + di.Version
+	}
+	if di.Sha1 != "" {
+		s += " (sha1: " + di.Sha1 + ")"
+	}
+	return s
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
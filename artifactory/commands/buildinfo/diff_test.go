@@ -0,0 +1,142 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffBuildInfo(t *testing.T) {
+	buildInfoA := &buildinfo.BuildInfo{
+		Name:   "build",
+		Number: "41",
+		Modules: []buildinfo.Module{
+			{
+				Id:           "app",
+				Artifacts:    []buildinfo.Artifact{{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: "a1"}}, {Name: "old.jar", Checksum: &buildinfo.Checksum{Sha1: "o1"}}},
+				Dependencies: []buildinfo.Dependency{{Id: "org:lib:1.0", Checksum: &buildinfo.Checksum{Sha1: "l1"}}, {Id: "org:same:1.0", Checksum: &buildinfo.Checksum{Sha1: "s1"}}},
+			},
+			{Id: "removed"},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.A": "1", "buildInfo.env.B": "2"},
+		Vcs:        &buildinfo.Vcs{Url: "https://git/repo", Revision: "r41"},
+	}
+	buildInfoB := &buildinfo.BuildInfo{
+		Name:   "build",
+		Number: "42",
+		Modules: []buildinfo.Module{
+			{
+				Id:           "app",
+				Artifacts:    []buildinfo.Artifact{{Name: "app.jar", Checksum: &buildinfo.Checksum{Sha1: "a2"}}, {Name: "new.jar", Checksum: &buildinfo.Checksum{Sha1: "n2"}}},
+				Dependencies: []buildinfo.Dependency{{Id: "org:lib:1.1", Checksum: &buildinfo.Checksum{Sha1: "l2"}}, {Id: "org:same:1.0", Checksum: &buildinfo.Checksum{Sha1: "s1"}}},
+			},
+			{Id: "added", Artifacts: []buildinfo.Artifact{{Name: "added.zip"}}},
+		},
+		Properties: buildinfo.Env{"buildInfo.env.A": "1", "buildInfo.env.B": "3", "buildInfo.env.C": "4"},
+		Vcs:        &buildinfo.Vcs{Url: "https://git/repo", Revision: "r42"},
+	}
+
+	expected := &BuildDiff{
+		BuildA: "build/41",
+		BuildB: "build/42",
+		Modules: []ModuleDiff{
+			{Id: "added", Status: DiffAdded, Artifacts: ItemsDiff{Added: []DiffItem{{Name: "added.zip"}}}},
+			{
+				Id:     "app",
+				Status: DiffChanged,
+				Artifacts: ItemsDiff{
+					Added:   []DiffItem{{Name: "new.jar", Sha1: "n2"}},
+					Removed: []DiffItem{{Name: "old.jar", Sha1: "o1"}},
+					Changed: []DiffItemChange{{Name: "app.jar", From: DiffItem{Name: "app.jar", Sha1: "a1"}, To: DiffItem{Name: "app.jar", Sha1: "a2"}}},
+				},
+				Dependencies: ItemsDiff{
+					Changed: []DiffItemChange{{Name: "org:lib", From: DiffItem{Name: "org:lib", Version: "1.0", Sha1: "l1"}, To: DiffItem{Name: "org:lib", Version: "1.1", Sha1: "l2"}}},
+				},
+			},
+			{Id: "removed", Status: DiffRemoved},
+		},
+		Properties: &PropertiesDiff{
+			Added:   map[string]string{"buildInfo.env.C": "4"},
+			Removed: map[string]string{},
+			Changed: map[string]ValueChange{"buildInfo.env.B": {From: "2", To: "3"}},
+		},
+		Vcs: &VcsDiff{Revision: &ValueChange{From: "r41", To: "r42"}},
+	}
+	actual := DiffBuildInfo(buildInfoA, buildInfoB)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("Expected:\n%+v\nGot:\n%+v", expected, actual)
+	}
+}
+
+func TestDiffIdenticalBuildInfo(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{
+		Name:    "build",
+		Number:  "1",
+		Modules: []buildinfo.Module{{Id: "app", Artifacts: []buildinfo.Artifact{{Name: "app.jar"}}}},
+	}
+	diff := DiffBuildInfo(buildInfo, buildInfo)
+	if !diff.IsEmpty() {
+		t.Errorf("Expected an empty diff, got: %+v", diff)
+	}
+	if expected := "--- build/1\n+++ build/1\nThe builds are identical.\n"; diff.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, diff.String())
+	}
+}
+
+func TestDiffDependencyVersions(t *testing.T) {
+	buildInfoA := &buildinfo.BuildInfo{
+		Name:   "build",
+		Number: "1",
+		Modules: []buildinfo.Module{{
+			Id:           "app",
+			Dependencies: []buildinfo.Dependency{{Id: "lib:1.0"}, {Id: "lib:2.0"}, {Id: "multi:1.0"}, {Id: "gone:1.0"}},
+		}},
+	}
+	buildInfoB := &buildinfo.BuildInfo{
+		Name:   "build",
+		Number: "2",
+		Modules: []buildinfo.Module{{
+			Id:           "app",
+			Dependencies: []buildinfo.Dependency{{Id: "lib:1.0"}, {Id: "multi:2.0"}, {Id: "multi:3.0"}, {Id: "gone:1.0"}},
+		}},
+	}
+	expected := ItemsDiff{
+		Added:   []DiffItem{{Name: "multi", Version: "2.0"}, {Name: "multi", Version: "3.0"}},
+		Removed: []DiffItem{{Name: "lib", Version: "2.0"}, {Name: "multi", Version: "1.0"}},
+	}
+	diff := DiffBuildInfo(buildInfoA, buildInfoB)
+	if len(diff.Modules) != 1 || !reflect.DeepEqual(expected, diff.Modules[0].Dependencies) {
+		t.Errorf("Expected:\n%+v\nGot:\n%+v", expected, diff.Modules)
+	}
+}
+
+func TestGetLocalBuildInfo(t *testing.T) {
+	log.SetDefaultLogger()
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "build-diff-local-test", BuildNumber: "1"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	command := NewBuildDiffCommand()
+	if _, err := command.getBuildInfo(buildConfiguration, true); err == nil || !strings.Contains(err.Error(), "not found locally") {
+		t.Errorf("Expected a not found error, got: %v", err)
+	}
+
+	if err := utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber); err != nil {
+		t.Fatal(err)
+	}
+	populateEnv := func(partial *buildinfo.Partial) {
+		partial.Env = buildinfo.Env{"buildInfo.env.VAR": "value", "buildInfo.env.API_TOKEN": "secret"}
+	}
+	if err := utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, populateEnv); err != nil {
+		t.Fatal(err)
+	}
+	buildInfo, err := command.getBuildInfo(buildConfiguration, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := buildinfo.Env{"buildInfo.env.VAR": "value"}
+	if !reflect.DeepEqual(expected, buildInfo.Properties) {
+		t.Errorf("Expected properties %v, got %v", expected, buildInfo.Properties)
+	}
+}
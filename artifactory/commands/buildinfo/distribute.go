@@ -1,6 +1,7 @@
 package buildinfo
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -10,10 +11,16 @@ type BuildDistributeCommnad struct {
 	rtDetails *config.ArtifactoryDetails
 	services.BuildDistributionParams
 	dryRun bool
+	result *commandsutils.Result
 }
 
 func NewBuildDistributeCommnad() *BuildDistributeCommnad {
-	return &BuildDistributeCommnad{}
+	return &BuildDistributeCommnad{result: new(commandsutils.Result)}
+}
+
+// The distributed build is counted as a single item.
+func (bdc *BuildDistributeCommnad) Result() *commandsutils.Result {
+	return bdc.result
 }
 
 func (bdc *BuildDistributeCommnad) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildDistributeCommnad {
@@ -36,7 +43,7 @@ func (bdc *BuildDistributeCommnad) Run() error {
 	if err != nil {
 		return err
 	}
-	return servicesManager.DistributeBuild(bdc.BuildDistributionParams)
+	return countBuild(bdc.result, servicesManager.DistributeBuild(bdc.BuildDistributionParams))
 }
 
 func (bdc *BuildDistributeCommnad) RtDetails() (*config.ArtifactoryDetails, error) {
@@ -0,0 +1,39 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+)
+
+// Bundles a locally collected build to a portable file, so that it can be imported on another machine.
+type BuildExportCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	bundlePath         string
+}
+
+func NewBuildExportCommand() *BuildExportCommand {
+	return &BuildExportCommand{}
+}
+
+func (bec *BuildExportCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildExportCommand {
+	bec.buildConfiguration = buildConfiguration
+	return bec
+}
+
+func (bec *BuildExportCommand) SetBundlePath(bundlePath string) *BuildExportCommand {
+	bec.bundlePath = bundlePath
+	return bec
+}
+
+func (bec *BuildExportCommand) CommandName() string {
+	return "rt_build_export"
+}
+
+func (bec *BuildExportCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return nil, nil
+}
+
+func (bec *BuildExportCommand) Run() error {
+	_, err := utils.ExportBuild(bec.buildConfiguration.BuildName, bec.buildConfiguration.BuildNumber, bec.bundlePath)
+	return err
+}
@@ -0,0 +1,39 @@
+package buildinfo
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+)
+
+// Adds a build bundle, created by the build-export command, to the locally collected builds.
+type BuildImportCommand struct {
+	bundlePath     string
+	conflictPolicy utils.ImportConflictPolicy
+}
+
+func NewBuildImportCommand() *BuildImportCommand {
+	return &BuildImportCommand{conflictPolicy: utils.ImportMerge}
+}
+
+func (bic *BuildImportCommand) SetBundlePath(bundlePath string) *BuildImportCommand {
+	bic.bundlePath = bundlePath
+	return bic
+}
+
+func (bic *BuildImportCommand) SetConflictPolicy(conflictPolicy utils.ImportConflictPolicy) *BuildImportCommand {
+	bic.conflictPolicy = conflictPolicy
+	return bic
+}
+
+func (bic *BuildImportCommand) CommandName() string {
+	return "rt_build_import"
+}
+
+func (bic *BuildImportCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return nil, nil
+}
+
+func (bic *BuildImportCommand) Run() error {
+	_, err := utils.ImportBuild(bic.bundlePath, bic.conflictPolicy)
+	return err
+}
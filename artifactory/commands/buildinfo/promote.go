@@ -1,6 +1,7 @@
 package buildinfo
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
@@ -10,10 +11,16 @@ type BuildPromotionCommand struct {
 	services.PromotionParams
 	rtDetails *config.ArtifactoryDetails
 	dryRun    bool
+	result    *commandsutils.Result
 }
 
 func NewBuildPromotionCommand() *BuildPromotionCommand {
-	return &BuildPromotionCommand{}
+	return &BuildPromotionCommand{result: new(commandsutils.Result)}
+}
+
+// The promoted build is counted as a single item.
+func (bpc *BuildPromotionCommand) Result() *commandsutils.Result {
+	return bpc.result
 }
 
 func (bpc *BuildPromotionCommand) SetDryRun(dryRun bool) *BuildPromotionCommand {
@@ -36,7 +43,7 @@ func (bpc *BuildPromotionCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return servicesManager.PromoteBuild(bpc.PromotionParams)
+	return countBuild(bpc.result, servicesManager.PromoteBuild(bpc.PromotionParams))
 }
 
 func (bpc *BuildPromotionCommand) RtDetails() (*config.ArtifactoryDetails, error) {
@@ -0,0 +1,26 @@
+package buildinfo
+
+import (
+	"errors"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"testing"
+)
+
+func TestCountBuild(t *testing.T) {
+	result := new(commandsutils.Result)
+	if err := countBuild(result, nil); err != nil {
+		t.Error(err)
+	}
+	if result.SuccessCount() != 1 || result.FailCount() != 0 {
+		t.Errorf("Expected 1 success and 0 failures, got %d and %d", result.SuccessCount(), result.FailCount())
+	}
+
+	result = new(commandsutils.Result)
+	expectedErr := errors.New("promotion failed")
+	if err := countBuild(result, expectedErr); err != expectedErr {
+		t.Errorf("Expected the error to be returned as is, got: %v", err)
+	}
+	if result.SuccessCount() != 0 || result.FailCount() != 1 {
+		t.Errorf("Expected 0 successes and 1 failure, got %d and %d", result.SuccessCount(), result.FailCount())
+	}
+}
@@ -2,6 +2,7 @@ package buildinfo
 
 import (
 	"fmt"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
@@ -12,14 +13,28 @@ import (
 	"strings"
 )
 
+// The environment variables which are excluded from the build-info by default, since they may hold secrets.
+const DefaultEnvExclude = "*password*;*secret*;*key*;*token*"
+
 type BuildPublishCommand struct {
 	buildConfiguration *utils.BuildConfiguration
 	rtDetails          *config.ArtifactoryDetails
 	config             *buildinfo.Configuration
+	outputFile         string
+	result             *commandsutils.Result
 }
 
 func NewBuildPublishCommand() *BuildPublishCommand {
-	return &BuildPublishCommand{}
+	return &BuildPublishCommand{result: new(commandsutils.Result)}
+}
+
+// Returns the configuration build-publish uses when no env filters are set - all environment variables, except for the excluded ones.
+func NewDefaultConfiguration() *buildinfo.Configuration {
+	return &buildinfo.Configuration{EnvInclude: "*", EnvExclude: DefaultEnvExclude}
+}
+
+func (bpc *BuildPublishCommand) Result() *commandsutils.Result {
+	return bpc.result
 }
 
 func (bpc *BuildPublishCommand) SetConfig(config *buildinfo.Configuration) *BuildPublishCommand {
@@ -37,51 +52,77 @@ func (bpc *BuildPublishCommand) SetBuildConfiguration(buildConfiguration *utils.
 	return bpc
 }
 
+// Write the build-info to this file instead of publishing it to Artifactory.
+// The file can be published later using the build-upload-file command.
+func (bpc *BuildPublishCommand) SetOutputFile(outputFile string) *BuildPublishCommand {
+	bpc.outputFile = outputFile
+	return bpc
+}
+
 func (bpc *BuildPublishCommand) CommandName() string {
 	return "rt_build_publish"
 }
 
 func (bpc *BuildPublishCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	// Writing the build-info to a file is done offline, so no usage is reported.
+	if bpc.outputFile != "" {
+		return nil, nil
+	}
 	return bpc.rtDetails, nil
 }
 
+// The build-info is created from a snapshot of the local build data.
+// Once it is published, only the data of the snapshot is removed, so data saved while publishing is kept.
 func (bpc *BuildPublishCommand) Run() error {
-	servicesManager, err := utils.CreateServiceManager(bpc.rtDetails, bpc.config.DryRun)
+	snapshot, err := utils.ReadBuildSnapshot(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
 	if err != nil {
 		return err
 	}
-
-	buildInfo, err := bpc.createBuildInfoFromPartials()
+	buildInfo, err := bpc.createBuildInfo(snapshot)
 	if err != nil {
 		return err
 	}
 
-	generatedBuildsInfo, err := utils.GetGeneratedBuildsInfo(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber)
+	if bpc.outputFile != "" {
+		err = utils.WriteBuildInfoFile(bpc.outputFile, buildInfo)
+	} else {
+		err = publishBuildInfo(bpc.rtDetails, bpc.config.DryRun, buildInfo)
+	}
 	if err != nil {
 		return err
 	}
-
-	for _, v := range generatedBuildsInfo {
-		buildInfo.Append(v)
+	bpc.result.SetSuccessCount(1)
+	for _, module := range buildInfo.Modules {
+		bpc.result.AddModules(module.Id)
 	}
 
-	if err = servicesManager.PublishBuildInfo(buildInfo); err != nil {
-		return err
-	}
+	return snapshot.Remove()
+}
 
-	if err = utils.RemoveBuildDir(bpc.buildConfiguration.BuildName, bpc.buildConfiguration.BuildNumber); err != nil {
+func publishBuildInfo(rtDetails *config.ArtifactoryDetails, dryRun bool, buildInfo *buildinfo.BuildInfo) error {
+	servicesManager, err := utils.CreateServiceManager(rtDetails, dryRun)
+	if err != nil {
 		return err
 	}
-	return nil
+	return servicesManager.PublishBuildInfo(buildInfo)
 }
 
-func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildInfo, error) {
-	buildName := bpc.buildConfiguration.BuildName
-	buildNumber := bpc.buildConfiguration.BuildNumber
-	partials, err := utils.ReadPartialBuildInfoFiles(buildName, buildNumber)
+// Creates the build-info of the locally collected build, before it is published.
+func (bpc *BuildPublishCommand) createBuildInfo(snapshot *utils.BuildSnapshot) (*buildinfo.BuildInfo, error) {
+	buildInfo, err := bpc.createBuildInfoFromPartials(snapshot)
 	if err != nil {
 		return nil, err
 	}
+	for _, v := range snapshot.GeneratedBuildsInfo {
+		buildInfo.Append(v)
+	}
+	return buildInfo, nil
+}
+
+func (bpc *BuildPublishCommand) createBuildInfoFromPartials(snapshot *utils.BuildSnapshot) (*buildinfo.BuildInfo, error) {
+	buildName := bpc.buildConfiguration.BuildName
+	buildNumber := bpc.buildConfiguration.BuildNumber
+	partials := snapshot.Partials
 	sort.Sort(partials)
 
 	buildInfo := buildinfo.New()
@@ -90,11 +131,7 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 	buildInfo.SetBuildAgentVersion(cliutils.GetVersion())
 	buildInfo.Name = buildName
 	buildInfo.Number = buildNumber
-	buildGeneralDetails, err := utils.ReadBuildInfoGeneralDetails(buildName, buildNumber)
-	if err != nil {
-		return nil, err
-	}
-	buildInfo.Started = buildGeneralDetails.Timestamp.Format("2006-01-02T15:04:05.000-0700")
+	buildInfo.Started = snapshot.GeneralDetails.Timestamp.Format("2006-01-02T15:04:05.000-0700")
 	modules, env, vcs, issues, err := extractBuildInfoData(partials, createIncludeFilter(bpc.config.EnvInclude), createExcludeFilter(bpc.config.EnvExclude))
 	if err != nil {
 		return nil, err
@@ -102,7 +139,9 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 	if len(env) != 0 {
 		buildInfo.Properties = env
 	}
-	buildInfo.ArtifactoryPrincipal = bpc.rtDetails.User
+	if bpc.rtDetails != nil {
+		buildInfo.ArtifactoryPrincipal = bpc.rtDetails.User
+	}
 	buildInfo.BuildUrl = bpc.config.BuildUrl
 	if vcs != (buildinfo.Vcs{}) {
 		buildInfo.Revision = vcs.Revision
@@ -116,6 +155,9 @@ func (bpc *BuildPublishCommand) createBuildInfoFromPartials() (*buildinfo.BuildI
 		if module.Id == "" {
 			module.Id = buildName
 		}
+		if properties, ok := snapshot.ModulesProperties[module.Id]; ok {
+			module.Properties = properties
+		}
 		buildInfo.Modules = append(buildInfo.Modules, module)
 	}
 	return buildInfo, nil
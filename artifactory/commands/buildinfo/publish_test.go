@@ -1,6 +1,12 @@
 package buildinfo
 
 import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -61,3 +67,49 @@ func TestExcludePasswordsPattern(t *testing.T) {
 		t.Error("expeted:", expected, "got:", filteredKeys)
 	}
 }
+
+func TestPublishToOutputFile(t *testing.T) {
+	log.SetDefaultLogger()
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "publish-output-file-test", BuildNumber: "1"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	if err := utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber); err != nil {
+		t.Fatal(err)
+	}
+	err := utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, func(partial *buildinfo.Partial) {
+		partial.ModuleId = "module"
+		partial.Artifacts = []buildinfo.Artifact{{Name: "a.zip", Checksum: &buildinfo.Checksum{Sha1: "sha1"}}}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tempDir, err := ioutil.TempDir("", "publish")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	outputFile := filepath.Join(tempDir, "build-info.json")
+	publishCmd := NewBuildPublishCommand().SetBuildConfiguration(buildConfiguration).SetConfig(&buildinfo.Configuration{EnvInclude: "*"}).SetOutputFile(outputFile)
+	if err = publishCmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	buildInfo, err := utils.ReadBuildInfoFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buildInfo.Name != buildConfiguration.BuildName || len(buildInfo.Modules) != 1 || buildInfo.Modules[0].Artifacts[0].Name != "a.zip" {
+		t.Errorf("Unexpected build-info: %+v", buildInfo)
+	}
+}
+
+func TestFilterBuildInfoProperties(t *testing.T) {
+	buildInfo := &buildinfo.BuildInfo{Properties: buildinfo.Env{"buildInfo.env.USER": "user", "buildInfo.env.MY_TOKEN": "secret", "buildInfo.env.OTHER": "other"}}
+	err := filterBuildInfoProperties(buildInfo, &buildinfo.Configuration{EnvInclude: "*USER*;*TOKEN*", EnvExclude: "*token*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := buildinfo.Env{"buildInfo.env.USER": "user"}
+	if !reflect.DeepEqual(expected, buildInfo.Properties) {
+		t.Error("expected:", expected, "got:", buildInfo.Properties)
+	}
+}
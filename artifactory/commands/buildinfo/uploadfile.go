@@ -0,0 +1,88 @@
+package buildinfo
+
+import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// Publishes a build-info file, written by the build-publish command with the --output-file option.
+type BuildUploadFileCommand struct {
+	rtDetails *config.ArtifactoryDetails
+	config    *buildinfo.Configuration
+	filePath  string
+	result    *commandsutils.Result
+}
+
+func NewBuildUploadFileCommand() *BuildUploadFileCommand {
+	return &BuildUploadFileCommand{result: new(commandsutils.Result)}
+}
+
+func (bufc *BuildUploadFileCommand) Result() *commandsutils.Result {
+	return bufc.result
+}
+
+func (bufc *BuildUploadFileCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *BuildUploadFileCommand {
+	bufc.rtDetails = rtDetails
+	return bufc
+}
+
+func (bufc *BuildUploadFileCommand) SetConfig(config *buildinfo.Configuration) *BuildUploadFileCommand {
+	bufc.config = config
+	return bufc
+}
+
+func (bufc *BuildUploadFileCommand) SetFilePath(filePath string) *BuildUploadFileCommand {
+	bufc.filePath = filePath
+	return bufc
+}
+
+func (bufc *BuildUploadFileCommand) CommandName() string {
+	return "rt_build_upload_file"
+}
+
+func (bufc *BuildUploadFileCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return bufc.rtDetails, nil
+}
+
+func (bufc *BuildUploadFileCommand) Run() error {
+	buildInfo, err := utils.ReadBuildInfoFile(bufc.filePath)
+	if err != nil {
+		return err
+	}
+	if err = filterBuildInfoProperties(buildInfo, bufc.config); err != nil {
+		return err
+	}
+	if buildInfo.ArtifactoryPrincipal == "" && bufc.rtDetails != nil {
+		buildInfo.ArtifactoryPrincipal = bufc.rtDetails.User
+	}
+	if bufc.config.BuildUrl != "" {
+		buildInfo.BuildUrl = bufc.config.BuildUrl
+	}
+	if err = publishBuildInfo(bufc.rtDetails, bufc.config.DryRun, buildInfo); err != nil {
+		return err
+	}
+	bufc.result.SetSuccessCount(1)
+	for _, module := range buildInfo.Modules {
+		bufc.result.AddModules(module.Id)
+	}
+	return nil
+}
+
+// Applies the env include and exclude patterns to the properties of the build-info, in the same way build-publish applies them to the collected env.
+func filterBuildInfoProperties(buildInfo *buildinfo.BuildInfo, config *buildinfo.Configuration) error {
+	if len(buildInfo.Properties) == 0 {
+		return nil
+	}
+	properties, err := createIncludeFilter(config.EnvInclude)(buildInfo.Properties)
+	if err != nil {
+		return err
+	}
+	properties, err = createExcludeFilter(config.EnvExclude)(properties)
+	if err != nil {
+		return err
+	}
+	buildInfo.Properties = properties
+	return nil
+}
@@ -1,14 +1,15 @@
 package buildinfo
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/xray"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
-	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
 )
 
 type BuildScanCommand struct {
@@ -16,6 +17,11 @@ type BuildScanCommand struct {
 	failBuild          bool
 	rtDetails          *config.ArtifactoryDetails
 	buildFailed        bool
+	minSeverity        xray.Severity
+	ignoreFile         string
+	junitFile          string
+	sarifFile          string
+	jsonOutput         bool
 }
 
 func NewBuildScanCommand() *BuildScanCommand {
@@ -41,6 +47,34 @@ func (bsc *BuildScanCommand) SetBuildConfiguration(buildConfiguration *utils.Bui
 	return bsc
 }
 
+// Violations with a lower severity are ignored.
+func (bsc *BuildScanCommand) SetMinSeverity(minSeverity xray.Severity) *BuildScanCommand {
+	bsc.minSeverity = minSeverity
+	return bsc
+}
+
+// A JSON file of accepted issues, which are ignored until they expire.
+func (bsc *BuildScanCommand) SetIgnoreFile(ignoreFile string) *BuildScanCommand {
+	bsc.ignoreFile = ignoreFile
+	return bsc
+}
+
+func (bsc *BuildScanCommand) SetJUnitFile(junitFile string) *BuildScanCommand {
+	bsc.junitFile = junitFile
+	return bsc
+}
+
+func (bsc *BuildScanCommand) SetSarifFile(sarifFile string) *BuildScanCommand {
+	bsc.sarifFile = sarifFile
+	return bsc
+}
+
+// Prints the JSON result of Xray together with the reported and ignored violations, instead of the violations table.
+func (bsc *BuildScanCommand) SetJsonOutput(jsonOutput bool) *BuildScanCommand {
+	bsc.jsonOutput = jsonOutput
+	return bsc
+}
+
 func (bsc *BuildScanCommand) CommandName() string {
 	return "rt_build_scan"
 }
@@ -50,6 +84,14 @@ func (bsc *BuildScanCommand) RtDetails() (*config.ArtifactoryDetails, error) {
 }
 
 func (bsc *BuildScanCommand) Run() error {
+	var ignoreRules []*xray.IgnoreRule
+	if bsc.ignoreFile != "" {
+		var err error
+		if ignoreRules, err = xray.ReadIgnoreFile(bsc.ignoreFile, time.Now()); err != nil {
+			return err
+		}
+	}
+
 	log.Info("Triggered Xray build scan... The scan may take a few minutes.")
 	servicesManager, err := utils.CreateServiceManager(bsc.rtDetails, false)
 	if err != nil {
@@ -62,34 +104,73 @@ func (bsc *BuildScanCommand) Run() error {
 		return err
 	}
 
-	var scanResults scanResult
-	err = json.Unmarshal(result, &scanResults)
-	if errorutils.CheckError(err) != nil {
+	scanResult, err := xray.ParseScanResult(result)
+	if err != nil {
 		return err
 	}
-
 	log.Info("Xray scan completed.")
-	log.Output(clientutils.IndentJson(result))
+
+	reported, ignored := xray.FilterViolations(scanResult.Violations(), bsc.minSeverity, ignoreRules)
+	if bsc.jsonOutput {
+		content, err := xray.CreateJsonOutput(result, reported, ignored)
+		if err != nil {
+			return err
+		}
+		log.Output(string(content))
+	} else {
+		bsc.printViolations(scanResult, reported, ignored)
+	}
+	if err = bsc.writeReports(scanResult, reported, ignored); err != nil {
+		return err
+	}
 
 	// Check if should fail build
-	if bsc.failBuild && scanResults.Summary.FailBuild {
+	if bsc.failBuild && bsc.shouldFailBuild(scanResult, reported) {
 		bsc.buildFailed = true
-		return errorutils.CheckError(errors.New(scanResults.Summary.Message))
+		return errorutils.CheckError(errors.New(scanResult.Summary.Message))
 	}
+	return nil
+}
 
-	return err
+// Xray decides whether the build fails. If violations are filtered locally, the build fails only if some of the violations are still reported.
+func (bsc *BuildScanCommand) shouldFailBuild(scanResult *xray.ScanResult, reported []*xray.Violation) bool {
+	if !scanResult.Summary.FailBuild {
+		return false
+	}
+	if bsc.minSeverity == xray.Unknown && bsc.ignoreFile == "" {
+		return true
+	}
+	return len(reported) > 0
 }
 
-// To unmarshal xray scan summary result
-type scanResult struct {
-	Summary scanSummary `json:"summary,omitempty"`
+func (bsc *BuildScanCommand) printViolations(scanResult *xray.ScanResult, reported, ignored []*xray.Violation) {
+	if len(reported) > 0 {
+		log.Output(xray.ViolationsTable(reported))
+	}
+	if len(scanResult.Licenses) > 0 {
+		log.Output(xray.LicensesTable(scanResult.Licenses))
+	}
+	log.Output(fmt.Sprintf("%d violations found, %d ignored.", len(reported), len(ignored)))
+	if scanResult.Summary.Url != "" {
+		log.Output("More details:", scanResult.Summary.Url)
+	}
 }
 
-type scanSummary struct {
-	TotalAlerts int    `json:"total_alerts,omitempty"`
-	FailBuild   bool   `json:"fail_build,omitempty"`
-	Message     string `json:"message,omitempty"`
-	Url         string `json:"more_details_url,omitempty"`
+func (bsc *BuildScanCommand) writeReports(scanResult *xray.ScanResult, reported, ignored []*xray.Violation) error {
+	build := bsc.buildConfiguration.BuildName + "/" + bsc.buildConfiguration.BuildNumber
+	if bsc.junitFile != "" {
+		if err := xray.WriteJUnitReport(bsc.junitFile, build, reported, ignored); err != nil {
+			return err
+		}
+		log.Info("JUnit report written to", bsc.junitFile)
+	}
+	if bsc.sarifFile != "" {
+		if err := xray.WriteSarifReport(bsc.sarifFile, build, reported, scanResult.Summary.Url); err != nil {
+			return err
+		}
+		log.Info("SARIF report written to", bsc.sarifFile)
+	}
+	return nil
 }
 
 func getXrayScanParams(buildName, buildNumber string) services.XrayScanParams {
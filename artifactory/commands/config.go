@@ -194,7 +194,7 @@ func (cc *ConfigCommand) getConfigurationFromUser() error {
 	}
 	cc.details.Url = clientutils.AddTrailingSlashIfNeeded(cc.details.Url)
 	// Api-Key/Password/Access-Token
-	if cc.details.ApiKey == "" && cc.details.Password == "" && cc.details.AccessToken == "" {
+	if cc.details.ApiKey == "" && cc.details.Password == "" && cc.details.AccessToken == "" && cc.details.CredentialsHelper == "" {
 		err := readAccessTokenFromConsole(cc.details)
 		if err != nil {
 			return err
@@ -288,7 +288,7 @@ func printConfigs(configuration []*config.ArtifactoryDetails) {
 			log.Output("Url: " + details.Url)
 		}
 		if details.ApiKey != "" {
-			log.Output("API key: " + details.ApiKey)
+			log.Output("API key: ***")
 		}
 		if details.User != "" {
 			log.Output("User: " + details.User)
@@ -302,6 +302,9 @@ func printConfigs(configuration []*config.ArtifactoryDetails) {
 		if details.SshKeyPath != "" {
 			log.Output("SSH key file path: " + details.SshKeyPath)
 		}
+		if details.CredentialsHelper != "" {
+			log.Output("Credentials helper: " + details.CredentialsHelper)
+		}
 		log.Output("Default: ", details.IsDefault)
 		log.Output()
 	}
@@ -403,9 +406,9 @@ func (cc *ConfigCommand) encryptPassword() error {
 }
 
 func checkSingleAuthMethod(details *config.ArtifactoryDetails) error {
-	boolArr := []bool{details.User != "" && details.Password != "", details.ApiKey != "", fileutils.IsSshUrl(details.Url), details.AccessToken != ""}
+	boolArr := []bool{details.User != "" && details.Password != "", details.ApiKey != "", fileutils.IsSshUrl(details.Url), details.AccessToken != "", details.CredentialsHelper != ""}
 	if cliutils.SumTrueValues(boolArr) > 1 {
-		return errorutils.CheckError(errors.New("Only one authentication method is allowed: Username + Password/API key, RSA Token (SSH) or Access Token."))
+		return errorutils.CheckError(errors.New("Only one authentication method is allowed: Username + Password/API key, RSA Token (SSH), Access Token or Credentials Helper."))
 	}
 	return nil
 }
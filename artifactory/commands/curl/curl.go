@@ -63,6 +63,9 @@ func (curlCmd *CurlCommand) Run() error {
 	cmdWithoutCreds := strings.Join(curlCmd.arguments, " ")
 	// Add credentials to curl command.
 	credentialsMessage, err := curlCmd.addCommandCredentials()
+	if err != nil {
+		return err
+	}
 
 	// Run curl.
 	log.Debug(fmt.Sprintf("Executing curl command: '%s %s'", cmdWithoutCreds, credentialsMessage))
@@ -70,15 +73,19 @@ func (curlCmd *CurlCommand) Run() error {
 }
 
 func (curlCmd *CurlCommand) addCommandCredentials() (string, error) {
-	if curlCmd.rtDetails.AccessToken != "" {
+	rtDetails, err := curlCmd.rtDetails.ResolveCredentials()
+	if err != nil {
+		return "", err
+	}
+	if rtDetails.AccessToken != "" {
 		// Add access token header.
-		tokenHeader := fmt.Sprintf("Authorization: Bearer %s", curlCmd.rtDetails.AccessToken)
+		tokenHeader := fmt.Sprintf("Authorization: Bearer %s", rtDetails.AccessToken)
 		curlCmd.arguments = append(curlCmd.arguments, "-H", tokenHeader)
 		return "-H \"Authorization: Bearer ***\"", nil
 	}
 
 	// Add credentials flag to Command. In case of flag duplication, the latter is used by Curl.
-	credFlag := fmt.Sprintf("-u%s:%s", curlCmd.rtDetails.User, curlCmd.rtDetails.Password)
+	credFlag := fmt.Sprintf("-u%s:%s", rtDetails.User, rtDetails.Password)
 	curlCmd.arguments = append(curlCmd.arguments, credFlag)
 	return "-u***:***", nil
 }
@@ -1,6 +1,13 @@
 package curl
 
 import (
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
 	"testing"
 )
 
@@ -102,3 +109,29 @@ func TestBuildCommandUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCommandCredentialsFromHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test credentials helper is a shell script.")
+	}
+	log.SetDefaultLogger()
+	tempDir, err := ioutil.TempDir("", "curl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	helper := filepath.Join(tempDir, "helper.sh")
+	if err = ioutil.WriteFile(helper, []byte("#!/bin/sh\necho '{\"accessToken\": \"helper-token\"}'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	command := NewCurlCommand().SetRtDetails(&config.ArtifactoryDetails{Url: "http://localhost:8081/artifactory/", CredentialsHelper: helper})
+	command.arguments = []string{"-XGET", "/api/system/ping"}
+	if _, err = command.addCommandCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"-XGET", "/api/system/ping", "-H", "Authorization: Bearer helper-token"}
+	if !reflect.DeepEqual(expected, command.arguments) {
+		t.Errorf("Expected arguments %v, got %v", expected, command.arguments)
+	}
+}
@@ -0,0 +1,144 @@
+package docker
+
+import (
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/docker"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+	"strings"
+)
+
+// Builds a docker image and pushes it. When collecting build info, the base images of the Dockerfile
+// are added as dependencies to the module of the pushed image.
+type DockerBuildCommand struct {
+	DockerPushCommand
+	dockerfilePath string
+	contextPath    string
+	buildArgs      []string
+	resolverRepo   string
+	skipBuild      bool
+}
+
+func NewDockerBuildCommand() *DockerBuildCommand {
+	return &DockerBuildCommand{DockerPushCommand: DockerPushCommand{DockerCommand: DockerCommand{result: new(commandsutils.Result)}}}
+}
+
+// The Dockerfile path. If empty, the Dockerfile in the build context is used.
+func (dbc *DockerBuildCommand) SetDockerfilePath(dockerfilePath string) *DockerBuildCommand {
+	dbc.dockerfilePath = dockerfilePath
+	return dbc
+}
+
+func (dbc *DockerBuildCommand) SetContextPath(contextPath string) *DockerBuildCommand {
+	dbc.contextPath = contextPath
+	return dbc
+}
+
+// Additional docker build options, such as --build-arg.
+func (dbc *DockerBuildCommand) SetBuildArgs(buildArgs []string) *DockerBuildCommand {
+	dbc.buildArgs = buildArgs
+	return dbc
+}
+
+// The repository the base images are pulled from. If empty, the target repository is used.
+func (dbc *DockerBuildCommand) SetResolverRepo(resolverRepo string) *DockerBuildCommand {
+	dbc.resolverRepo = resolverRepo
+	return dbc
+}
+
+// Skip docker build, for images which were already built from the Dockerfile.
+func (dbc *DockerBuildCommand) SetSkipBuild(skipBuild bool) *DockerBuildCommand {
+	dbc.skipBuild = skipBuild
+	return dbc
+}
+
+// Build the docker image, push it and create build info if needed
+func (dbc *DockerBuildCommand) Run() error {
+	if dbc.contextPath == "" {
+		dbc.contextPath = "."
+	}
+	dockerfilePath := dbc.dockerfilePath
+	if dockerfilePath == "" {
+		dockerfilePath = filepath.Join(dbc.contextPath, "Dockerfile")
+	}
+	if strings.LastIndex(dbc.imageTag, ":") <= strings.LastIndex(dbc.imageTag, "/") {
+		dbc.imageTag = dbc.imageTag + ":latest"
+	}
+
+	// Base images may be pulled from Artifactory, so login is performed before the build.
+	if !dbc.skipLogin {
+		loginConfig := &docker.DockerLoginConfig{ArtifactoryDetails: dbc.rtDetails}
+		if err := docker.DockerLogin(dbc.imageTag, loginConfig); err != nil {
+			return err
+		}
+		dbc.skipLogin = true
+	}
+	if !dbc.skipBuild {
+		buildCmd := &docker.BuildCmd{ImageTag: dbc.imageTag, DockerfilePath: dbc.dockerfilePath, ContextPath: dbc.contextPath, BuildArgs: dbc.buildArgs}
+		if err := errorutils.CheckError(gofrogcmd.RunCmd(buildCmd)); err != nil {
+			return err
+		}
+	}
+
+	if dbc.buildConfiguration.BuildName != "" && dbc.buildConfiguration.BuildNumber != "" {
+		if err := dbc.collectBaseImages(dockerfilePath); err != nil {
+			return err
+		}
+	}
+	return dbc.DockerPushCommand.Run()
+}
+
+func (dbc *DockerBuildCommand) collectBaseImages(dockerfilePath string) error {
+	baseImages, err := docker.ParseDockerfileBaseImages(dockerfilePath, getBuildArgValues(dbc.buildArgs))
+	if err != nil {
+		return err
+	}
+	serviceManager, err := docker.CreateServiceManager(dbc.rtDetails, 0)
+	if err != nil {
+		return err
+	}
+	resolverRepo := dbc.resolverRepo
+	if resolverRepo == "" {
+		resolverRepo = dbc.repo
+	}
+	for _, baseImage := range baseImages {
+		if strings.Contains(baseImage, "@") {
+			log.Warn("Skipping the base image " + baseImage + ", since base images referenced by digest are not supported.")
+			continue
+		}
+		log.Info("Collecting the dependencies of the base image", baseImage)
+		dependencies, err := docker.BaseImageDependencies(baseImage, resolverRepo, serviceManager)
+		if err != nil {
+			return err
+		}
+		dbc.dependencies = append(dbc.dependencies, dependencies...)
+	}
+	return nil
+}
+
+// Returns the values of the --build-arg options of docker build.
+func getBuildArgValues(buildArgs []string) map[string]string {
+	values := make(map[string]string)
+	for i := 0; i < len(buildArgs); i++ {
+		arg := buildArgs[i]
+		switch {
+		case arg == "--build-arg" && i+1 < len(buildArgs):
+			i++
+			arg = buildArgs[i]
+		case strings.HasPrefix(arg, "--build-arg="):
+			arg = strings.TrimPrefix(arg, "--build-arg=")
+		default:
+			continue
+		}
+		if index := strings.Index(arg, "="); index > 0 {
+			values[arg[:index]] = arg[index+1:]
+		}
+	}
+	return values
+}
+
+func (dbc *DockerBuildCommand) CommandName() string {
+	return "rt_docker_build"
+}
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 )
@@ -11,6 +12,13 @@ type DockerCommand struct {
 	buildConfiguration *utils.BuildConfiguration
 	rtDetails          *config.ArtifactoryDetails
 	skipLogin          bool
+	// Access the registry over HTTP rather than HTTPS. Applies only to daemonless push and pull.
+	insecureRegistry bool
+	result           *commandsutils.Result
+}
+
+func (dc *DockerCommand) Result() *commandsutils.Result {
+	return dc.result
 }
 
 func (dc *DockerCommand) ImageTag() string {
@@ -45,6 +53,11 @@ func (dc *DockerCommand) SetSkipLogin(skipLogin bool) *DockerCommand {
 	return dc
 }
 
+func (dc *DockerCommand) SetInsecureRegistry(insecureRegistry bool) *DockerCommand {
+	dc.insecureRegistry = insecureRegistry
+	return dc
+}
+
 func (dc *DockerCommand) RtDetails() *config.ArtifactoryDetails {
 	return dc.rtDetails
 }
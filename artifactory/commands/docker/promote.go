@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"fmt"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/docker"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Promotes a docker image between repositories, optionally renaming and retagging it.
+// The image tag of the command is the source image, and the repo is the source repo.
+type DockerPromoteCommand struct {
+	DockerCommand
+	targetRepo  string
+	targetImage string
+	targetTag   string
+	copy        bool
+}
+
+func NewDockerPromoteCommand() *DockerPromoteCommand {
+	return &DockerPromoteCommand{DockerCommand: DockerCommand{result: new(commandsutils.Result)}}
+}
+
+func (dpc *DockerPromoteCommand) SetTargetRepo(targetRepo string) *DockerPromoteCommand {
+	dpc.targetRepo = targetRepo
+	return dpc
+}
+
+// The image name in the target repo. If empty, the source image name is used.
+func (dpc *DockerPromoteCommand) SetTargetImage(targetImage string) *DockerPromoteCommand {
+	dpc.targetImage = targetImage
+	return dpc
+}
+
+// The image tag in the target repo. If empty, the source image tag is used.
+func (dpc *DockerPromoteCommand) SetTargetTag(targetTag string) *DockerPromoteCommand {
+	dpc.targetTag = targetTag
+	return dpc
+}
+
+// Copy the image rather than move it.
+func (dpc *DockerPromoteCommand) SetCopy(copy bool) *DockerPromoteCommand {
+	dpc.copy = copy
+	return dpc
+}
+
+// Validate that the image exists in the source repo, promote it and create build info if needed
+func (dpc *DockerPromoteCommand) Run() error {
+	serviceManager, err := docker.CreateServiceManager(dpc.rtDetails, 0)
+	if err != nil {
+		return err
+	}
+	imageName, tag := docker.SplitImageTag(dpc.imageTag)
+	targetImage, targetTag := dpc.targetImage, dpc.targetTag
+	if targetImage == "" {
+		targetImage = imageName
+	}
+	if targetTag == "" {
+		targetTag = tag
+	}
+
+	image, err := docker.GetRepoImage(dpc.repo, imageName, tag, serviceManager)
+	if err != nil {
+		return err
+	}
+	action := "Moving"
+	if dpc.copy {
+		action = "Copying"
+	}
+	sourcePath := fmt.Sprintf("%s/%s:%s", dpc.repo, imageName, tag)
+	targetPath := fmt.Sprintf("%s/%s:%s", dpc.targetRepo, targetImage, targetTag)
+	log.Info(fmt.Sprintf("%s docker image %s to %s", action, sourcePath, targetPath))
+	params := docker.PromoteParams{
+		TargetRepo:             dpc.targetRepo,
+		DockerRepository:       imageName,
+		TargetDockerRepository: targetImage,
+		Tag:                    tag,
+		TargetTag:              targetTag,
+		Copy:                   dpc.copy,
+	}
+	if err = docker.PromoteImage(dpc.repo, params, serviceManager); err != nil {
+		return err
+	}
+	dpc.result.SetSuccessCount(1)
+	dpc.result.AddPaths(targetPath)
+
+	// Return if no build name and number was provided
+	if dpc.buildConfiguration == nil || dpc.buildConfiguration.BuildName == "" || dpc.buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	buildName, buildNumber := dpc.buildConfiguration.BuildName, dpc.buildConfiguration.BuildNumber
+	if err := utils.SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		return err
+	}
+	module := dpc.buildConfiguration.Module
+	if module == "" {
+		module = targetImage + ":" + targetTag
+	}
+	buildInfo := docker.CreatePromotionBuildInfo(module, dpc.repo, imageName+":"+tag, dpc.targetRepo, targetImage+":"+targetTag, image)
+	if err := utils.SaveBuildInfo(buildName, buildNumber, buildInfo); err != nil {
+		return err
+	}
+	dpc.result.AddModules(module)
+	return nil
+}
+
+func (dpc *DockerPromoteCommand) CommandName() string {
+	return "rt_docker_promote"
+}
+
+func (dpc *DockerPromoteCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return dpc.rtDetails, nil
+}
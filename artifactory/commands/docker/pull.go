@@ -1,6 +1,7 @@
 package docker
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/docker"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
@@ -10,10 +11,17 @@ import (
 
 type DockerPullCommand struct {
 	DockerCommand
+	// A dir to pull the image into as an OCI image layout, through the registry API and without a Docker daemon.
+	ociLayout string
 }
 
 func NewDockerPullCommand() *DockerPullCommand {
-	return &DockerPullCommand{}
+	return &DockerPullCommand{DockerCommand: DockerCommand{result: new(commandsutils.Result)}}
+}
+
+func (dpc *DockerPullCommand) SetOciLayout(ociLayout string) *DockerPullCommand {
+	dpc.ociLayout = ociLayout
+	return dpc
 }
 
 // Pull docker image and create build info if needed
@@ -23,7 +31,8 @@ func (dpc *DockerPullCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
-	if !dpc.skipLogin {
+	// Docker login is not needed when pulling through the registry API.
+	if !dpc.skipLogin && dpc.ociLayout == "" {
 		loginConfig := &docker.DockerLoginConfig{ArtifactoryDetails: rtDetails}
 		err = docker.DockerLogin(dpc.imageTag, loginConfig)
 		if err != nil {
@@ -35,11 +44,18 @@ func (dpc *DockerPullCommand) Run() error {
 	if strings.LastIndex(dpc.imageTag, ":") == -1 {
 		dpc.imageTag = dpc.imageTag + ":latest"
 	}
-	image := docker.New(dpc.imageTag)
+	var image docker.Image
+	if dpc.ociLayout != "" {
+		image = docker.NewRegistryImage(dpc.imageTag, dpc.ociLayout, rtDetails, dpc.insecureRegistry)
+	} else {
+		image = docker.New(dpc.imageTag)
+	}
 	err = image.Pull()
 	if err != nil {
 		return err
 	}
+	dpc.result.SetSuccessCount(1)
+	dpc.result.AddPaths(dpc.imageTag)
 
 	buildName := dpc.BuildConfiguration().BuildName
 	buildNumber := dpc.BuildConfiguration().BuildNumber
@@ -62,7 +78,13 @@ func (dpc *DockerPullCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return utils.SaveBuildInfo(buildName, buildNumber, buildInfo)
+	if err = utils.SaveBuildInfo(buildName, buildNumber, buildInfo); err != nil {
+		return err
+	}
+	for _, module := range buildInfo.Modules {
+		dpc.result.AddModules(module.Id)
+	}
+	return nil
 }
 
 func (dpc *DockerPullCommand) CommandName() string {
@@ -1,9 +1,11 @@
 package docker
 
 import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/docker"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"strings"
 )
@@ -11,10 +13,14 @@ import (
 type DockerPushCommand struct {
 	DockerCommand
 	threads int
+	// An OCI image layout dir or a 'docker save' tarball to push through the registry API, without a Docker daemon.
+	source string
+	// Dependencies added to the module of the pushed image, such as the base images collected by docker-build.
+	dependencies []buildinfo.Dependency
 }
 
 func NewDockerPushCommand() *DockerPushCommand {
-	return &DockerPushCommand{}
+	return &DockerPushCommand{DockerCommand: DockerCommand{result: new(commandsutils.Result)}}
 }
 
 func (dpc *DockerPushCommand) Threads() int {
@@ -26,6 +32,11 @@ func (dpc *DockerPushCommand) SetThreads(threads int) *DockerPushCommand {
 	return dpc
 }
 
+func (dpc *DockerPushCommand) SetSource(source string) *DockerPushCommand {
+	dpc.source = source
+	return dpc
+}
+
 // Push docker image and create build info if needed
 func (dpc *DockerPushCommand) Run() error {
 	// Perform login
@@ -33,7 +44,8 @@ func (dpc *DockerPushCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
-	if !dpc.skipLogin {
+	// Docker login is not needed when pushing through the registry API.
+	if !dpc.skipLogin && dpc.source == "" {
 		loginConfig := &docker.DockerLoginConfig{ArtifactoryDetails: rtDetails}
 		err = docker.DockerLogin(dpc.imageTag, loginConfig)
 		if err != nil {
@@ -45,11 +57,18 @@ func (dpc *DockerPushCommand) Run() error {
 	if strings.LastIndex(dpc.imageTag, ":") == -1 {
 		dpc.imageTag = dpc.imageTag + ":latest"
 	}
-	image := docker.New(dpc.imageTag)
+	var image docker.Image
+	if dpc.source != "" {
+		image = docker.NewRegistryImage(dpc.imageTag, dpc.source, rtDetails, dpc.insecureRegistry)
+	} else {
+		image = docker.New(dpc.imageTag)
+	}
 	err = image.Push()
 	if err != nil {
 		return err
 	}
+	dpc.result.SetSuccessCount(1)
+	dpc.result.AddPaths(dpc.imageTag)
 
 	// Return if no build name and number was provided
 	if dpc.buildConfiguration.BuildName == "" || dpc.buildConfiguration.BuildNumber == "" {
@@ -70,7 +89,14 @@ func (dpc *DockerPushCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return utils.SaveBuildInfo(dpc.BuildConfiguration().BuildName, dpc.BuildConfiguration().BuildNumber, buildInfo)
+	buildInfo.Modules[0].Dependencies = append(buildInfo.Modules[0].Dependencies, dpc.dependencies...)
+	if err = utils.SaveBuildInfo(dpc.BuildConfiguration().BuildName, dpc.BuildConfiguration().BuildNumber, buildInfo); err != nil {
+		return err
+	}
+	for _, module := range buildInfo.Modules {
+		dpc.result.AddModules(module.Id)
+	}
+	return nil
 }
 
 func (dpc *DockerPushCommand) CommandName() string {
@@ -52,6 +52,11 @@ func (dc *DeleteCommand) Run() error {
 		result := dc.Result()
 		result.SetFailCount(failed)
 		result.SetSuccessCount(success)
+		if failed == 0 {
+			for _, item := range dc.deleteItems {
+				result.AddPaths(item.GetItemRelativePath())
+			}
+		}
 		return err
 	}
 	return nil
@@ -88,7 +88,13 @@ func (dc *DownloadCommand) Run() error {
 	}
 
 	// Perform download.
-	filesInfo, totalExpected, err := servicesManager.DownloadFiles(downloadParamsArray...)
+	var filesInfo []clientutils.FileInfo
+	var totalExpected int
+	if dc.configuration.Resume && !dc.DryRun() {
+		filesInfo, totalExpected, err = dc.resumableDownload(servicesManager, downloadParamsArray)
+	} else {
+		filesInfo, totalExpected, err = servicesManager.DownloadFiles(downloadParamsArray...)
+	}
 	if err != nil {
 		errorOccurred = true
 		log.Error(err)
@@ -96,6 +102,9 @@ func (dc *DownloadCommand) Run() error {
 
 	dc.result.SetSuccessCount(len(filesInfo))
 	dc.result.SetFailCount(totalExpected - len(filesInfo))
+	for _, fileInfo := range filesInfo {
+		dc.result.AddPaths(fileInfo.LocalPath)
+	}
 	// Check for errors.
 	if errorOccurred {
 		return errors.New("Download finished with errors, please review the logs.")
@@ -114,7 +123,9 @@ func (dc *DownloadCommand) Run() error {
 			partial.Dependencies = buildDependencies
 			partial.ModuleId = dc.buildConfiguration.Module
 		}
-		err = utils.SavePartialBuildInfo(dc.buildConfiguration.BuildName, dc.buildConfiguration.BuildNumber, populateFunc)
+		if err = utils.SavePartialBuildInfo(dc.buildConfiguration.BuildName, dc.buildConfiguration.BuildNumber, populateFunc); err == nil {
+			dc.result.AddModules(dc.buildConfiguration.Module)
+		}
 	}
 
 	return err
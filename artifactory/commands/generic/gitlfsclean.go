@@ -63,10 +63,15 @@ func (glc *GitLfsCommand) deleteLfsFilesFromArtifactory(deleteItems []clientutil
 	if err != nil {
 		return err
 	}
-	_, err = servicesManager.DeleteFiles(deleteItems)
+	deleted, err := servicesManager.DeleteFiles(deleteItems)
+	glc.result.SetSuccessCount(deleted)
+	glc.result.SetFailCount(len(deleteItems) - deleted)
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
+	for _, item := range deleteItems {
+		glc.result.AddPaths(item.GetItemRelativePath())
+	}
 	return nil
 }
 
@@ -0,0 +1,415 @@
+package generic
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	rthttpclient "github.com/jfrog/jfrog-client-go/artifactory/httpclient"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Number of files uploaded between two saves of the transfer journal.
+const resumeBatchSize = 100
+
+// The characters used by the upload service for the placeholders of the target path.
+const placeholderChars = "(){}"
+
+// Uploads the files in batches, recording the uploaded files in the transfer journal after each batch.
+// Files recorded in the journal by a previous run, which were not modified since, are skipped.
+func (uc *UploadCommand) resumableUpload(servicesManager *artifactory.ArtifactoryServicesManager, uploadParamsArray []services.UploadParams, artifactoryUrl string) (filesInfo []serviceutils.FileInfo, successCount, failCount int, err error) {
+	for _, params := range uploadParamsArray {
+		if err = validateResumableUpload(params); err != nil {
+			return
+		}
+	}
+	journal, err := utils.OpenTransferJournal(uc.CommandName(), artifactoryUrl, uc.Spec())
+	if err != nil {
+		return
+	}
+	batches, filesInfo := prepareResumedUpload(uploadParamsArray, journal)
+	if len(filesInfo) > 0 {
+		log.Info("Skipping", len(filesInfo), "files which were already uploaded.")
+	}
+	successCount = len(filesInfo)
+	for _, batch := range batches {
+		batchFilesInfo, batchSuccessCount, batchFailCount, e := servicesManager.UploadFiles(batch...)
+		for _, fileInfo := range batchFilesInfo {
+			journal.AddCompleted(fileInfo.LocalPath, fileInfo)
+		}
+		filesInfo = append(filesInfo, batchFilesInfo...)
+		successCount += batchSuccessCount
+		failCount += batchFailCount
+		if err = journal.Save(); err != nil {
+			return
+		}
+		if e != nil {
+			err = e
+			return
+		}
+	}
+	if failCount == 0 {
+		err = journal.Remove()
+	}
+	return
+}
+
+// Splits the upload into batches of single file upload params.
+// Returns the batches and the files which were already uploaded by a previous run.
+func prepareResumedUpload(uploadParamsArray []services.UploadParams, journal *utils.TransferJournal) (batches [][]services.UploadParams, uploaded []serviceutils.FileInfo) {
+	var singleFileParams []services.UploadParams
+	for _, params := range uploadParamsArray {
+		candidates, err := collectUploadCandidates(params)
+		if err != nil || !canSplitUpload(candidates) {
+			// Let the upload service handle this File Spec as a whole.
+			batches = append(batches, []services.UploadParams{params})
+			continue
+		}
+		for _, candidate := range candidates {
+			if fileInfo, ok := getVerifiedUpload(journal, candidate.LocalPath); ok {
+				uploaded = append(uploaded, fileInfo)
+				continue
+			}
+			singleFileParams = append(singleFileParams, createSingleFileUploadParams(params, candidate))
+		}
+	}
+	for len(singleFileParams) > 0 {
+		size := resumeBatchSize
+		if len(singleFileParams) < size {
+			size = len(singleFileParams)
+		}
+		batches = append(batches, singleFileParams[:size])
+		singleFileParams = singleFileParams[size:]
+	}
+	return
+}
+
+// Returns the local files matching the upload params and their target paths.
+// Only plain File Specs are resumed, so the pattern is matched as a wildcard pattern and the target has no placeholders.
+func collectUploadCandidates(uploadParams services.UploadParams) ([]clientutils.Artifact, error) {
+	// The common params are shared with the caller, so they are copied before being modified.
+	commonParams := *uploadParams.ArtifactoryCommonParams
+	uploadParams.ArtifactoryCommonParams = &commonParams
+	if !strings.Contains(uploadParams.GetTarget(), "/") {
+		uploadParams.SetTarget(uploadParams.GetTarget() + "/")
+	}
+	uploadParams.SetPattern(clientutils.ReplaceTildeWithUserHome(uploadParams.GetPattern()))
+	rootPath, err := fspatterns.GetRootPath(uploadParams.GetPattern(), false, uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	isDir, err := fileutils.IsDirExists(rootPath, uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	if !isDir || (fileutils.IsPathSymlink(rootPath) && uploadParams.IsSymlink()) {
+		artifact, err := fspatterns.GetSingleFileToUpload(rootPath, uploadParams.GetTarget(), uploadParams.IsFlat(), uploadParams.IsSymlink())
+		if err != nil {
+			return nil, err
+		}
+		return []clientutils.Artifact{artifact}, nil
+	}
+	patternRegex, err := regexp.Compile(clientutils.PrepareLocalPathForUpload(uploadParams.GetPattern(), false))
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	excludePathPattern := fspatterns.PrepareExcludePathPattern(uploadParams)
+	paths, err := fspatterns.GetPaths(rootPath, uploadParams.IsRecursive(), false, uploadParams.IsSymlink())
+	if err != nil {
+		return nil, err
+	}
+	var candidates []clientutils.Artifact
+	for _, path := range paths {
+		matches, isDir, _, err := fspatterns.PrepareAndFilterPaths(path, excludePathPattern, uploadParams.IsSymlink(), false, patternRegex)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 || isDir {
+			continue
+		}
+		artifact, err := fspatterns.GetSingleFileToUpload(path, uploadParams.GetTarget(), uploadParams.IsFlat(), uploadParams.IsSymlink())
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, artifact)
+	}
+	return candidates, nil
+}
+
+// Resuming an upload requires collecting the files of each File Spec before uploading them.
+// Regular expressions, placeholders and directories are handled only by the upload service, so File Specs using them are rejected.
+func validateResumableUpload(params services.UploadParams) error {
+	if params.IsRegexp() || params.IsIncludeDirs() || strings.ContainsAny(params.GetPattern(), placeholderChars) || strings.ContainsAny(params.GetTarget(), placeholderChars) {
+		return errorutils.CheckError(errors.New("The --resume option supports only File Specs without regular expressions, placeholders, parentheses, curly braces or include-dirs. Unsupported File Spec pattern: " + params.GetPattern()))
+	}
+	return nil
+}
+
+// A single file pattern including one of these characters isn't uploaded as is, since they are treated as a wildcard or a placeholder.
+// Such files are left to the upload service, which uploads their File Spec as a whole.
+func canSplitUpload(candidates []clientutils.Artifact) bool {
+	for _, candidate := range candidates {
+		if strings.ContainsAny(candidate.LocalPath, "*"+placeholderChars) || strings.ContainsAny(candidate.TargetPath, placeholderChars) {
+			return false
+		}
+	}
+	return true
+}
+
+func createSingleFileUploadParams(params services.UploadParams, candidate clientutils.Artifact) services.UploadParams {
+	commonParams := *params.ArtifactoryCommonParams
+	commonParams.Pattern = candidate.LocalPath
+	commonParams.Target = candidate.TargetPath
+	commonParams.ExcludePatterns = nil
+	commonParams.Regexp = false
+	commonParams.Recursive = false
+	params.ArtifactoryCommonParams = &commonParams
+	return params
+}
+
+// Returns the journal record of an uploaded file, if the file was not modified since it was uploaded.
+func getVerifiedUpload(journal *utils.TransferJournal, localPath string) (serviceutils.FileInfo, bool) {
+	fileInfo, ok := journal.GetCompleted(localPath)
+	if !ok || fileInfo.FileHashes == nil {
+		return fileInfo, false
+	}
+	details, err := fileutils.GetFileDetails(localPath)
+	if err != nil {
+		return fileInfo, false
+	}
+	return fileInfo, details.Checksum.Sha1 == fileInfo.Sha1
+}
+
+// Downloads the files, recording the downloaded files in the transfer journal.
+// Files which should be downloaded in split chunks are downloaded first, keeping the completed chunks between runs.
+// The rest of the files are then downloaded by the download service, which skips the files already downloaded.
+func (dc *DownloadCommand) resumableDownload(servicesManager *artifactory.ArtifactoryServicesManager, downloadParamsArray []services.DownloadParams) (filesInfo []serviceutils.FileInfo, totalExpected int, err error) {
+	journal, err := utils.OpenTransferJournal(dc.CommandName(), dc.rtDetails.Url, dc.Spec())
+	if err != nil {
+		return
+	}
+	for _, params := range downloadParamsArray {
+		if e := downloadSplitFilesWithResume(servicesManager, params, journal); e != nil {
+			log.Error(e)
+		}
+		if e := journal.Save(); e != nil {
+			return nil, 0, e
+		}
+	}
+	filesInfo, totalExpected, err = servicesManager.DownloadFiles(downloadParamsArray...)
+	for _, fileInfo := range filesInfo {
+		journal.AddCompleted(fileInfo.ArtifactoryPath, fileInfo)
+	}
+	if e := journal.Save(); e != nil {
+		return filesInfo, totalExpected, e
+	}
+	if err == nil && totalExpected == len(filesInfo) {
+		err = journal.Remove()
+	}
+	return
+}
+
+func downloadSplitFilesWithResume(servicesManager *artifactory.ArtifactoryServicesManager, params services.DownloadParams, journal *utils.TransferJournal) error {
+	if params.SplitCount <= 1 || params.MinSplitSize < 0 || params.IsExplode() {
+		return nil
+	}
+	// The common params are shared with the download params, so they are copied before being used for the search.
+	commonParams := *params.ArtifactoryCommonParams
+	resultItems, err := servicesManager.SearchFiles(services.SearchParams{ArtifactoryCommonParams: &commonParams})
+	if err != nil {
+		return err
+	}
+	for _, item := range resultItems {
+		if item.Type == "folder" || item.Size < params.MinSplitSize*1000 {
+			continue
+		}
+		localPath, localFileName, err := getDownloadLocalPath(params, item)
+		if err != nil {
+			return err
+		}
+		localFilePath := filepath.Join(localPath, localFileName)
+		if isVerifiedDownload(journal, item, localFilePath) {
+			continue
+		}
+		downloaded, err := downloadFileWithResume(servicesManager, journal, item, localFilePath, params.SplitCount)
+		if err != nil {
+			return err
+		}
+		if !downloaded {
+			continue
+		}
+		journal.AddCompleted(item.GetItemRelativePath(), serviceutils.FileInfo{
+			FileHashes:      &serviceutils.FileHashes{Sha1: item.Actual_Sha1, Md5: item.Actual_Md5},
+			LocalPath:       localFilePath,
+			ArtifactoryPath: item.GetItemRelativePath(),
+		})
+		if err = journal.Save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the local path of a downloaded item, in the same way the download service resolves it.
+func getDownloadLocalPath(params services.DownloadParams, item serviceutils.ResultItem) (localPath, localFileName string, err error) {
+	target, err := clientutils.BuildTargetPath(params.GetPattern(), item.GetItemRelativePath(), params.GetTarget(), true)
+	if err != nil {
+		return
+	}
+	localPath, localFileName = fileutils.GetLocalPathAndFile(item.Name, item.Path, target, params.IsFlat())
+	return
+}
+
+func isVerifiedDownload(journal *utils.TransferJournal, item serviceutils.ResultItem, localFilePath string) bool {
+	fileInfo, ok := journal.GetCompleted(item.GetItemRelativePath())
+	if !ok || fileInfo.FileHashes == nil || fileInfo.Sha1 != item.Actual_Sha1 {
+		return false
+	}
+	details, err := fileutils.GetFileDetails(localFilePath)
+	return err == nil && details.Checksum.Sha1 == item.Actual_Sha1
+}
+
+// Downloads a file in split chunks, which are kept in the journal's chunks dir until the file is complete.
+// Chunks which were partially downloaded by a previous run are resumed from where they stopped.
+// Returns false if the file can't be downloaded in chunks, leaving it to the download service.
+func downloadFileWithResume(servicesManager *artifactory.ArtifactoryServicesManager, journal *utils.TransferJournal, item serviceutils.ResultItem, localFilePath string, splitCount int) (bool, error) {
+	artDetails := servicesManager.GetConfig().GetArtDetails()
+	downloadUrl, err := serviceutils.BuildArtifactoryUrl(artDetails.GetUrl(), item.GetItemRelativePath(), make(map[string]string))
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := artDetails.CreateHttpClientDetails()
+	acceptRanges, _, err := servicesManager.Client().IsAcceptRanges(downloadUrl, &httpClientsDetails)
+	if err != nil || !acceptRanges {
+		return false, err
+	}
+	chunksDir, err := journal.ChunksDir(item.Actual_Sha1)
+	if err != nil {
+		return false, err
+	}
+	log.Info("Downloading", item.GetItemRelativePath(), "in", splitCount, "resumable chunks")
+	chunkSize := item.Size / int64(splitCount)
+	chunksPaths := make([]string, splitCount)
+	errorsList := make([]error, splitCount)
+	var wg sync.WaitGroup
+	for i := 0; i < splitCount; i++ {
+		start := chunkSize * int64(i)
+		end := start + chunkSize
+		if i == splitCount-1 {
+			end = item.Size
+		}
+		chunksPaths[i] = filepath.Join(chunksDir, strconv.Itoa(i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errorsList[i] = downloadChunk(servicesManager.Client(), httpClientsDetails, downloadUrl, chunksPaths[i], start, end)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errorsList {
+		if err != nil {
+			return false, err
+		}
+	}
+	err = mergeChunks(chunksPaths, localFilePath, item.Actual_Sha1)
+	if err != nil {
+		// The chunks don't add up to the expected file, so they can't be reused.
+		os.RemoveAll(chunksDir)
+		return false, err
+	}
+	return true, errorutils.CheckError(os.RemoveAll(chunksDir))
+}
+
+// Downloads the range [start, end) of the file into the chunk file, continuing from the end of the existing chunk.
+func downloadChunk(client *rthttpclient.ArtifactoryHttpClient, httpClientsDetails httputils.HttpClientDetails, downloadUrl, chunkPath string, start, end int64) error {
+	chunkFile, err := os.OpenFile(chunkPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer chunkFile.Close()
+	fileInfo, err := chunkFile.Stat()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	offset := start + fileInfo.Size()
+	if offset > end {
+		if err = chunkFile.Truncate(0); err != nil {
+			return errorutils.CheckError(err)
+		}
+		offset = start
+	}
+	if offset == end {
+		return nil
+	}
+	headers := map[string]string{"Range": fmt.Sprintf("bytes=%d-%d", offset, end-1)}
+	for key, value := range httpClientsDetails.Headers {
+		headers[key] = value
+	}
+	httpClientsDetails.Headers = headers
+	// The response body is left open, to be streamed into the chunk file.
+	resp, _, _, err := client.Send("GET", downloadUrl, nil, true, false, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return errorutils.CheckError(errors.New("Artifactory response: " + resp.Status))
+	}
+	_, err = io.Copy(chunkFile, resp.Body)
+	return errorutils.CheckError(err)
+}
+
+// Merges the chunks into the local file, verifying the checksum of the merged file.
+func mergeChunks(chunksPaths []string, localFilePath, expectedSha1 string) error {
+	if err := os.MkdirAll(filepath.Dir(localFilePath), 0777); err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(localFilePath), filepath.Base(localFilePath))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer os.Remove(tempFile.Name())
+	hash := sha1.New()
+	writer := io.MultiWriter(tempFile, hash)
+	for _, chunkPath := range chunksPaths {
+		if err = appendFile(writer, chunkPath); err != nil {
+			tempFile.Close()
+			return err
+		}
+	}
+	if err = tempFile.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if actualSha1 := hex.EncodeToString(hash.Sum(nil)); actualSha1 != expectedSha1 {
+		return errorutils.CheckError(errors.New("Checksum mismatch for " + localFilePath + ", expected: " + expectedSha1 + ", actual: " + actualSha1))
+	}
+	return errorutils.CheckError(os.Rename(tempFile.Name(), localFilePath))
+}
+
+func appendFile(writer io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	_, err = io.Copy(writer, file)
+	return errorutils.CheckError(err)
+}
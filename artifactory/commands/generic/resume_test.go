@@ -0,0 +1,131 @@
+package generic
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	rthttpclient "github.com/jfrog/jfrog-client-go/artifactory/httpclient"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDownloadChunksWithResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer ts.Close()
+	tempDir, err := ioutil.TempDir("", "resume")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	artAuth, err := (&config.ArtifactoryDetails{Url: ts.URL + "/"}).CreateArtAuthConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := rthttpclient.ArtifactoryClientBuilder().SetArtDetails(&artAuth).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a chunk which was partially downloaded by an interrupted run.
+	chunksPaths := []string{filepath.Join(tempDir, "0"), filepath.Join(tempDir, "1")}
+	if err = ioutil.WriteFile(chunksPaths[0], content[:123], 0600); err != nil {
+		t.Fatal(err)
+	}
+	for i, chunkPath := range chunksPaths {
+		start := int64(i * 500)
+		err = downloadChunk(client, artAuth.CreateHttpClientDetails(), ts.URL+"/repo/file", chunkPath, start, start+500)
+		if err != nil {
+			t.Fatal("Chunk", strconv.Itoa(i), err)
+		}
+	}
+
+	hash := sha1.Sum(content)
+	localFilePath := filepath.Join(tempDir, "out", "file")
+	if err = mergeChunks(chunksPaths, localFilePath, hex.EncodeToString(hash[:])); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := ioutil.ReadFile(localFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(merged, content) {
+		t.Error("The merged file doesn't match the remote file.")
+	}
+
+	if err = mergeChunks(chunksPaths, localFilePath, "wrong"); err == nil {
+		t.Error("Expected a checksum mismatch error.")
+	}
+}
+
+func TestValidateResumableUpload(t *testing.T) {
+	tests := []struct {
+		pattern string
+		target  string
+		regexp  bool
+		valid   bool
+	}{
+		{"dir/*.zip", "repo/path/", false, true},
+		{"dir/(*).zip", "repo/path/{1}.zip", false, false},
+		{"dir/*.zip", "repo/{1}/", false, false},
+		{"dir/a{b}.zip", "repo/path/", false, false},
+		{"dir/.*\\.zip", "repo/path/", true, false},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			params := services.UploadParams{ArtifactoryCommonParams: &serviceutils.ArtifactoryCommonParams{Pattern: test.pattern, Target: test.target, Regexp: test.regexp}}
+			if err := validateResumableUpload(params); (err == nil) != test.valid {
+				t.Errorf("Expected valid: %t, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestCollectUploadCandidates(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "resume")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	for _, name := range []string{"a.zip", "b.zip", "c.txt", "d (1).zip"} {
+		if err = ioutil.WriteFile(filepath.Join(tempDir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	params := services.UploadParams{ArtifactoryCommonParams: &serviceutils.ArtifactoryCommonParams{Pattern: filepath.Join(tempDir, "*.zip"), Target: "repo/path/"}}
+	params.Flat = true
+	candidates, err := collectUploadCandidates(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targets := make(map[string]string)
+	for _, candidate := range candidates {
+		targets[filepath.Base(candidate.LocalPath)] = candidate.TargetPath
+	}
+	expected := map[string]string{"a.zip": "repo/path/a.zip", "b.zip": "repo/path/b.zip", "d (1).zip": "repo/path/d (1).zip"}
+	if len(targets) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, targets)
+	}
+	for name, target := range expected {
+		if targets[name] != target {
+			t.Errorf("Expected the target of %s to be %s, got %s", name, target, targets[name])
+		}
+	}
+	// The file including parentheses isn't uploaded as a single file pattern.
+	if canSplitUpload(candidates) {
+		t.Error("Expected the upload not to be split")
+	}
+}
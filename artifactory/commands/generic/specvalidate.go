@@ -0,0 +1,114 @@
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"sort"
+	"strings"
+)
+
+// The File Spec validation applied by each command which accepts a spec.
+type specValidation struct {
+	isTargetMandatory bool
+	isSearchBasedSpec bool
+}
+
+var specValidations = map[string]specValidation{
+	"upload":                 {isTargetMandatory: true, isSearchBasedSpec: false},
+	"download":               {isTargetMandatory: false, isSearchBasedSpec: true},
+	"copy":                   {isTargetMandatory: true, isSearchBasedSpec: true},
+	"move":                   {isTargetMandatory: true, isSearchBasedSpec: true},
+	"delete":                 {isTargetMandatory: false, isSearchBasedSpec: true},
+	"search":                 {isTargetMandatory: false, isSearchBasedSpec: true},
+	"build-add-dependencies": {isTargetMandatory: false, isSearchBasedSpec: false},
+}
+
+func SpecValidationCommands() []string {
+	var commands []string
+	for command := range specValidations {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// Validates a File Spec offline - the spec is parsed and checked the same way the commands check it, without contacting Artifactory.
+type SpecValidateCommand struct {
+	specPath    string
+	specVars    map[string]string
+	commandName string
+	// The validation error of each command, or nil if the spec is valid for it.
+	results map[string]error
+}
+
+func NewSpecValidateCommand() *SpecValidateCommand {
+	return &SpecValidateCommand{}
+}
+
+func (svc *SpecValidateCommand) SetSpecPath(specPath string) *SpecValidateCommand {
+	svc.specPath = specPath
+	return svc
+}
+
+func (svc *SpecValidateCommand) SetSpecVars(specVars map[string]string) *SpecValidateCommand {
+	svc.specVars = specVars
+	return svc
+}
+
+// Validates the spec only for the given command. If empty, the spec is validated for all the commands.
+func (svc *SpecValidateCommand) SetCommandName(commandName string) *SpecValidateCommand {
+	svc.commandName = commandName
+	return svc
+}
+
+func (svc *SpecValidateCommand) Results() map[string]error {
+	return svc.results
+}
+
+// The command is offline, so no usage is reported.
+func (svc *SpecValidateCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return nil, nil
+}
+
+func (svc *SpecValidateCommand) CommandName() string {
+	return "rt_spec_validate"
+}
+
+func (svc *SpecValidateCommand) Run() error {
+	commands := SpecValidationCommands()
+	if svc.commandName != "" {
+		if _, ok := specValidations[svc.commandName]; !ok {
+			return errorutils.CheckError(fmt.Errorf("Unsupported command '%s'. Supported commands: %s.", svc.commandName, strings.Join(commands, ", ")))
+		}
+		commands = []string{svc.commandName}
+	}
+	fileSpec, err := spec.CreateSpecFromFile(svc.specPath, svc.specVars)
+	if err != nil {
+		return err
+	}
+
+	svc.results = make(map[string]error)
+	validCount := 0
+	for _, command := range commands {
+		validation := specValidations[command]
+		err := spec.ValidateSpec(fileSpec.Files, validation.isTargetMandatory, validation.isSearchBasedSpec)
+		svc.results[command] = err
+		if err == nil {
+			validCount++
+			log.Output(fmt.Sprintf("%s: valid", command))
+		} else {
+			log.Output(fmt.Sprintf("%s: invalid - %s", command, err.Error()))
+		}
+	}
+	if validCount == 0 {
+		if svc.commandName != "" {
+			return errorutils.CheckError(fmt.Errorf("The File Spec is invalid for the %s command: %s", svc.commandName, svc.results[svc.commandName].Error()))
+		}
+		return errorutils.CheckError(errors.New("The File Spec is invalid for all the commands."))
+	}
+	return nil
+}
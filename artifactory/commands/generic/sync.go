@@ -0,0 +1,479 @@
+package generic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/spec"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	logUtils "github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils/checksum"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	SyncUpload   = "upload"
+	SyncDownload = "download"
+	SyncDelete   = "delete"
+)
+
+// A single change required to make the destination identical to the source.
+type SyncAction struct {
+	Action string `json:"action"`
+	Path   string `json:"path"`
+}
+
+// Base for the sync-upload and sync-download commands.
+// Only the files which are missing or have a different checksum in the destination are transferred.
+// If deleteExtras is set, files which exist only in the destination are deleted.
+type SyncCommand struct {
+	GenericCommand
+	localDir     string
+	remotePath   string
+	deleteExtras bool
+	quiet        bool
+	actions      []SyncAction
+}
+
+func (sc *SyncCommand) SetLocalDir(localDir string) *SyncCommand {
+	sc.localDir = localDir
+	return sc
+}
+
+func (sc *SyncCommand) SetRemotePath(remotePath string) *SyncCommand {
+	sc.remotePath = strings.Trim(remotePath, "/")
+	return sc
+}
+
+func (sc *SyncCommand) SetDeleteExtras(deleteExtras bool) *SyncCommand {
+	sc.deleteExtras = deleteExtras
+	return sc
+}
+
+func (sc *SyncCommand) SetQuiet(quiet bool) *SyncCommand {
+	sc.quiet = quiet
+	return sc
+}
+
+// Returns the changes found by the last run. When running with dry-run, these are the changes which would have been made.
+func (sc *SyncCommand) Actions() []SyncAction {
+	return sc.actions
+}
+
+func (sc *SyncCommand) addAction(action, path string) {
+	sc.actions = append(sc.actions, SyncAction{Action: action, Path: path})
+}
+
+// Lists the files under the remote path. The key is the path relative to the remote path.
+// The remote path may include '*' and '?', which are wildcards in a search pattern and in the query created for it.
+// The results are therefore filtered, keeping only the files which are actually under the remote path.
+func (sc *SyncCommand) listRemoteFiles() (map[string]clientutils.ResultItem, error) {
+	servicesManager, err := utils.CreateServiceManager(sc.rtDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	itemsFind, err := createRemoteFilesQuery(sc.remotePath)
+	if err != nil {
+		return nil, err
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.ArtifactoryCommonParams = &clientutils.ArtifactoryCommonParams{Aql: clientutils.Aql{ItemsFind: itemsFind}}
+	resultItems, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles := make(map[string]clientutils.ResultItem)
+	for _, item := range resultItems {
+		if !strings.HasPrefix(item.GetItemRelativePath(), sc.remotePath+"/") {
+			continue
+		}
+		relativePath := strings.TrimPrefix(item.GetItemRelativePath(), sc.remotePath+"/")
+		remoteFiles[relativePath] = item
+	}
+	return remoteFiles, nil
+}
+
+// Creates a query finding the files in the repository path and in all of its sub-paths.
+func createRemoteFilesQuery(remotePath string) (string, error) {
+	sections := strings.SplitN(remotePath, "/", 2)
+	query := map[string]interface{}{"repo": sections[0], "type": "file"}
+	if len(sections) == 2 {
+		query["$or"] = []interface{}{
+			map[string]interface{}{"path": sections[1]},
+			map[string]interface{}{"path": map[string]string{"$match": sections[1] + "/*"}},
+		}
+	}
+	itemsFind, err := json.Marshal(query)
+	return string(itemsFind), errorutils.CheckError(err)
+}
+
+// Lists the files under the local dir. The key is the path relative to the local dir, using forward slashes.
+func (sc *SyncCommand) listLocalFiles() (map[string]string, error) {
+	localFiles := make(map[string]string)
+	err := filepath.Walk(sc.localDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(sc.localDir, path)
+		if err != nil {
+			return err
+		}
+		localFiles[filepath.ToSlash(relativePath)] = path
+		return nil
+	})
+	return localFiles, errorutils.CheckError(err)
+}
+
+// Compares the local and remote files.
+// Returns the relative paths of the files which should be transferred from the source to the destination,
+// and the relative paths which exist only in the destination.
+func diffFiles(sourceFiles, destinationFiles []string, isChanged func(relativePath string) (bool, error)) (transfer, extras []string, err error) {
+	destinationSet := make(map[string]bool)
+	for _, relativePath := range destinationFiles {
+		destinationSet[relativePath] = true
+	}
+	sourceSet := make(map[string]bool)
+	for _, relativePath := range sourceFiles {
+		sourceSet[relativePath] = true
+		if destinationSet[relativePath] {
+			changed, err := isChanged(relativePath)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !changed {
+				continue
+			}
+		}
+		transfer = append(transfer, relativePath)
+	}
+	for _, relativePath := range destinationFiles {
+		if !sourceSet[relativePath] {
+			extras = append(extras, relativePath)
+		}
+	}
+	sort.Strings(transfer)
+	sort.Strings(extras)
+	return
+}
+
+func calcSha1(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer file.Close()
+	checksums, err := checksum.Calc(file, checksum.SHA1)
+	if err != nil {
+		return "", err
+	}
+	return checksums[checksum.SHA1], nil
+}
+
+// Prints the changes found, when running with dry-run.
+func (sc *SyncCommand) printActions() {
+	if len(sc.actions) == 0 {
+		log.Info("The destination is already in sync.")
+	}
+	for _, action := range sc.actions {
+		log.Output(fmt.Sprintf("%-8s  %s", action.Action, action.Path))
+	}
+}
+
+func (sc *SyncCommand) confirmDelete(paths []string) bool {
+	if sc.quiet || len(paths) == 0 {
+		return true
+	}
+	for _, path := range paths {
+		log.Output("  " + path)
+	}
+	return cliutils.InteractiveConfirm("Are you sure you want to delete the above paths?")
+}
+
+func getLocalRelativePaths(localFiles map[string]string) []string {
+	var relativePaths []string
+	for relativePath := range localFiles {
+		relativePaths = append(relativePaths, relativePath)
+	}
+	return relativePaths
+}
+
+func getRemoteRelativePaths(remoteFiles map[string]clientutils.ResultItem) []string {
+	var relativePaths []string
+	for relativePath := range remoteFiles {
+		relativePaths = append(relativePaths, relativePath)
+	}
+	return relativePaths
+}
+
+// Mirrors a local directory to a repository path.
+type SyncUploadCommand struct {
+	SyncCommand
+	uploadConfiguration *utils.UploadConfiguration
+}
+
+func NewSyncUploadCommand() *SyncUploadCommand {
+	return &SyncUploadCommand{SyncCommand: SyncCommand{GenericCommand: *NewGenericCommand()}}
+}
+
+func (suc *SyncUploadCommand) SetUploadConfiguration(uploadConfiguration *utils.UploadConfiguration) *SyncUploadCommand {
+	suc.uploadConfiguration = uploadConfiguration
+	return suc
+}
+
+func (suc *SyncUploadCommand) CommandName() string {
+	return "rt_sync_upload"
+}
+
+func (suc *SyncUploadCommand) Run() error {
+	localFiles, err := suc.listLocalFiles()
+	if err != nil {
+		return err
+	}
+	remoteFiles, err := suc.listRemoteFiles()
+	if err != nil {
+		return err
+	}
+	upload, extras, err := diffFiles(getLocalRelativePaths(localFiles), getRemoteRelativePaths(remoteFiles), func(relativePath string) (bool, error) {
+		localSha1, err := calcSha1(localFiles[relativePath])
+		return localSha1 != remoteFiles[relativePath].Actual_Sha1, err
+	})
+	if err != nil {
+		return err
+	}
+	for _, relativePath := range upload {
+		suc.addAction(SyncUpload, localFiles[relativePath])
+	}
+	if suc.deleteExtras {
+		for _, relativePath := range extras {
+			suc.addAction(SyncDelete, remoteFiles[relativePath].GetItemRelativePath())
+		}
+	} else {
+		extras = nil
+	}
+	if suc.DryRun() {
+		suc.printActions()
+		return nil
+	}
+
+	if len(upload) > 0 {
+		uploadSpec, err := suc.createUploadSpec(localFiles, upload)
+		if err != nil {
+			return err
+		}
+		uploadCommand := NewUploadCommand()
+		uploadCommand.SetUploadConfiguration(suc.uploadConfiguration).SetBuildConfiguration(new(utils.BuildConfiguration)).SetSpec(uploadSpec).SetRtDetails(suc.rtDetails)
+		err = uploadCommand.Run()
+		logUtils.CloseLogFile(uploadCommand.LogFile())
+		suc.result.SetSuccessCount(uploadCommand.Result().SuccessCount())
+		suc.result.SetFailCount(uploadCommand.Result().FailCount())
+		suc.result.AddPaths(uploadCommand.Result().Paths()...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var deleteItems []clientutils.ResultItem
+	var deletePaths []string
+	for _, relativePath := range extras {
+		deleteItems = append(deleteItems, remoteFiles[relativePath])
+		deletePaths = append(deletePaths, remoteFiles[relativePath].GetItemRelativePath())
+	}
+	if len(deleteItems) == 0 || !suc.confirmDelete(deletePaths) {
+		return nil
+	}
+	deleteCommand := NewDeleteCommand()
+	deleteCommand.SetDeleteItems(deleteItems).SetRtDetails(suc.rtDetails)
+	success, failed, err := deleteCommand.DeleteFiles()
+	suc.result.SetSuccessCount(suc.result.SuccessCount() + success)
+	suc.result.SetFailCount(suc.result.FailCount() + failed)
+	if failed == 0 {
+		suc.result.AddPaths(deletePaths...)
+	}
+	return err
+}
+
+// Creates a spec uploading the files of each directory to the matching path under the remote path.
+func (suc *SyncUploadCommand) createUploadSpec(localFiles map[string]string, upload []string) (*spec.SpecFiles, error) {
+	uploadSpec := new(spec.SpecFiles)
+	dirs, filesByDir := groupByDir(upload)
+	for _, dir := range dirs {
+		var fileNames []string
+		for _, relativePath := range filesByDir[dir] {
+			fileNames = append(fileNames, path.Base(relativePath))
+		}
+		localDir, err := filepath.Abs(filepath.Dir(localFiles[filesByDir[dir][0]]))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		pattern, isRecursive := getDirUploadPattern(localDir, fileNames)
+		uploadSpec.Files = append(uploadSpec.Files, spec.File{Pattern: pattern, Target: path.Join(suc.remotePath, dir) + "/",
+			Recursive: fmt.Sprint(isRecursive), Regexp: "true", Flat: "true"})
+	}
+	return uploadSpec, nil
+}
+
+// Returns a regexp upload pattern matching only the given files of the local dir.
+// The file names are quoted, since they may include characters which have a special meaning in a pattern.
+// The root path of a regexp pattern ends before its first group, so the group starts at the first section of the dir which requires quoting.
+// In this case, the pattern should be recursive to find the files under the root path.
+// The groups are non-capturing, so that the upload service doesn't replace placeholders in the target.
+func getDirUploadPattern(localDir string, fileNames []string) (pattern string, isRecursive bool) {
+	separator := string(filepath.Separator)
+	quotedSeparator := regexp.QuoteMeta(separator)
+	var quotedNames []string
+	for _, fileName := range fileNames {
+		quotedNames = append(quotedNames, regexp.QuoteMeta(fileName))
+	}
+	pattern = "(?:" + strings.Join(quotedNames, "|") + ")"
+	sections := strings.Split(localDir, separator)
+	rootSections := 0
+	for rootSections < len(sections) && regexp.QuoteMeta(sections[rootSections]) == sections[rootSections] {
+		rootSections++
+	}
+	if rootSections < len(sections) {
+		for i := rootSections; i < len(sections); i++ {
+			sections[i] = regexp.QuoteMeta(sections[i])
+		}
+		pattern = "(?:" + strings.Join(sections[rootSections:], quotedSeparator) + quotedSeparator + pattern + ")"
+	}
+	return strings.Join(sections[:rootSections], quotedSeparator) + quotedSeparator + pattern + "$", rootSections < len(sections)
+}
+
+// Groups the relative paths by their dirs. The dirs are returned in the order of the paths.
+func groupByDir(relativePaths []string) (dirs []string, pathsByDir map[string][]string) {
+	pathsByDir = make(map[string][]string)
+	for _, relativePath := range relativePaths {
+		dir := path.Dir(relativePath)
+		if _, ok := pathsByDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		pathsByDir[dir] = append(pathsByDir[dir], relativePath)
+	}
+	return
+}
+
+// Mirrors a repository path to a local directory.
+type SyncDownloadCommand struct {
+	SyncCommand
+	downloadConfiguration *utils.DownloadConfiguration
+}
+
+func NewSyncDownloadCommand() *SyncDownloadCommand {
+	return &SyncDownloadCommand{SyncCommand: SyncCommand{GenericCommand: *NewGenericCommand()}}
+}
+
+func (sdc *SyncDownloadCommand) SetDownloadConfiguration(downloadConfiguration *utils.DownloadConfiguration) *SyncDownloadCommand {
+	sdc.downloadConfiguration = downloadConfiguration
+	return sdc
+}
+
+func (sdc *SyncDownloadCommand) CommandName() string {
+	return "rt_sync_download"
+}
+
+func (sdc *SyncDownloadCommand) Run() error {
+	if err := os.MkdirAll(sdc.localDir, 0777); err != nil {
+		return errorutils.CheckError(err)
+	}
+	localFiles, err := sdc.listLocalFiles()
+	if err != nil {
+		return err
+	}
+	remoteFiles, err := sdc.listRemoteFiles()
+	if err != nil {
+		return err
+	}
+	download, extras, err := diffFiles(getRemoteRelativePaths(remoteFiles), getLocalRelativePaths(localFiles), func(relativePath string) (bool, error) {
+		localSha1, err := calcSha1(localFiles[relativePath])
+		return localSha1 != remoteFiles[relativePath].Actual_Sha1, err
+	})
+	if err != nil {
+		return err
+	}
+	for _, relativePath := range download {
+		sdc.addAction(SyncDownload, remoteFiles[relativePath].GetItemRelativePath())
+	}
+	if sdc.deleteExtras {
+		for _, relativePath := range extras {
+			sdc.addAction(SyncDelete, localFiles[relativePath])
+		}
+	} else {
+		extras = nil
+	}
+	if sdc.DryRun() {
+		sdc.printActions()
+		return nil
+	}
+
+	if len(download) > 0 {
+		downloadSpec, err := sdc.createDownloadSpec(remoteFiles, download)
+		if err != nil {
+			return err
+		}
+		downloadCommand := NewDownloadCommand()
+		downloadCommand.SetConfiguration(sdc.downloadConfiguration).SetBuildConfiguration(new(utils.BuildConfiguration)).SetSpec(downloadSpec).SetRtDetails(sdc.rtDetails)
+		err = downloadCommand.Run()
+		logUtils.CloseLogFile(downloadCommand.LogFile())
+		sdc.result.SetSuccessCount(downloadCommand.Result().SuccessCount())
+		sdc.result.SetFailCount(downloadCommand.Result().FailCount())
+		sdc.result.AddPaths(downloadCommand.Result().Paths()...)
+		if err != nil {
+			return err
+		}
+	}
+
+	var deletePaths []string
+	for _, relativePath := range extras {
+		deletePaths = append(deletePaths, localFiles[relativePath])
+	}
+	if len(deletePaths) == 0 || !sdc.confirmDelete(deletePaths) {
+		return nil
+	}
+	var deleteErr error
+	for _, localPath := range deletePaths {
+		log.Info("Deleting", localPath)
+		if err = os.Remove(localPath); err != nil {
+			log.Error(err)
+			deleteErr = errors.New("Sync finished with errors, please review the logs.")
+			sdc.result.SetFailCount(sdc.result.FailCount() + 1)
+			continue
+		}
+		sdc.result.SetSuccessCount(sdc.result.SuccessCount() + 1)
+		sdc.result.AddPaths(localPath)
+	}
+	return deleteErr
+}
+
+// Creates a spec downloading the files of each remote dir to the matching dir under the local dir.
+// The files are found by their exact paths and names, since '*' and '?' in a pattern are wildcards.
+func (sdc *SyncDownloadCommand) createDownloadSpec(remoteFiles map[string]clientutils.ResultItem, download []string) (*spec.SpecFiles, error) {
+	downloadSpec := new(spec.SpecFiles)
+	dirs, filesByDir := groupByDir(download)
+	for _, dir := range dirs {
+		var names []interface{}
+		for _, relativePath := range filesByDir[dir] {
+			names = append(names, map[string]string{"name": remoteFiles[relativePath].Name})
+		}
+		item := remoteFiles[filesByDir[dir][0]]
+		itemsFind, err := json.Marshal(map[string]interface{}{"repo": item.Repo, "path": item.Path, "$or": names})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		localDir := filepath.Join(sdc.localDir, filepath.FromSlash(dir)) + string(filepath.Separator)
+		downloadSpec.Files = append(downloadSpec.Files, spec.File{Aql: clientutils.Aql{ItemsFind: string(itemsFind)}, Target: localDir, Flat: "true"})
+	}
+	return downloadSpec, nil
+}
@@ -0,0 +1,165 @@
+package generic
+
+import (
+	"encoding/json"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	source := []string{"a.txt", "b.txt", "dir/c.txt"}
+	destination := []string{"b.txt", "dir/c.txt", "d.txt"}
+	changed := map[string]bool{"b.txt": true}
+	transfer, extras, err := diffFiles(source, destination, func(relativePath string) (bool, error) {
+		return changed[relativePath], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a.txt", "b.txt"}; !reflect.DeepEqual(transfer, expected) {
+		t.Error("Expected to transfer", expected, "got:", transfer)
+	}
+	if expected := []string{"d.txt"}; !reflect.DeepEqual(extras, expected) {
+		t.Error("Expected extras", expected, "got:", extras)
+	}
+}
+
+func TestListLocalFiles(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	for _, relativePath := range []string{"a.txt", "dir/b*.txt", "dir/empty/"} {
+		localPath := filepath.Join(tempDir, filepath.FromSlash(relativePath))
+		if err = os.MkdirAll(filepath.Dir(localPath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Base(relativePath) != "empty" {
+			if err = ioutil.WriteFile(localPath, []byte(relativePath), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	sc := new(SyncCommand).SetLocalDir(tempDir)
+	localFiles, err := sc.listLocalFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"a.txt": filepath.Join(tempDir, "a.txt"), "dir/b*.txt": filepath.Join(tempDir, "dir", "b*.txt")}
+	if !reflect.DeepEqual(localFiles, expected) {
+		t.Error("Expected local files", expected, "got:", localFiles)
+	}
+}
+
+func TestCreateUploadSpec(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	localFiles := map[string]string{
+		"a?.txt":      filepath.Join(tempDir, "a?.txt"),
+		"b{1}.txt":    filepath.Join(tempDir, "b{1}.txt"),
+		"d(1)/c*.txt": filepath.Join(tempDir, "d(1)", "c*.txt"),
+	}
+	suc := NewSyncUploadCommand()
+	suc.SetRemotePath("repo/path/")
+	uploadSpec, err := suc.createUploadSpec(localFiles, []string{"a?.txt", "b{1}.txt", "d(1)/c*.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uploadSpec.Files) != 2 {
+		t.Fatalf("Expected a spec file for each dir, got %d.", len(uploadSpec.Files))
+	}
+
+	// The files of a plain dir are found directly under the dir.
+	file := uploadSpec.Files[0]
+	if file.Regexp != "true" || file.Recursive != "false" || file.Target != "repo/path/" {
+		t.Errorf("Unexpected spec file %+v.", file)
+	}
+	assertUploadPattern(t, file.Pattern, tempDir, []string{localFiles["a?.txt"], localFiles["b{1}.txt"]}, []string{filepath.Join(tempDir, "ab.txt")})
+
+	// The root path of a dir which requires quoting ends before it.
+	file = uploadSpec.Files[1]
+	if file.Regexp != "true" || file.Recursive != "true" || file.Target != "repo/path/d(1)/" {
+		t.Errorf("Unexpected spec file %+v.", file)
+	}
+	assertUploadPattern(t, file.Pattern, tempDir, []string{localFiles["d(1)/c*.txt"]}, []string{filepath.Join(tempDir, "d(1)", "cd.txt"), filepath.Join(tempDir, "d1", "c*.txt")})
+}
+
+func assertUploadPattern(t *testing.T, uploadPattern, expectedRootPath string, matching, notMatching []string) {
+	if rootPath := utils.GetRootPath(uploadPattern, true); rootPath != expectedRootPath {
+		t.Errorf("Expected the root path %s, got %s.", expectedRootPath, rootPath)
+	}
+	pattern := regexp.MustCompile(uploadPattern)
+	for _, localPath := range matching {
+		// The pattern shouldn't capture groups, which would be used as placeholders in the target.
+		if groups := pattern.FindStringSubmatch(localPath); len(groups) != 1 {
+			t.Errorf("Expected %s to match %s without groups, got %v.", uploadPattern, localPath, groups)
+		}
+	}
+	for _, localPath := range notMatching {
+		if pattern.MatchString(localPath) {
+			t.Errorf("Expected %s not to match %s.", uploadPattern, localPath)
+		}
+	}
+}
+
+func TestCreateDownloadSpec(t *testing.T) {
+	remoteFiles := map[string]clientutils.ResultItem{
+		"dir/a*.txt": {Repo: "repo", Path: "path/dir", Name: "a*.txt"},
+		"dir/b.txt":  {Repo: "repo", Path: "path/dir", Name: "b.txt"},
+	}
+	sdc := NewSyncDownloadCommand()
+	sdc.SetLocalDir("out")
+	downloadSpec, err := sdc.createDownloadSpec(remoteFiles, []string{"dir/a*.txt", "dir/b.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(downloadSpec.Files) != 1 {
+		t.Fatalf("Expected 1 spec file, got %d.", len(downloadSpec.Files))
+	}
+	file := downloadSpec.Files[0]
+	if file.Pattern != "" || file.Target != filepath.Join("out", "dir")+string(filepath.Separator) {
+		t.Errorf("Unexpected spec file %+v.", file)
+	}
+	var itemsFind map[string]interface{}
+	if err = json.Unmarshal([]byte(file.Aql.ItemsFind), &itemsFind); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"repo": "repo", "path": "path/dir", "$or": []interface{}{
+		map[string]interface{}{"name": "a*.txt"},
+		map[string]interface{}{"name": "b.txt"},
+	}}
+	if !reflect.DeepEqual(itemsFind, expected) {
+		t.Error("Expected the query", expected, "got:", itemsFind)
+	}
+}
+
+func TestCreateRemoteFilesQuery(t *testing.T) {
+	tests := []struct {
+		remotePath string
+		expected   string
+	}{
+		{"repo", `{"repo":"repo","type":"file"}`},
+		{"repo/a*/b", `{"$or":[{"path":"a*/b"},{"path":{"$match":"a*/b/*"}}],"repo":"repo","type":"file"}`},
+	}
+	for _, test := range tests {
+		t.Run(test.remotePath, func(t *testing.T) {
+			itemsFind, err := createRemoteFilesQuery(test.remotePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if itemsFind != test.expected {
+				t.Errorf("Expected the query %s, got %s.", test.expected, itemsFind)
+			}
+		})
+	}
+}
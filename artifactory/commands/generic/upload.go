@@ -110,7 +110,13 @@ func (uc *UploadCommand) upload() error {
 	}
 
 	// Perform upload.
-	filesInfo, successCount, failCount, err := servicesManager.UploadFiles(uploadParamsArray...)
+	var filesInfo []clientutils.FileInfo
+	var successCount, failCount int
+	if uc.uploadConfiguration.Resume && !uc.DryRun() {
+		filesInfo, successCount, failCount, err = uc.resumableUpload(servicesManager, uploadParamsArray, rtDetails.Url)
+	} else {
+		filesInfo, successCount, failCount, err = servicesManager.UploadFiles(uploadParamsArray...)
+	}
 	if err != nil {
 		errorOccurred = true
 		log.Error(err)
@@ -118,6 +124,9 @@ func (uc *UploadCommand) upload() error {
 	result := uc.Result()
 	result.SetSuccessCount(successCount)
 	result.SetFailCount(failCount)
+	for _, fileInfo := range filesInfo {
+		result.AddPaths(fileInfo.ArtifactoryPath)
+	}
 	if errorOccurred {
 		err = errors.New("Upload finished with errors, Please review the logs.")
 		return err
@@ -133,7 +142,9 @@ func (uc *UploadCommand) upload() error {
 			partial.Artifacts = buildArtifacts
 			partial.ModuleId = uc.buildConfiguration.Module
 		}
-		err = utils.SavePartialBuildInfo(uc.buildConfiguration.BuildName, uc.buildConfiguration.BuildNumber, populateFunc)
+		if err = utils.SavePartialBuildInfo(uc.buildConfiguration.BuildName, uc.buildConfiguration.BuildNumber, populateFunc); err == nil {
+			result.AddModules(uc.buildConfiguration.Module)
+		}
 	}
 	return err
 }
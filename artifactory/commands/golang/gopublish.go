@@ -20,6 +20,7 @@ type GoPublishCommand struct {
 	buildConfiguration *utils.BuildConfiguration
 	dependencies       string
 	version            string
+	allTags            bool
 	result             *commandutils.Result
 	GoParamsCommand
 }
@@ -37,6 +38,12 @@ func (gpc *GoPublishCommand) SetVersion(version string) *GoPublishCommand {
 	return gpc
 }
 
+// Publish the versions of all the semver git tags of the module, rather than the version of the working tree.
+func (gpc *GoPublishCommand) SetAllTags(allTags bool) *GoPublishCommand {
+	gpc.allTags = allTags
+	return gpc
+}
+
 func (gpc *GoPublishCommand) SetDependencies(dependencies string) *GoPublishCommand {
 	gpc.dependencies = dependencies
 	return gpc
@@ -57,6 +64,9 @@ func (gpc *GoPublishCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	if gpc.allTags && gpc.dependencies != "" {
+		return errorutils.CheckError(errors.New("Publishing dependencies is not supported when publishing all tags."))
+	}
 
 	err = golang.LogGoVersion()
 	if err != nil {
@@ -91,6 +101,10 @@ func (gpc *GoPublishCommand) Run() error {
 		}
 	}
 
+	if gpc.allTags {
+		return gpc.publishAllTags(serviceManager)
+	}
+
 	goProject, err := project.Load(gpc.version)
 	if err != nil {
 		return err
@@ -133,7 +147,13 @@ func (gpc *GoPublishCommand) Run() error {
 		if err != nil {
 			return err
 		}
-		err = utils.SaveBuildInfo(buildName, buildNumber, goProject.BuildInfo(true, gpc.buildConfiguration.Module))
+		buildInfo := goProject.BuildInfo(true, gpc.buildConfiguration.Module)
+		if err = utils.SaveBuildInfo(buildName, buildNumber, buildInfo); err != nil {
+			return err
+		}
+		for _, module := range buildInfo.Modules {
+			result.AddModules(module.Id)
+		}
 	}
 
 	return err
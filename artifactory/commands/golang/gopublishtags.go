@@ -0,0 +1,140 @@
+package golang
+
+import (
+	"errors"
+	"github.com/jfrog/gocmd/cmd"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/git"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/golang/project"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path/filepath"
+)
+
+// Publishes the versions of the module held by its semver git tags, which are missing from the target repository.
+// The files of each version are taken from its tag, rather than from the working tree.
+func (gpc *GoPublishCommand) publishAllTags(serviceManager *artifactory.ArtifactoryServicesManager) error {
+	currentProject, err := project.Load("")
+	if err != nil {
+		return err
+	}
+	moduleName := currentProject.ModuleName()
+	projectRoot, err := cmd.GetProjectRoot()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	gitRoot, exists, err := fileutils.FindUpstream(".git", fileutils.Dir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if !exists {
+		return errorutils.CheckError(errors.New("Publishing all tags requires the module to be in a git repository."))
+	}
+	// The tags of a module in a subdirectory of the repository are prefixed by the directory.
+	moduleDir, err := filepath.Rel(gitRoot, projectRoot)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	tags, err := git.NewManager(gitRoot).ListTags()
+	if err != nil {
+		return err
+	}
+	tagVersions := project.VersionsFromTags(tags, filepath.ToSlash(moduleDir), moduleName)
+	if len(tagVersions) == 0 {
+		log.Info("No semver tags were found for", moduleName)
+		return nil
+	}
+
+	result := gpc.Result()
+	for _, tagVersion := range tagVersions {
+		published, err := isVersionPublished(gpc.TargetRepo(), moduleName, tagVersion.Version, serviceManager)
+		if err != nil {
+			return err
+		}
+		if published {
+			log.Info("Skipping", moduleName+"@"+tagVersion.Version, "since it already exists in", gpc.TargetRepo())
+			continue
+		}
+		published, err = gpc.publishTag(gitRoot, tagVersion, moduleDir, moduleName, serviceManager)
+		if err != nil {
+			return err
+		}
+		if published {
+			result.SetSuccessCount(result.SuccessCount() + 1)
+		}
+	}
+	return nil
+}
+
+// Extracts the tag into a temp directory and publishes the module from there.
+// Tags without a go.mod file, or with a different module name, are skipped.
+func (gpc *GoPublishCommand) publishTag(gitRoot string, tagVersion project.TagVersion, moduleDir, moduleName string, serviceManager *artifactory.ArtifactoryServicesManager) (bool, error) {
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return false, err
+	}
+	defer fileutils.RemoveTempDir(tempDirPath)
+
+	log.Debug("Extracting tag", tagVersion.Tag, "to", tempDirPath)
+	if err = git.NewManager(gitRoot).ExtractTag(tagVersion.Tag, tempDirPath); err != nil {
+		return false, err
+	}
+	tagProjectPath := filepath.Join(tempDirPath, moduleDir)
+	exists, err := fileutils.IsFileExists(filepath.Join(tagProjectPath, "go.mod"), false)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		log.Info("Skipping tag", tagVersion.Tag, "since it has no go.mod file.")
+		return false, nil
+	}
+	goProject, err := project.LoadFromPath(tagProjectPath, tagVersion.Version)
+	if err != nil {
+		return false, err
+	}
+	if goProject.ModuleName() != moduleName {
+		log.Info("Skipping tag", tagVersion.Tag, "since its module is", goProject.ModuleName())
+		return false, nil
+	}
+
+	buildName := gpc.buildConfiguration.BuildName
+	buildNumber := gpc.buildConfiguration.BuildNumber
+	if err = goProject.PublishPackage(gpc.TargetRepo(), buildName, buildNumber, serviceManager); err != nil {
+		return false, err
+	}
+	if len(buildName) > 0 && len(buildNumber) > 0 {
+		// Each version is saved as a separate build-info module, unless a module is provided.
+		module := gpc.buildConfiguration.Module
+		if module == "" {
+			module = moduleName + ":" + tagVersion.Version
+		}
+		buildInfo := goProject.BuildInfo(true, module)
+		if err = utils.SaveBuildInfo(buildName, buildNumber, buildInfo); err != nil {
+			return false, err
+		}
+		gpc.Result().AddModules(module)
+	}
+	return true, nil
+}
+
+func isVersionPublished(repo, moduleName, version string, serviceManager *artifactory.ArtifactoryServicesManager) (bool, error) {
+	rtDetails := serviceManager.GetConfig().GetArtDetails()
+	httpClientsDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, _, err := serviceManager.Client().SendGet(rtDetails.GetUrl()+"api/storage/"+repo+"/"+moduleName+"/@v/"+version+".zip", true, &httpClientsDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/buger/jsonparser"
 	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/gofrog/parallel"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
@@ -44,10 +45,12 @@ type NpmCommandArgs struct {
 	registry         string
 	npmAuth          string
 	collectBuildInfo bool
+	usePackageLock   bool
 	dependencies     map[string]*dependency
 	typeRestriction  string
 	artDetails       auth.ArtifactoryDetails
 	packageInfo      *npm.PackageInfo
+	result           *commandsutils.Result
 	NpmCommand
 }
 
@@ -73,8 +76,19 @@ func (nca *NpmCommandArgs) SetThreads(threads int) *NpmCommandArgs {
 	return nca
 }
 
+// Collect the dependencies and their checksums from package-lock.json, rather than by running npm ls and an AQL query per dependency.
+func (nca *NpmCommandArgs) SetUsePackageLock(usePackageLock bool) *NpmCommandArgs {
+	nca.usePackageLock = usePackageLock
+	return nca
+}
+
 func NewNpmCommandArgs(npmCommand string) *NpmCommandArgs {
-	return &NpmCommandArgs{command: npmCommand}
+	return &NpmCommandArgs{command: npmCommand, result: new(commandsutils.Result)}
+}
+
+// The dependencies added to the build-info, and the build-info module. Empty if build-info isn't collected.
+func (nca *NpmCommandArgs) Result() *commandsutils.Result {
+	return nca.result
 }
 
 func (nca *NpmCommandArgs) RtDetails() (*config.ArtifactoryDetails, error) {
@@ -103,12 +117,20 @@ func (nca *NpmCommandArgs) run() error {
 		return nil
 	}
 
-	if err := nca.setDependenciesList(); err != nil {
-		return err
-	}
-
-	if err := nca.collectDependenciesChecksums(); err != nil {
-		return err
+	if nca.usePackageLock {
+		if err := nca.setDependenciesFromPackageLock(); err != nil {
+			return err
+		}
+		if err := nca.collectMissingChecksums(); err != nil {
+			return err
+		}
+	} else {
+		if err := nca.setDependenciesList(); err != nil {
+			return err
+		}
+		if err := nca.collectDependenciesChecksums(); err != nil {
+			return err
+		}
 	}
 
 	if err := nca.saveDependenciesData(); err != nil {
@@ -145,7 +167,7 @@ func (nca *NpmCommandArgs) preparePrerequisites(repo string) error {
 }
 
 func (nca *NpmCommandArgs) prepareArtifactoryPrerequisites(repo string) (err error) {
-	npmAuth, artifactoryVersion, err := getArtifactoryDetails(nca.artDetails)
+	npmAuth, artifactoryVersion, err := GetArtifactoryDetails(nca.artDetails)
 	if err != nil {
 		return err
 	}
@@ -160,7 +182,7 @@ func (nca *NpmCommandArgs) prepareArtifactoryPrerequisites(repo string) (err err
 		return err
 	}
 
-	nca.registry = getNpmRepositoryUrl(repo, nca.artDetails.GetUrl())
+	nca.registry = GetNpmRepositoryUrl(repo, nca.artDetails.GetUrl())
 	return nil
 }
 
@@ -325,6 +347,12 @@ func (nca *NpmCommandArgs) saveDependenciesData() error {
 		return err
 	}
 
+	if err := nca.saveDependencyPaths(); err != nil {
+		return err
+	}
+	nca.result.SetSuccessCount(len(dependencies))
+	nca.result.AddModules(nca.buildConfiguration.Module)
+
 	if len(missingDependencies) > 0 {
 		var missingDependenciesText []string
 		for _, dependency := range missingDependencies {
@@ -565,7 +593,8 @@ func (nca *NpmCommandArgs) setNpmExecutable() error {
 	return nil
 }
 
-func getArtifactoryDetails(artDetails auth.ArtifactoryDetails) (npmAuth string, artifactoryVersion string, err error) {
+// Returns the npm auth configuration (in .npmrc format) and the Artifactory version.
+func GetArtifactoryDetails(artDetails auth.ArtifactoryDetails) (npmAuth string, artifactoryVersion string, err error) {
 	if artDetails.GetAccessToken() == "" {
 		return getDetailsUsingBasicAuth(artDetails)
 	}
@@ -619,7 +648,8 @@ func getDetailsUsingBasicAuth(artDetails auth.ArtifactoryDetails) (npmAuth strin
 	return string(body), strings.TrimSpace(serverValues[1]), err
 }
 
-func getNpmRepositoryUrl(repo, url string) string {
+// Returns the npm registry URL of an Artifactory repository.
+func GetNpmRepositoryUrl(repo, url string) string {
 	if !strings.HasSuffix(url, "/") {
 		url += "/"
 	}
@@ -664,6 +694,8 @@ type dependency struct {
 	scopes       []string
 	artifactName string
 	checksum     *buildinfo.Checksum
+	// The packages which lead from the project to the dependency. Collected from package-lock.json only.
+	path []string
 }
 
 type aqlResult struct {
@@ -0,0 +1,92 @@
+package npm
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"sort"
+)
+
+const dependencyPathPropertyPrefix = "npm.dependency.path."
+
+// Sets the dependencies from package-lock.json.
+// Dependencies whose sha1 is recorded in the lockfile don't need to be searched in Artifactory.
+func (nca *NpmCommandArgs) setDependenciesFromPackageLock() error {
+	packages, err := npm.ReadPackageLock(nca.workingDirectory)
+	if err != nil {
+		return err
+	}
+	nca.dependencies = make(map[string]*dependency)
+	for _, pkg := range packages {
+		scope := "production"
+		if pkg.Dev {
+			scope = "development"
+		}
+		if nca.typeRestriction != "" && nca.typeRestriction != scope {
+			continue
+		}
+		dep := &dependency{name: pkg.Name, version: pkg.Version, scopes: []string{scope}, path: pkg.Path}
+		if pkg.Sha1 != "" {
+			dep.artifactName = pkg.TarballName()
+			dep.checksum = &buildinfo.Checksum{Sha1: pkg.Sha1}
+		}
+		nca.dependencies[pkg.Name+"-"+pkg.Version] = dep
+	}
+	return nil
+}
+
+// Fetches the checksums of the dependencies which are missing them from Artifactory, using an AQL query per batch of dependencies.
+func (nca *NpmCommandArgs) collectMissingChecksums() error {
+	var missing []*dependency
+	for _, dep := range nca.dependencies {
+		if dep.checksum == nil {
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].name+"-"+missing[i].version < missing[j].name+"-"+missing[j].version
+	})
+	log.Info(fmt.Sprintf("Collecting the checksums of %d dependencies from Artifactory...", len(missing)))
+	servicesManager, err := utils.CreateServiceManager(nca.rtDetails, false)
+	if err != nil {
+		return err
+	}
+
+	var packageVersions []npm.PackageVersion
+	for _, dep := range missing {
+		packageVersions = append(packageVersions, npm.PackageVersion{Name: dep.name, Version: dep.version})
+	}
+	items, err := npm.SearchPackages(servicesManager, packageVersions, nca.threads)
+	if err != nil {
+		return err
+	}
+	for i, dep := range missing {
+		if len(items[i]) == 0 {
+			log.Debug(dep.name, "-", dep.version, "could not be found in Artifactory.")
+			continue
+		}
+		dep.artifactName = items[i][0].Name
+		dep.checksum = &buildinfo.Checksum{Sha1: items[i][0].Actual_Sha1, Md5: items[i][0].Actual_Md5}
+	}
+	return nil
+}
+
+// Saves the dependency paths collected from package-lock.json as properties of the build-info module.
+func (nca *NpmCommandArgs) saveDependencyPaths() error {
+	properties := make(map[string][]string)
+	for _, dep := range nca.dependencies {
+		if dep.artifactName != "" && len(dep.path) > 0 {
+			properties[dependencyPathPropertyPrefix+dep.artifactName] = dep.path
+		}
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	moduleProperties := &utils.ModuleProperties{ModuleId: nca.buildConfiguration.Module, Properties: properties}
+	return utils.SaveModuleProperties(nca.buildConfiguration.BuildName, nca.buildConfiguration.BuildNumber, moduleProperties)
+}
@@ -0,0 +1,84 @@
+package npm
+
+import (
+	"encoding/json"
+	buildinfocmd "github.com/jfrog/jfrog-cli-go/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Collects the dependencies of a project from its package-lock.json, moves the build to another agent with a build bundle and publishes it,
+// checking that the dependency paths are added to the published module.
+func TestDependencyPathsInPublishedBuildInfo(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir, err := ioutil.TempDir("", "npm-package-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(projectDir)
+	// The integrity of each package includes the sha1 of its tarball, so that no checksum is searched in Artifactory.
+	packageLock := `{
+  "lockfileVersion": 2,
+  "packages": {
+    "": {"name": "project", "version": "1.0.0", "dependencies": {"parent": "^1.0.0"}},
+    "node_modules/parent": {"version": "1.0.0", "integrity": "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk=", "dependencies": {"child": "^2.0.0"}},
+    "node_modules/child": {"version": "2.0.0", "integrity": "sha1-qZk+NkcGgWq6PiVxeFDCbJzQ2J0="}
+  }
+}`
+	if err = ioutil.WriteFile(filepath.Join(projectDir, "package-lock.json"), []byte(packageLock), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "npm-dependency-paths-test", BuildNumber: "1", Module: "project:1.0.0"}
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber)
+	if err = utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber); err != nil {
+		t.Fatal(err)
+	}
+	nca := NewNpmCommandArgs("install")
+	nca.workingDirectory = projectDir
+	nca.buildConfiguration = buildConfiguration
+	if err = nca.setDependenciesFromPackageLock(); err != nil {
+		t.Fatal(err)
+	}
+	if err = nca.saveDependenciesData(); err != nil {
+		t.Fatal(err)
+	}
+
+	bundlePath := filepath.Join(projectDir, "build.json")
+	if _, err = utils.ExportBuild(buildConfiguration.BuildName, buildConfiguration.BuildNumber, bundlePath); err != nil {
+		t.Fatal(err)
+	}
+	if err = utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = utils.ImportBuild(bundlePath, utils.ImportFail); err != nil {
+		t.Fatal(err)
+	}
+
+	outputFile := filepath.Join(projectDir, "build-info.json")
+	publishCmd := buildinfocmd.NewBuildPublishCommand().SetBuildConfiguration(buildConfiguration).SetConfig(buildinfocmd.NewDefaultConfiguration()).SetOutputFile(outputFile)
+	if err = publishCmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	buildInfo, err := utils.ReadBuildInfoFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buildInfo.Modules) != 1 || len(buildInfo.Modules[0].Dependencies) != 2 {
+		t.Fatalf("Expected a module with 2 dependencies, got %+v", buildInfo.Modules)
+	}
+	expected := map[string][]string{
+		"npm.dependency.path.parent-1.0.0.tgz": {"parent:1.0.0"},
+		"npm.dependency.path.child-2.0.0.tgz":  {"parent:1.0.0", "child:2.0.0"},
+	}
+	// The module properties are read from the build-info file as generic JSON values, so they are compared as JSON.
+	expectedJson, _ := json.Marshal(expected)
+	actualJson, _ := json.Marshal(buildInfo.Modules[0].Properties)
+	if string(expectedJson) != string(actualJson) {
+		t.Errorf("Expected the module properties:\n%s\nGot:\n%s", expectedJson, actualJson)
+	}
+}
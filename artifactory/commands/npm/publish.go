@@ -5,6 +5,7 @@ import (
 	"compress/gzip"
 	"errors"
 	"fmt"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
@@ -14,7 +15,6 @@ import (
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
-	"github.com/mattn/go-shellwords"
 	"io"
 	"io/ioutil"
 	"os"
@@ -33,10 +33,36 @@ type NpmPublishCommand struct {
 	publishPath      string
 	tarballProvided  bool
 	artifactData     []specutils.FileInfo
+	result           *commandsutils.Result
+	workspaces       bool
+	workspacesGlobs  []string
+	threads          int
 }
 
 func NewNpmPublishCommand() *NpmPublishCommand {
-	return &NpmPublishCommand{}
+	return &NpmPublishCommand{result: new(commandsutils.Result)}
+}
+
+// Publish all the packages of the workspace, rather than the package in the working directory.
+func (npc *NpmPublishCommand) SetWorkspaces(workspaces bool) *NpmPublishCommand {
+	npc.workspaces = workspaces
+	return npc
+}
+
+// Glob patterns of the workspace package directories. If empty, the workspaces of the root package.json are used.
+func (npc *NpmPublishCommand) SetWorkspacesGlobs(workspacesGlobs []string) *NpmPublishCommand {
+	npc.workspacesGlobs = workspacesGlobs
+	return npc
+}
+
+// The number of packages deployed in parallel when publishing workspaces.
+func (npc *NpmPublishCommand) SetThreads(threads int) *NpmPublishCommand {
+	npc.threads = threads
+	return npc
+}
+
+func (npc *NpmPublishCommand) Result() *commandsutils.Result {
+	return npc.result
 }
 
 func (npc *NpmPublishCommand) RtDetails() (*config.ArtifactoryDetails, error) {
@@ -49,6 +75,10 @@ func (npc *NpmPublishCommand) Run() error {
 		return err
 	}
 
+	if npc.workspaces {
+		return npc.publishWorkspaces()
+	}
+
 	if !npc.tarballProvided {
 		if err := npc.pack(); err != nil {
 			return err
@@ -146,22 +176,26 @@ func (npc *NpmPublishCommand) deploy() (err error) {
 	}
 
 	target := fmt.Sprintf("%s/%s", npc.repo, npc.packageInfo.GetDeployPath())
-	artifactsFileInfo, err := npc.doDeploy(target, npc.rtDetails)
+	artifactsFileInfo, err := npc.doDeploy(target, npc.packedFilePath, npc.rtDetails)
 	if err != nil {
 		return err
 	}
 
 	npc.artifactData = artifactsFileInfo
+	npc.result.SetSuccessCount(len(artifactsFileInfo))
+	for _, fileInfo := range artifactsFileInfo {
+		npc.result.AddPaths(fileInfo.ArtifactoryPath)
+	}
 	return nil
 }
 
-func (npc *NpmPublishCommand) doDeploy(target string, artDetails *config.ArtifactoryDetails) (artifactsFileInfo []specutils.FileInfo, err error) {
+func (npc *NpmPublishCommand) doDeploy(target, packedFilePath string, artDetails *config.ArtifactoryDetails) (artifactsFileInfo []specutils.FileInfo, err error) {
 	servicesManager, err := utils.CreateServiceManager(artDetails, false)
 	if err != nil {
 		return nil, err
 	}
 	up := services.UploadParams{}
-	up.ArtifactoryCommonParams = &specutils.ArtifactoryCommonParams{Pattern: npc.packedFilePath, Target: target}
+	up.ArtifactoryCommonParams = &specutils.ArtifactoryCommonParams{Pattern: packedFilePath, Target: target}
 	if npc.collectBuildInfo {
 		utils.SaveBuildGeneralDetails(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber)
 		props, err := utils.CreateBuildProperties(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber)
@@ -196,24 +230,23 @@ func (npc *NpmPublishCommand) saveArtifactData() error {
 		}
 		partial.ModuleId = npc.buildConfiguration.Module
 	}
-	return utils.SavePartialBuildInfo(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber, populateFunc)
+	if err := utils.SavePartialBuildInfo(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber, populateFunc); err != nil {
+		return err
+	}
+	npc.result.AddModules(npc.buildConfiguration.Module)
+	return nil
 }
 
 func (npc *NpmPublishCommand) setPublishPath() error {
 	log.Debug("Reading Package Json.")
-	splitFlags, err := shellwords.Parse(npc.npmArgs)
+	path, _, err := npm.SplitPublishPath(npc.npmArgs)
 	if err != nil {
-		return errorutils.CheckError(err)
+		return err
 	}
 
 	npc.publishPath = npc.workingDirectory
-	if len(splitFlags) > 0 && !strings.HasPrefix(strings.TrimSpace(splitFlags[0]), "-") {
-		path := strings.TrimSpace(splitFlags[0])
+	if path != "" {
 		path = clientutils.ReplaceTildeWithUserHome(path)
-		if err != nil {
-			return errorutils.CheckError(err)
-		}
-
 		if filepath.IsAbs(path) {
 			npc.publishPath = path
 		} else {
@@ -0,0 +1,161 @@
+package npm
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path/filepath"
+)
+
+// A package of the workspace which is published.
+type workspacePackage struct {
+	dir            string
+	packageInfo    *npm.PackageInfo
+	packedFilePath string
+	artifacts      []specutils.FileInfo
+}
+
+// Packs the packages of the workspace one by one, and deploys them in parallel.
+// Private packages and package versions which already exist in the repository are skipped.
+func (npc *NpmPublishCommand) publishWorkspaces() error {
+	if npc.tarballProvided {
+		return errorutils.CheckError(errors.New("Publishing workspaces requires a project directory rather than an npm package."))
+	}
+	if npc.buildConfiguration.Module != "" {
+		log.Warn("The module option is ignored when publishing workspaces, since each package is saved as a separate build-info module.")
+	}
+	packageDirs, err := npm.FindWorkspacePackages(npc.publishPath, npc.workspacesGlobs)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(npc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+
+	var packages []*workspacePackage
+	defer func() {
+		for _, pkg := range packages {
+			if pkg.packedFilePath != "" {
+				deleteCreatedTarball(pkg.packedFilePath)
+			}
+		}
+	}()
+	for _, dir := range packageDirs {
+		packageInfo, err := npm.ReadPackageInfoFromPackageJson(dir)
+		if err != nil {
+			return err
+		}
+		if packageInfo.Private {
+			log.Info("Skipping the private package in", dir)
+			continue
+		}
+		exists, err := packageVersionExists(npc.repo, packageInfo, servicesManager)
+		if err != nil {
+			return err
+		}
+		if exists {
+			log.Info(fmt.Sprintf("Skipping %s, since it already exists in %s.", packageInfo.GetDeployPath(), npc.repo))
+			continue
+		}
+		pkg := &workspacePackage{dir: dir, packageInfo: packageInfo}
+		packages = append(packages, pkg)
+		log.Info("Packing", dir)
+		if err = npm.PackDirectory(npc.npmArgs, npc.executablePath, dir); err != nil {
+			return err
+		}
+		pkg.packedFilePath = filepath.Join(npc.workingDirectory, packageInfo.GetExpectedPackedFileName())
+	}
+	if len(packages) == 0 {
+		log.Info("No workspace packages to publish.")
+		return nil
+	}
+
+	if npc.collectBuildInfo {
+		if err = utils.SaveBuildGeneralDetails(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber); err != nil {
+			return err
+		}
+	}
+	if err = npc.deployWorkspacePackages(packages); err != nil {
+		return err
+	}
+
+	for _, pkg := range packages {
+		npc.result.SetSuccessCount(npc.result.SuccessCount() + len(pkg.artifacts))
+		for _, fileInfo := range pkg.artifacts {
+			npc.result.AddPaths(fileInfo.ArtifactoryPath)
+		}
+		if !npc.collectBuildInfo {
+			continue
+		}
+		if err = npc.saveWorkspacePackageData(pkg); err != nil {
+			return err
+		}
+	}
+	log.Info("npm publish finished successfully.")
+	return nil
+}
+
+func (npc *NpmPublishCommand) deployWorkspacePackages(packages []*workspacePackage) error {
+	producerConsumer := parallel.NewBounedRunner(npc.threads, false)
+	errorsQueue := specutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for _, pkg := range packages {
+			pkg := pkg
+			producerConsumer.AddTaskWithError(func(threadId int) error {
+				target := fmt.Sprintf("%s/%s", npc.repo, pkg.packageInfo.GetDeployPath())
+				log.Info(clientutils.GetLogMsgPrefix(threadId, false)+"Deploying", pkg.packageInfo.GetExpectedPackedFileName(), "to", target)
+				artifacts, err := npc.doDeploy(target, pkg.packedFilePath, npc.rtDetails)
+				pkg.artifacts = artifacts
+				return err
+			}, errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+// Each package of the workspace is saved as a separate build-info module.
+func (npc *NpmPublishCommand) saveWorkspacePackageData(pkg *workspacePackage) error {
+	var buildArtifacts []buildinfo.Artifact
+	for _, artifact := range pkg.artifacts {
+		buildArtifacts = append(buildArtifacts, artifact.ToBuildArtifacts())
+	}
+	moduleId := pkg.packageInfo.BuildInfoModuleId()
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Artifacts = buildArtifacts
+		partial.ModuleId = moduleId
+	}
+	if err := utils.SavePartialBuildInfo(npc.buildConfiguration.BuildName, npc.buildConfiguration.BuildNumber, populateFunc); err != nil {
+		return err
+	}
+	npc.result.AddModules(moduleId)
+	return nil
+}
+
+func packageVersionExists(repo string, packageInfo *npm.PackageInfo, servicesManager *artifactory.ArtifactoryServicesManager) (bool, error) {
+	rtDetails := servicesManager.GetConfig().GetArtDetails()
+	httpClientsDetails := rtDetails.CreateHttpClientDetails()
+	resp, body, _, err := servicesManager.Client().SendGet(rtDetails.GetUrl()+"api/storage/"+repo+"/"+packageInfo.GetDeployPath(), true, &httpClientsDetails)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+}
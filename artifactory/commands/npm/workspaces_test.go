@@ -0,0 +1,38 @@
+package npm
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPackageVersionExists(t *testing.T) {
+	log.SetDefaultLogger()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/storage/npm-local/@jfrog/cli/-/cli-1.0.0.tgz" {
+			w.Write([]byte(`{"repo": "npm-local"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ArtifactoryDetails{Url: server.URL + "/"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]bool{"1.0.0": true, "1.0.1": false}
+	for version, expected := range tests {
+		exists, err := packageVersionExists("npm-local", &npm.PackageInfo{Name: "cli", Scope: "@jfrog", Version: version}, servicesManager)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != expected {
+			t.Errorf("Expected packageVersionExists for version %s to be %t", version, expected)
+		}
+	}
+}
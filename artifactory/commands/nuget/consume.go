@@ -3,6 +3,7 @@ package nuget
 import (
 	"fmt"
 	gofrogcmd "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/nuget"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/nuget/solution"
@@ -27,10 +28,16 @@ type NugetCommand struct {
 	solutionPath       string
 	buildConfiguration *utils.BuildConfiguration
 	rtDetails          *config.ArtifactoryDetails
+	result             *commandsutils.Result
 }
 
 func NewNugetCommand() *NugetCommand {
-	return &NugetCommand{}
+	return &NugetCommand{result: new(commandsutils.Result)}
+}
+
+// The dependencies added to the build-info, and the build-info modules. Empty if build-info isn't collected.
+func (nc *NugetCommand) Result() *commandsutils.Result {
+	return nc.result
 }
 
 func (nc *NugetCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *NugetCommand {
@@ -105,7 +112,14 @@ func (nc *NugetCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	return utils.SaveBuildInfo(nc.buildConfiguration.BuildName, nc.buildConfiguration.BuildNumber, buildInfo)
+	if err = utils.SaveBuildInfo(nc.buildConfiguration.BuildName, nc.buildConfiguration.BuildNumber, buildInfo); err != nil {
+		return err
+	}
+	for _, module := range buildInfo.Modules {
+		nc.result.SetSuccessCount(nc.result.SuccessCount() + len(module.Dependencies))
+		nc.result.AddModules(module.Id)
+	}
+	return nil
 }
 
 func (nc *NugetCommand) RtDetails() (*config.ArtifactoryDetails, error) {
@@ -316,13 +330,13 @@ func (nc *NugetCommand) getSourceDetails() (sourceURL, user, password string, er
 	u.Path = path.Join(u.Path, "api/nuget", nc.repoName)
 	sourceURL = u.String()
 
-	user = nc.rtDetails.User
-	password = nc.rtDetails.Password
-	// If access-token is defined, extract user from it.
-	rtDetails, err := nc.RtDetails()
-	if errorutils.CheckError(err) != nil {
+	rtDetails, err := nc.rtDetails.ResolveCredentials()
+	if err != nil {
 		return
 	}
+	user = rtDetails.User
+	password = rtDetails.Password
+	// If access-token is defined, extract user from it.
 	if rtDetails.AccessToken != "" {
 		log.Debug("Using access-token details for nuget authentication.")
 		user, err = auth.ExtractUsernameFromAccessToken(rtDetails.AccessToken)
@@ -0,0 +1,87 @@
+package pip
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/dependencytree"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/pip"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const requirementsFileName = "requirements.txt"
+
+// Prints the dependency tree of the installed Python environment, in the same format as the NuGet dependency tree.
+// If no site-packages directory is given, the one of the python executable is used.
+// The roots of the tree are the projects of the requirements file, or of requirements.txt in the working directory if it exists.
+// Otherwise, the roots are the installed distributions which aren't required by any other distribution.
+func DependencyTreeCmd(sitePackagesDir, requirementsPath string) error {
+	workspace, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	sitePackagesDirs, err := getSitePackagesDirs(sitePackagesDir)
+	if err != nil {
+		return err
+	}
+	log.Debug("Reading the installed distributions from", sitePackagesDirs)
+	env, err := pip.LoadEnvironment(sitePackagesDirs)
+	if err != nil {
+		return err
+	}
+
+	requirements, err := readRequirements(workspace, requirementsPath)
+	if err != nil {
+		return err
+	}
+	tree := dependencytree.Create(env.RootDependencies(requirements), env.AllDependencies(), env.ChildrenMap())
+
+	content, err := json.Marshal(&struct {
+		Projects []interface{} `json:"projects,omitempty"`
+	}{
+		Projects: []interface{}{&struct {
+			Name         string              `json:"name,omitempty"`
+			Dependencies dependencytree.Tree `json:"dependencies,omitempty"`
+		}{
+			Name:         filepath.Base(workspace),
+			Dependencies: tree,
+		}},
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func getSitePackagesDirs(sitePackagesDir string) ([]string, error) {
+	if sitePackagesDir != "" {
+		return []string{sitePackagesDir}, nil
+	}
+	pythonExecPath, err := exec.LookPath("python3")
+	if err != nil {
+		if pythonExecPath, err = exec.LookPath("python"); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	log.Debug("Found python executable at:", pythonExecPath)
+	return pip.SitePackagesDirs(pythonExecPath)
+}
+
+func readRequirements(workspace, requirementsPath string) ([]string, error) {
+	if requirementsPath != "" {
+		return pip.ReadRequirements(requirementsPath)
+	}
+	defaultPath := filepath.Join(workspace, requirementsFileName)
+	exists, err := fileutils.IsFileExists(defaultPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	log.Debug("Found", defaultPath)
+	return pip.ReadRequirements(defaultPath)
+}
@@ -0,0 +1,264 @@
+package pip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/pip"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+type PipInstallCommand struct {
+	repo               string
+	pipArgs            []string
+	buildConfiguration *utils.BuildConfiguration
+	rtDetails          *config.ArtifactoryDetails
+	executablePath     string
+	indexUrl           string
+	collectBuildInfo   bool
+	reportDir          string
+}
+
+func NewPipInstallCommand() *PipInstallCommand {
+	return &PipInstallCommand{}
+}
+
+func (pic *PipInstallCommand) SetRepo(repo string) *PipInstallCommand {
+	pic.repo = repo
+	return pic
+}
+
+func (pic *PipInstallCommand) SetPipArgs(pipArgs []string) *PipInstallCommand {
+	pic.pipArgs = pipArgs
+	return pic
+}
+
+func (pic *PipInstallCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *PipInstallCommand {
+	pic.buildConfiguration = buildConfiguration
+	return pic
+}
+
+func (pic *PipInstallCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *PipInstallCommand {
+	pic.rtDetails = rtDetails
+	return pic
+}
+
+func (pic *PipInstallCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return pic.rtDetails, nil
+}
+
+func (pic *PipInstallCommand) CommandName() string {
+	return "rt_pip_install"
+}
+
+func (pic *PipInstallCommand) Run() error {
+	log.Info("Running pip install.")
+	if err := pic.preparePrerequisites(); err != nil {
+		return err
+	}
+	if pic.reportDir != "" {
+		defer fileutils.RemoveTempDir(pic.reportDir)
+	}
+
+	if err := pic.runPipInstall(); err != nil {
+		return err
+	}
+
+	if pic.collectBuildInfo {
+		if err := pic.saveDependenciesData(); err != nil {
+			return err
+		}
+	}
+	log.Info("pip install finished successfully.")
+	return nil
+}
+
+func (pic *PipInstallCommand) preparePrerequisites() error {
+	log.Debug("Preparing prerequisites.")
+	pipExecPath, err := exec.LookPath("pip")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	pic.executablePath = pipExecPath
+	log.Debug("Found pip executable at:", pic.executablePath)
+
+	artDetails, err := pic.rtDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if artDetails.GetSshAuthHeaders() != nil {
+		return errorutils.CheckError(errors.New("SSH authentication is not supported in this command."))
+	}
+	if err = utils.CheckIfRepoExists(pic.repo, artDetails); err != nil {
+		return err
+	}
+	if pic.indexUrl, err = pip.GetIndexUrl(pic.repo, artDetails); err != nil {
+		return err
+	}
+
+	return pic.prepareBuildInfo()
+}
+
+// The installed distributions are collected from the installation report of pip, which requires pip 22.2 or above.
+func (pic *PipInstallCommand) prepareBuildInfo() error {
+	if len(pic.buildConfiguration.BuildName) == 0 || len(pic.buildConfiguration.BuildNumber) == 0 {
+		return nil
+	}
+	pipVersion, err := pip.Version(pic.executablePath)
+	if err != nil {
+		return err
+	}
+	log.Debug("Using pip version:", pipVersion)
+	if !version.NewVersion(pipVersion).AtLeast(pip.MinReportVersion) {
+		return errorutils.CheckError(errors.New("Collecting build-info requires pip version " + pip.MinReportVersion + " or higher."))
+	}
+
+	pic.collectBuildInfo = true
+	if err = utils.SaveBuildGeneralDetails(pic.buildConfiguration.BuildName, pic.buildConfiguration.BuildNumber); err != nil {
+		return err
+	}
+	pic.reportDir, err = fileutils.CreateTempDir()
+	return err
+}
+
+func (pic *PipInstallCommand) runPipInstall() error {
+	command := append([]string{"install"}, pic.pipArgs...)
+	if pic.collectBuildInfo {
+		command = append(command, "--report", pic.reportPath())
+	}
+	log.Debug("Running pip", strings.Join(command, " "))
+	pipCmdConfig := &pip.PipConfig{
+		Pip:       pic.executablePath,
+		Command:   command,
+		IndexUrl:  pic.indexUrl,
+		StrWriter: nil,
+		ErrWriter: nil,
+	}
+	return errorutils.CheckError(gofrogcmd.RunCmd(pipCmdConfig))
+}
+
+func (pic *PipInstallCommand) reportPath() string {
+	return filepath.Join(pic.reportDir, "report.json")
+}
+
+// Saves the distributions installed by pip as the dependencies of the module in a partial build info.
+// Distributions which were already installed in the environment aren't included in the installation report, and therefore aren't collected.
+func (pic *PipInstallCommand) saveDependenciesData() error {
+	log.Info("Collecting dependencies information... This may take a few minutes...")
+	distributions, err := pip.ReadInstallReport(pic.reportPath())
+	if err != nil {
+		return err
+	}
+	dependencies, missingDistributions, err := pic.collectDependenciesChecksums(distributions)
+	if err != nil {
+		return err
+	}
+
+	if pic.buildConfiguration.Module == "" {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		pic.buildConfiguration.Module = filepath.Base(currentDir)
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+		partial.ModuleId = pic.buildConfiguration.Module
+	}
+	if err = utils.SavePartialBuildInfo(pic.buildConfiguration.BuildName, pic.buildConfiguration.BuildNumber, populateFunc); err != nil {
+		return err
+	}
+
+	if len(missingDistributions) > 0 {
+		log.Warn(strings.Join(missingDistributions, "\n"))
+		log.Warn("The pip dependencies above could not be found in Artifactory and therefore are not included in the build-info.\n" +
+			"Make sure the dependencies are available in Artifactory for this build.\n" +
+			"Installing them with 'pip install --no-cache-dir' will force populating Artifactory with these dependencies.")
+	}
+	return nil
+}
+
+// Fetches the checksums of the distributions from Artifactory, using an AQL query per batch of distributions.
+func (pic *PipInstallCommand) collectDependenciesChecksums(distributions []*pip.InstalledDistribution) ([]buildinfo.Dependency, []string, error) {
+	servicesManager, err := utils.CreateServiceManager(pic.rtDetails, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]*distributionItem, len(distributions))
+	err = utils.SearchInBatches(servicesManager, createDistributionsCriteria(distributions), distributionSearchFields, 1, func(start, end int, result []byte) error {
+		parsedResult := new(distributionSearchResult)
+		if err := json.Unmarshal(result, parsedResult); err != nil {
+			return errorutils.CheckError(err)
+		}
+		for i := start; i < end; i++ {
+			items[i] = findDistributionItem(distributions[i], parsedResult.Results)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dependencies []buildinfo.Dependency
+	var missingDistributions []string
+	for i, item := range items {
+		if item == nil {
+			missingDistributions = append(missingDistributions, distributions[i].FileName)
+			continue
+		}
+		log.Debug("Found", item.Name, "sha1:", item.Actual_Sha1, "md5", item.Actual_Md5)
+		dependencies = append(dependencies, buildinfo.Dependency{Id: item.Name, Checksum: &buildinfo.Checksum{Sha1: item.Actual_Sha1, Md5: item.Actual_Md5}})
+	}
+	return dependencies, missingDistributions, nil
+}
+
+// The fields included in the results of a distributions search.
+var distributionSearchFields = []string{"name", "repo", "path", "actual_sha1", "actual_md5", "sha256"}
+
+// The AQL result item, including the sha256 checksum which isn't part of the common search results.
+type distributionItem struct {
+	Repo        string `json:"repo,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Actual_Md5  string `json:"actual_md5,omitempty"`
+	Actual_Sha1 string `json:"actual_sha1,omitempty"`
+	Sha256      string `json:"sha256,omitempty"`
+}
+
+type distributionSearchResult struct {
+	Results []*distributionItem `json:"results,omitempty"`
+}
+
+func createDistributionsCriteria(distributions []*pip.InstalledDistribution) []string {
+	var criteria []string
+	for _, distribution := range distributions {
+		criteria = append(criteria, fmt.Sprintf(`{"name":%q}`, distribution.FileName))
+	}
+	return criteria
+}
+
+// Returns the item of the distribution file. If pip recorded the sha256 of the file, it must match the one of the item.
+func findDistributionItem(distribution *pip.InstalledDistribution, items []*distributionItem) *distributionItem {
+	for _, item := range items {
+		if item.Name != distribution.FileName {
+			continue
+		}
+		if distribution.Sha256 != "" && item.Sha256 != "" && item.Sha256 != distribution.Sha256 {
+			continue
+		}
+		return item
+	}
+	return nil
+}
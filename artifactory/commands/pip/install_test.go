@@ -0,0 +1,46 @@
+package pip
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/pip"
+	"reflect"
+	"testing"
+)
+
+func TestCreateDistributionsCriteria(t *testing.T) {
+	distributions := []*pip.InstalledDistribution{
+		{Name: "requests", Version: "2.31.0", FileName: "requests-2.31.0-py3-none-any.whl"},
+		{Name: "urllib3", Version: "2.0.2", FileName: "urllib3-2.0.2.tar.gz"},
+	}
+	expected := []string{`{"name":"requests-2.31.0-py3-none-any.whl"}`, `{"name":"urllib3-2.0.2.tar.gz"}`}
+	if actual := createDistributionsCriteria(distributions); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestFindDistributionItem(t *testing.T) {
+	items := []*distributionItem{
+		{Name: "requests-2.31.0.tar.gz", Repo: "pypi-local", Sha256: "other"},
+		{Name: "requests-2.31.0.tar.gz", Repo: "pypi-remote-cache", Sha256: "1234"},
+		{Name: "urllib3-2.0.2.tar.gz", Repo: "pypi-remote-cache"},
+	}
+	tests := []struct {
+		distribution *pip.InstalledDistribution
+		expectedRepo string
+	}{
+		{&pip.InstalledDistribution{FileName: "requests-2.31.0.tar.gz", Sha256: "1234"}, "pypi-remote-cache"},
+		{&pip.InstalledDistribution{FileName: "requests-2.31.0.tar.gz"}, "pypi-local"},
+		{&pip.InstalledDistribution{FileName: "urllib3-2.0.2.tar.gz", Sha256: "5678"}, "pypi-remote-cache"},
+		{&pip.InstalledDistribution{FileName: "requests-2.31.0.tar.gz", Sha256: "5678"}, ""},
+		{&pip.InstalledDistribution{FileName: "idna-3.4.tar.gz"}, ""},
+	}
+	for _, test := range tests {
+		item := findDistributionItem(test.distribution, items)
+		actualRepo := ""
+		if item != nil {
+			actualRepo = item.Repo
+		}
+		if actualRepo != test.expectedRepo {
+			t.Errorf("Expected %s with sha256 '%s' to be found in '%s', got '%s'", test.distribution.FileName, test.distribution.Sha256, test.expectedRepo, actualRepo)
+		}
+	}
+}
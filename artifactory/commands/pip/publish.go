@@ -0,0 +1,174 @@
+package pip
+
+import (
+	"errors"
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/pip"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"path/filepath"
+)
+
+type PipPublishCommand struct {
+	repo               string
+	filesPattern       string
+	buildConfiguration *utils.BuildConfiguration
+	rtDetails          *config.ArtifactoryDetails
+	collectBuildInfo   bool
+	artifactData       []specutils.FileInfo
+	result             *commandsutils.Result
+}
+
+func NewPipPublishCommand() *PipPublishCommand {
+	return &PipPublishCommand{result: new(commandsutils.Result)}
+}
+
+func (ppc *PipPublishCommand) SetRepo(repo string) *PipPublishCommand {
+	ppc.repo = repo
+	return ppc
+}
+
+// A glob pattern of the distribution files to publish, such as 'dist/*'.
+func (ppc *PipPublishCommand) SetFilesPattern(filesPattern string) *PipPublishCommand {
+	ppc.filesPattern = filesPattern
+	return ppc
+}
+
+func (ppc *PipPublishCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *PipPublishCommand {
+	ppc.buildConfiguration = buildConfiguration
+	return ppc
+}
+
+func (ppc *PipPublishCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *PipPublishCommand {
+	ppc.rtDetails = rtDetails
+	return ppc
+}
+
+func (ppc *PipPublishCommand) Result() *commandsutils.Result {
+	return ppc.result
+}
+
+func (ppc *PipPublishCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return ppc.rtDetails, nil
+}
+
+func (ppc *PipPublishCommand) CommandName() string {
+	return "rt_pip_publish"
+}
+
+func (ppc *PipPublishCommand) Run() error {
+	log.Info("Running pip publish.")
+	distributions, err := ppc.findDistributions()
+	if err != nil {
+		return err
+	}
+
+	ppc.collectBuildInfo = len(ppc.buildConfiguration.BuildName) > 0 && len(ppc.buildConfiguration.BuildNumber) > 0
+	if ppc.collectBuildInfo {
+		if err = utils.SaveBuildGeneralDetails(ppc.buildConfiguration.BuildName, ppc.buildConfiguration.BuildNumber); err != nil {
+			return err
+		}
+	}
+
+	servicesManager, err := utils.CreateServiceManager(ppc.rtDetails, false)
+	if err != nil {
+		return err
+	}
+	for _, distribution := range distributions {
+		if err = ppc.deploy(distribution, servicesManager); err != nil {
+			return err
+		}
+	}
+
+	if ppc.collectBuildInfo {
+		if err = ppc.saveArtifactData(distributions); err != nil {
+			return err
+		}
+	}
+	log.Info("pip publish finished successfully.")
+	return nil
+}
+
+// A distribution file to publish.
+type distributionFile struct {
+	path string
+	*pip.Distribution
+}
+
+// Returns the distributions matched by the files pattern, sorted by their paths.
+func (ppc *PipPublishCommand) findDistributions() ([]*distributionFile, error) {
+	paths, err := filepath.Glob(ppc.filesPattern)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(paths) == 0 {
+		return nil, errorutils.CheckError(errors.New("No distribution files were found by the pattern " + ppc.filesPattern))
+	}
+	var distributions []*distributionFile
+	for _, path := range paths {
+		distribution, err := pip.ParseDistributionFileName(filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, &distributionFile{path: path, Distribution: distribution})
+	}
+	return distributions, nil
+}
+
+// Deploys the distribution to the path and with the properties Artifactory uses for PyPI packages.
+func (ppc *PipPublishCommand) deploy(distribution *distributionFile, servicesManager *artifactory.ArtifactoryServicesManager) error {
+	target := ppc.repo + "/" + distribution.DeployPath()
+	log.Info("Deploying", distribution.FileName, "to", target)
+	props := distribution.Properties()
+	if ppc.collectBuildInfo {
+		buildProps, err := utils.CreateBuildProperties(ppc.buildConfiguration.BuildName, ppc.buildConfiguration.BuildNumber)
+		if err != nil {
+			return err
+		}
+		props += ";" + buildProps
+	}
+	up := services.UploadParams{}
+	up.ArtifactoryCommonParams = &specutils.ArtifactoryCommonParams{Pattern: distribution.path, Target: target, Props: props}
+	artifactsFileInfo, _, failed, err := servicesManager.UploadFiles(up)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errorutils.CheckError(errors.New("Failed to upload " + distribution.FileName + " to Artifactory. See Artifactory logs for more details."))
+	}
+
+	ppc.artifactData = append(ppc.artifactData, artifactsFileInfo...)
+	ppc.result.SetSuccessCount(ppc.result.SuccessCount() + len(artifactsFileInfo))
+	for _, fileInfo := range artifactsFileInfo {
+		ppc.result.AddPaths(fileInfo.ArtifactoryPath)
+	}
+	return nil
+}
+
+// The module defaults to the name and version of the first published distribution.
+func (ppc *PipPublishCommand) saveArtifactData(distributions []*distributionFile) error {
+	log.Debug("Saving pip distributions artifact build info data.")
+	var buildArtifacts []buildinfo.Artifact
+	for _, artifact := range ppc.artifactData {
+		buildArtifacts = append(buildArtifacts, artifact.ToBuildArtifacts())
+	}
+
+	if ppc.buildConfiguration.Module == "" {
+		ppc.buildConfiguration.Module = distributions[0].NormalizedName() + ":" + distributions[0].Version
+	}
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Artifacts = buildArtifacts
+		partial.ModuleId = ppc.buildConfiguration.Module
+	}
+	if err := utils.SavePartialBuildInfo(ppc.buildConfiguration.BuildName, ppc.buildConfiguration.BuildNumber, populateFunc); err != nil {
+		return err
+	}
+	ppc.result.AddModules(ppc.buildConfiguration.Module)
+	return nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	commandsutils "github.com/jfrog/jfrog-cli-go/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/summary"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Commands which collect their results implement this interface, so that the results can be printed in a structured format.
+type ResultCommand interface {
+	Command
+	Result() *commandsutils.Result
+}
+
+// Print the result of the command as a JSON object, including the affected paths and build-info modules.
+// The given error will pass through and be returned as is if no other errors are raised.
+func PrintResult(command Command, err error) error {
+	summaryReport := summary.New(err)
+	summaryReport.Command = command.CommandName()
+	if err != nil {
+		summaryReport.Error = err.Error()
+	}
+	if resultCommand, ok := command.(ResultCommand); ok {
+		result := resultCommand.Result()
+		summaryReport.Totals.Success = result.SuccessCount()
+		summaryReport.Totals.Failure = result.FailCount()
+		summaryReport.Paths = result.Paths()
+		summaryReport.Modules = result.Modules()
+		if err == nil && result.FailCount() != 0 {
+			summaryReport.Status = summary.Failure
+		}
+	}
+	content, mErr := summaryReport.Marshal()
+	if errorutils.CheckError(mErr) != nil {
+		log.Error(mErr)
+		return err
+	}
+	log.Output(clientutils.IndentJson(content))
+	return err
+}
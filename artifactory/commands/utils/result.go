@@ -3,6 +3,10 @@ package utils
 type Result struct {
 	successCount int
 	failCount    int
+	// The paths affected by the command.
+	paths []string
+	// The IDs of the build-info modules created by the command.
+	modules []string
 }
 
 func (r *Result) SuccessCount() int {
@@ -13,6 +17,14 @@ func (r *Result) FailCount() int {
 	return r.failCount
 }
 
+func (r *Result) Paths() []string {
+	return r.paths
+}
+
+func (r *Result) Modules() []string {
+	return r.modules
+}
+
 func (r *Result) SetSuccessCount(successCount int) {
 	r.successCount = successCount
 }
@@ -20,3 +32,24 @@ func (r *Result) SetSuccessCount(successCount int) {
 func (r *Result) SetFailCount(failCount int) {
 	r.failCount = failCount
 }
+
+func (r *Result) AddPaths(paths ...string) {
+	r.paths = append(r.paths, paths...)
+}
+
+func (r *Result) AddModules(modules ...string) {
+	for _, module := range modules {
+		if module != "" && !r.containsModule(module) {
+			r.modules = append(r.modules, module)
+		}
+	}
+}
+
+func (r *Result) containsModule(module string) bool {
+	for _, existing := range r.modules {
+		if existing == module {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,373 @@
+package yarn
+
+import (
+	"errors"
+	"fmt"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	npmcommands "github.com/jfrog/jfrog-cli-go/artifactory/commands/npm"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/yarn"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/auth"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const minSupportedArtifactoryVersion = "5.5.2"
+const configBackupFileName = "jfrog.yarnrc.backup"
+
+type YarnCommand struct {
+	repo               string
+	yarnArgs           []string
+	threads            int
+	buildConfiguration *utils.BuildConfiguration
+	rtDetails          *config.ArtifactoryDetails
+	artDetails         auth.ArtifactoryDetails
+	executablePath     string
+	workingDirectory   string
+	// .yarnrc for Yarn Classic, or .yarnrc.yml for Yarn Berry.
+	configFileName   string
+	configFileMode   os.FileMode
+	configContent    []byte
+	classic          bool
+	registry         string
+	npmAuth          string
+	collectBuildInfo bool
+	packageInfo      *npm.PackageInfo
+}
+
+func NewYarnCommand() *YarnCommand {
+	return &YarnCommand{}
+}
+
+func (yc *YarnCommand) SetRepo(repo string) *YarnCommand {
+	yc.repo = repo
+	return yc
+}
+
+func (yc *YarnCommand) SetYarnArgs(yarnArgs []string) *YarnCommand {
+	yc.yarnArgs = yarnArgs
+	return yc
+}
+
+func (yc *YarnCommand) SetThreads(threads int) *YarnCommand {
+	yc.threads = threads
+	return yc
+}
+
+func (yc *YarnCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *YarnCommand {
+	yc.buildConfiguration = buildConfiguration
+	return yc
+}
+
+func (yc *YarnCommand) SetRtDetails(rtDetails *config.ArtifactoryDetails) *YarnCommand {
+	yc.rtDetails = rtDetails
+	return yc
+}
+
+func (yc *YarnCommand) RtDetails() (*config.ArtifactoryDetails, error) {
+	return yc.rtDetails, nil
+}
+
+func (yc *YarnCommand) CommandName() string {
+	return "rt_yarn"
+}
+
+func (yc *YarnCommand) Run() error {
+	log.Info("Running yarn.")
+	if err := yc.preparePrerequisites(); err != nil {
+		return err
+	}
+
+	if err := yc.createTempConfig(); err != nil {
+		return yc.restoreConfigAndError(err)
+	}
+
+	if err := yc.runYarn(); err != nil {
+		return yc.restoreConfigAndError(err)
+	}
+
+	if err := yc.restoreConfig(); err != nil {
+		return err
+	}
+
+	if yc.collectBuildInfo {
+		if err := yc.saveDependenciesData(); err != nil {
+			return err
+		}
+	}
+	log.Info("yarn finished successfully.")
+	return nil
+}
+
+func (yc *YarnCommand) preparePrerequisites() error {
+	log.Debug("Preparing prerequisites.")
+	if err := yc.setYarnExecutable(); err != nil {
+		return err
+	}
+
+	if err := yc.setWorkingDirectory(); err != nil {
+		return err
+	}
+
+	if err := yc.prepareArtifactoryPrerequisites(); err != nil {
+		return err
+	}
+
+	if err := yc.prepareBuildInfo(); err != nil {
+		return err
+	}
+
+	return yc.backupProjectConfig()
+}
+
+func (yc *YarnCommand) setYarnExecutable() error {
+	yarnExecPath, err := exec.LookPath("yarn")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	yc.executablePath = yarnExecPath
+	log.Debug("Found yarn executable at:", yc.executablePath)
+
+	yarnVersion, err := yarn.Version(yc.executablePath)
+	if err != nil {
+		return err
+	}
+	log.Debug("Using yarn version:", yarnVersion)
+	yc.classic = yarn.IsClassic(yarnVersion)
+	yc.configFileName = yarn.BerryConfigFileName
+	if yc.classic {
+		yc.configFileName = yarn.ClassicConfigFileName
+	}
+	return nil
+}
+
+func (yc *YarnCommand) setWorkingDirectory() error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	if currentDir, err = filepath.Abs(currentDir); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	yc.workingDirectory = currentDir
+	log.Debug("Working directory set to:", yc.workingDirectory)
+	return nil
+}
+
+func (yc *YarnCommand) prepareArtifactoryPrerequisites() error {
+	artDetails, err := yc.rtDetails.CreateArtAuthConfig()
+	if err != nil {
+		return err
+	}
+	if artDetails.GetSshAuthHeaders() != nil {
+		return errorutils.CheckError(errors.New("SSH authentication is not supported in this command."))
+	}
+	yc.artDetails = artDetails
+
+	npmAuth, artifactoryVersion, err := npmcommands.GetArtifactoryDetails(yc.artDetails)
+	if err != nil {
+		return err
+	}
+	yc.npmAuth = npmAuth
+	if !version.NewVersion(artifactoryVersion).AtLeast(minSupportedArtifactoryVersion) {
+		return errorutils.CheckError(errors.New("This operation requires Artifactory version " + minSupportedArtifactoryVersion + " or higher."))
+	}
+
+	if err = utils.CheckIfRepoExists(yc.repo, yc.artDetails); err != nil {
+		return err
+	}
+	yc.registry = npmcommands.GetNpmRepositoryUrl(yc.repo, yc.artDetails.GetUrl())
+	return nil
+}
+
+func (yc *YarnCommand) prepareBuildInfo() error {
+	if len(yc.buildConfiguration.BuildName) == 0 || len(yc.buildConfiguration.BuildNumber) == 0 {
+		return nil
+	}
+	yc.collectBuildInfo = true
+	if err := utils.SaveBuildGeneralDetails(yc.buildConfiguration.BuildName, yc.buildConfiguration.BuildNumber); err != nil {
+		return err
+	}
+	var err error
+	yc.packageInfo, err = npm.ReadPackageInfoFromPackageJson(yc.workingDirectory)
+	return err
+}
+
+// To make yarn resolve the packages from Artifactory, the registry is added to the project's yarn config file.
+// If the project already has a config file, it is backed up and its other settings are kept.
+func (yc *YarnCommand) backupProjectConfig() error {
+	configPath := filepath.Join(yc.workingDirectory, yc.configFileName)
+	fileInfo, err := os.Stat(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			yc.configFileMode = 0644
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+
+	yc.configFileMode = fileInfo.Mode()
+	if yc.configContent, err = ioutil.ReadFile(configPath); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = ioutils.CopyFile(configPath, filepath.Join(yc.workingDirectory, configBackupFileName), yc.configFileMode); err != nil {
+		return err
+	}
+	log.Debug("Project", yc.configFileName, "file backed up successfully to", filepath.Join(yc.workingDirectory, configBackupFileName))
+	return nil
+}
+
+func (yc *YarnCommand) createTempConfig() error {
+	log.Debug("Creating project", yc.configFileName, "file.")
+	auth := yarn.ExtractAuth(yc.npmAuth)
+	configContent := yarn.CreateBerryConfig(yc.configContent, yc.registry, auth)
+	if yc.classic {
+		configContent = yarn.CreateClassicConfig(yc.configContent, yc.registry, auth)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(yc.workingDirectory, yc.configFileName), configContent, yc.configFileMode))
+}
+
+// Deletes the config file created by the command, and restores the backed up config file of the project if it exists.
+func (yc *YarnCommand) restoreConfig() error {
+	log.Debug("Restoring project", yc.configFileName, "file")
+	configPath := filepath.Join(yc.workingDirectory, yc.configFileName)
+	backupPath := filepath.Join(yc.workingDirectory, configBackupFileName)
+	if err := os.Remove(configPath); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(errors.New(yc.createRestoreErrorPrefix() + err.Error()))
+	}
+
+	if _, err := os.Stat(backupPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(errors.New(yc.createRestoreErrorPrefix() + err.Error()))
+	}
+
+	if err := ioutils.CopyFile(backupPath, configPath, yc.configFileMode); err != nil {
+		return err
+	}
+	if err := os.Remove(backupPath); err != nil {
+		return errorutils.CheckError(errors.New(yc.createRestoreErrorPrefix() + err.Error()))
+	}
+	log.Debug("Restored project", yc.configFileName, "file successfully")
+	return nil
+}
+
+func (yc *YarnCommand) createRestoreErrorPrefix() string {
+	return fmt.Sprintf("Error occurred while restoring project %s file. "+
+		"Delete '%s' and move '%s' (if exists) to '%s' in order to restore the project. Failure cause: \n",
+		yc.configFileName,
+		filepath.Join(yc.workingDirectory, yc.configFileName),
+		filepath.Join(yc.workingDirectory, configBackupFileName),
+		filepath.Join(yc.workingDirectory, yc.configFileName))
+}
+
+func (yc *YarnCommand) restoreConfigAndError(err error) error {
+	if restoreErr := yc.restoreConfig(); restoreErr != nil {
+		return errors.New(fmt.Sprintf("Two errors occurred:\n %s\n %s", restoreErr.Error(), err.Error()))
+	}
+	return err
+}
+
+func (yc *YarnCommand) runYarn() error {
+	log.Debug("Running yarn", strings.Join(yc.yarnArgs, " "))
+	yarnCmdConfig := &yarn.YarnConfig{
+		Yarn:      yc.executablePath,
+		Command:   yc.yarnArgs,
+		StrWriter: nil,
+		ErrWriter: nil,
+	}
+	return errorutils.CheckError(gofrogcmd.RunCmd(yarnCmdConfig))
+}
+
+// Saves the packages of yarn.lock as the dependencies of the module in a partial build info.
+func (yc *YarnCommand) saveDependenciesData() error {
+	log.Info("Collecting dependencies information... This may take a few minutes...")
+	lockfile, err := yarn.ReadLockfile(yc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	production, development, err := yarn.ReadProjectDependencies(yc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	scopes := lockfile.Scopes(production, development)
+	dependencies, missingPackages, err := yc.collectDependenciesChecksums(lockfile.Packages(), scopes)
+	if err != nil {
+		return err
+	}
+
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Dependencies = dependencies
+		if yc.buildConfiguration.Module == "" {
+			yc.buildConfiguration.Module = yc.packageInfo.BuildInfoModuleId()
+		}
+		partial.ModuleId = yc.buildConfiguration.Module
+	}
+	if err = utils.SavePartialBuildInfo(yc.buildConfiguration.BuildName, yc.buildConfiguration.BuildNumber, populateFunc); err != nil {
+		return err
+	}
+
+	if len(missingPackages) > 0 {
+		log.Warn(strings.Join(missingPackages, "\n"))
+		log.Warn("The yarn dependencies above could not be found in Artifactory and therefore are not included in the build-info.\n" +
+			"Make sure the dependencies are available in Artifactory for this build.\n" +
+			"Deleting the yarn cache will force populating Artifactory with these dependencies.")
+	}
+	return nil
+}
+
+// Fetches the checksums of the packages from Artifactory, using an AQL query per batch of packages.
+// The sha1 recorded in yarn.lock, if any, must match the one of the package found in Artifactory.
+func (yc *YarnCommand) collectDependenciesChecksums(packages []*yarn.Package, scopes map[*yarn.Package][]string) ([]buildinfo.Dependency, []string, error) {
+	servicesManager, err := utils.CreateServiceManager(yc.rtDetails, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	var packageVersions []npm.PackageVersion
+	for _, pkg := range packages {
+		packageVersions = append(packageVersions, npm.PackageVersion{Name: pkg.Name, Version: pkg.Version})
+	}
+	items, err := npm.SearchPackages(servicesManager, packageVersions, yc.threads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var foundDependencies []buildinfo.Dependency
+	var missingPackages []string
+	for i, pkg := range packages {
+		dependency := getDependency(pkg, items[i])
+		if dependency == nil {
+			missingPackages = append(missingPackages, pkg.Name+"-"+pkg.Version)
+			continue
+		}
+		dependency.Scopes = scopes[pkg]
+		foundDependencies = append(foundDependencies, *dependency)
+	}
+	return foundDependencies, missingPackages, nil
+}
+
+func getDependency(pkg *yarn.Package, items []serviceutils.ResultItem) *buildinfo.Dependency {
+	for _, item := range items {
+		if pkg.Sha1 != "" && item.Actual_Sha1 != pkg.Sha1 {
+			continue
+		}
+		log.Debug("Found", item.Name, "sha1:", item.Actual_Sha1, "md5", item.Actual_Md5)
+		return &buildinfo.Dependency{Id: item.Name, Checksum: &buildinfo.Checksum{Sha1: item.Actual_Sha1, Md5: item.Actual_Md5}}
+	}
+	log.Debug(pkg.Name, "-", pkg.Version, "could not be found in Artifactory.")
+	return nil
+}
@@ -0,0 +1,24 @@
+package yarn
+
+import (
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/yarn"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"testing"
+)
+
+func TestGetDependency(t *testing.T) {
+	log.SetDefaultLogger()
+	items := []serviceutils.ResultItem{
+		{Name: "lodash-4.17.21.tgz", Actual_Sha1: "111", Actual_Md5: "aaa"},
+		{Name: "lodash-4.17.21.tgz", Actual_Sha1: "222", Actual_Md5: "bbb"},
+	}
+	// The package found must have the sha1 recorded in yarn.lock.
+	dependency := getDependency(&yarn.Package{Name: "lodash", Version: "4.17.21", Sha1: "222"}, items)
+	if dependency == nil || dependency.Id != "lodash-4.17.21.tgz" || dependency.Md5 != "bbb" {
+		t.Errorf("Unexpected dependency: %+v", dependency)
+	}
+	if dependency = getDependency(&yarn.Package{Name: "lodash", Version: "4.17.21", Sha1: "333"}, items); dependency != nil {
+		t.Errorf("Expected the package not to be found, got %+v", dependency)
+	}
+}
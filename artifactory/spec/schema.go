@@ -0,0 +1,68 @@
+package spec
+
+// The JSON Schema of the File Spec, which editors and CI pipelines can use to validate specs before running the CLI.
+// Property names are matched case-insensitively by the CLI, but the schema documents their canonical form.
+const FileSpecSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "JFrog CLI File Spec",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["files"],
+  "properties": {
+    "files": {
+      "type": "array",
+      "items": {
+        "oneOf": [
+          {"$ref": "#/definitions/file"},
+          {"$ref": "#/definitions/include"}
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "booleanString": {
+      "type": "string",
+      "pattern": "^(true|false|True|False|TRUE|FALSE|t|f|T|F|1|0|\\$\\{[^}]+\\})$",
+      "description": "Either \"true\" or \"false\". Spec variables such as \"${FLAT}\" are allowed too, since they are replaced before the spec is validated."
+    },
+    "include": {
+      "type": "object",
+      "description": "Includes the file groups of another spec. A relative path is resolved against the directory of the including spec.",
+      "additionalProperties": false,
+      "required": ["include"],
+      "properties": {
+        "include": {"type": "string", "minLength": 1}
+      }
+    },
+    "file": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "aql": {
+          "type": "object",
+          "required": ["items.find"],
+          "properties": {
+            "items.find": {"type": "object"}
+          }
+        },
+        "pattern": {"type": "string"},
+        "excludePatterns": {"type": "array", "items": {"type": "string"}},
+        "target": {"type": "string"},
+        "explode": {"$ref": "#/definitions/booleanString"},
+        "props": {"type": "string"},
+        "sortOrder": {"type": "string", "enum": ["asc", "desc"]},
+        "sortBy": {"type": "array", "items": {"type": "string"}},
+        "offset": {"type": "integer", "minimum": 0},
+        "limit": {"type": "integer", "minimum": 0},
+        "build": {"type": "string"},
+        "recursive": {"$ref": "#/definitions/booleanString"},
+        "flat": {"$ref": "#/definitions/booleanString"},
+        "regexp": {"$ref": "#/definitions/booleanString"},
+        "includeDirs": {"$ref": "#/definitions/booleanString"},
+        "archiveEntries": {"type": "string"}
+      },
+      "not": {"required": ["aql", "pattern"]}
+    }
+  }
+}
+`
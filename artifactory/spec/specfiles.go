@@ -1,15 +1,12 @@
 package spec
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
-	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"regexp"
 )
 
 const fileSpecWithBuildNoRepoValidationMessage = "Spec cannot include both 'build' and '%s', if 'pattern' is empty or '*'."
@@ -26,31 +23,38 @@ func (spec *SpecFiles) Get(index int) *File {
 	return new(File)
 }
 
+// Reads a JSON or YAML File Spec, replaces its variables and resolves its includes.
+// Unknown properties and wrong value types are reported with the location of the file group.
 func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *SpecFiles, err error) {
-	spec = new(SpecFiles)
-	content, err := fileutils.ReadFile(specFilePath)
-	if errorutils.CheckError(err) != nil {
-		return
+	files, err := readSpecFiles(specFilePath, specVars, nil)
+	if err != nil {
+		return nil, err
 	}
-
-	if len(specVars) > 0 {
-		content = replaceSpecVars(content, specVars)
-	}
-
-	err = json.Unmarshal(content, spec)
-	if errorutils.CheckError(err) != nil {
-		return
-	}
-	return
+	return &SpecFiles{Files: files}, nil
 }
 
+// Matches ${VAR} and ${VAR:-default}.
+var specVarRegexp = regexp.MustCompile(`\$\{([^}:]+)(:-([^}]*))?\}`)
+
+// Replaces ${VAR} with the value of VAR, and ${VAR:-default} with the value of VAR or with the default if VAR is not provided.
+// Variables which are not provided and have no default are left unchanged.
 func replaceSpecVars(content []byte, specVars map[string]string) []byte {
-	log.Debug("Replacing variables in the provided File Spec: \n" + string(content))
-	for key, val := range specVars {
-		key = "${" + key + "}"
-		log.Debug(fmt.Sprintf("Replacing '%s' with '%s'", key, val))
-		content = bytes.Replace(content, []byte(key), []byte(val), -1)
+	if !specVarRegexp.Match(content) {
+		return content
 	}
+	log.Debug("Replacing variables in the provided File Spec: \n" + string(content))
+	content = specVarRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		groups := specVarRegexp.FindSubmatch(match)
+		if val, ok := specVars[string(groups[1])]; ok {
+			log.Debug(fmt.Sprintf("Replacing '%s' with '%s'", match, val))
+			return []byte(val)
+		}
+		if groups[2] != nil {
+			log.Debug(fmt.Sprintf("Replacing '%s' with the default '%s'", match, groups[3]))
+			return groups[3]
+		}
+		return match
+	})
 	log.Debug("The reformatted File Spec is: \n" + string(content))
 	return content
 }
@@ -2,7 +2,14 @@ package spec
 
 import (
 	"bytes"
+	"encoding/json"
 	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -42,3 +49,161 @@ func assertVariablesMap(expected, actual []byte, t *testing.T) {
 		t.Error("Wrong matching expected: `" + string(expected) + "` Got `" + string(actual) + "`")
 	}
 }
+
+func TestReplaceSpecVarsDefaults(t *testing.T) {
+	log.SetDefaultLogger()
+	actual := replaceSpecVars([]byte("${repo:-generic-local}/${dir:-}a"), map[string]string{})
+	assertVariablesMap([]byte("generic-local/a"), actual, t)
+
+	actual = replaceSpecVars([]byte("${repo:-generic-local}/a"), map[string]string{"repo": "libs"})
+	assertVariablesMap([]byte("libs/a"), actual, t)
+
+	actual = replaceSpecVars([]byte("${repo:-generic-local}/${foo}"), nil)
+	assertVariablesMap([]byte("generic-local/${foo}"), actual, t)
+}
+
+func TestCreateSpecFromFileUnknownProperty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := writeTestSpec(t, dir, "spec.json", "{\n  \"files\": [\n    {\n      \"pattern\": \"a/*\",\n      \"patern\": \"b/*\"\n    }\n  ]\n}")
+	_, err = CreateSpecFromFile(specPath, nil)
+	assertSpecError(t, err, `files[0]: unknown property "patern" (line 5, column 7)`)
+}
+
+func TestCreateSpecFromFileWrongType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := writeTestSpec(t, dir, "spec.json", "{\"files\": [{\"pattern\": \"a/*\"}, {\"pattern\": \"b/*\",\n\"limit\": \"5\"}]}")
+	_, err = CreateSpecFromFile(specPath, nil)
+	assertSpecError(t, err, "files[1]: the value of 'limit' must be of type int, not string (line 2, column 1)")
+}
+
+func TestCreateSpecFromFileInvalidBoolean(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := writeTestSpec(t, dir, "spec.json", "{\"files\": [{\"pattern\": \"a/*\",\n\"Flat\": \"${FLAT}\"}]}")
+	_, err = CreateSpecFromFile(specPath, map[string]string{"FLAT": "yes"})
+	assertSpecError(t, err, `files[0]: the value of 'flat' must be "true" or "false", not "yes" (line 2, column 1)`)
+
+	spec, err := CreateSpecFromFile(specPath, map[string]string{"FLAT": "true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flat, err := spec.Get(0).IsFlat(false); err != nil || !flat {
+		t.Errorf("Expected flat to be true, got %v (%v)", flat, err)
+	}
+}
+
+func TestCreateSpecFromFileUnknownTopLevelProperty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := writeTestSpec(t, dir, "spec.json", "{\"file\": []}")
+	_, err = CreateSpecFromFile(specPath, nil)
+	assertSpecError(t, err, `unknown property "file" (line 1, column 2)`)
+}
+
+func TestCreateSpecFromYaml(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	specPath := writeTestSpec(t, dir, "spec.yaml", "files:\n  - pattern: ${REPO:-generic-local}/*.zip\n    target: out/\n    flat: true\n    limit: 3\n")
+	spec, err := CreateSpecFromFile(specPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := File{Pattern: "generic-local/*.zip", Target: "out/", Flat: "true", Limit: 3}
+	if len(spec.Files) != 1 || !reflect.DeepEqual(spec.Files[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, spec.Files)
+	}
+
+	specPath = writeTestSpec(t, dir, "spec.yml", "files:\n  - patern: a/*\n")
+	_, err = CreateSpecFromFile(specPath, nil)
+	assertSpecError(t, err, `files[0]: unknown property "patern"`)
+}
+
+func TestCreateSpecFromFileWithInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestSpec(t, filepath.Join(dir, "common"), "shared.json", `{"files": [{"pattern": "${REPO}/shared/*"}]}`)
+	specPath := writeTestSpec(t, dir, "spec.json", `{"files": [{"pattern": "a/*"}, {"include": "common/shared.json"}, {"pattern": "b/*"}]}`)
+	spec, err := CreateSpecFromFile(specPath, map[string]string{"REPO": "libs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var patterns []string
+	for _, file := range spec.Files {
+		patterns = append(patterns, file.Pattern)
+	}
+	if !reflect.DeepEqual(patterns, []string{"a/*", "libs/shared/*", "b/*"}) {
+		t.Errorf("Unexpected patterns %v", patterns)
+	}
+
+	writeTestSpec(t, dir, "cycle.json", `{"files": [{"include": "spec2.json"}]}`)
+	writeTestSpec(t, dir, "spec2.json", `{"files": [{"include": "cycle.json"}]}`)
+	_, err = CreateSpecFromFile(filepath.Join(dir, "cycle.json"), nil)
+	if err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Expected an include cycle error, got %v", err)
+	}
+
+	writeTestSpec(t, dir, "mixed.json", `{"files": [{"include": "spec2.json", "pattern": "a/*"}]}`)
+	_, err = CreateSpecFromFile(filepath.Join(dir, "mixed.json"), nil)
+	assertSpecError(t, err, "files[0]: 'include' cannot be combined with other properties")
+}
+
+// Verifies that the schema documents every property of a file group.
+func TestFileSpecSchema(t *testing.T) {
+	schema := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(FileSpecSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	definitions := schema["definitions"].(map[string]interface{})
+	properties := definitions["file"].(map[string]interface{})["properties"].(map[string]interface{})
+	fileType := reflect.TypeOf(File{})
+	for i := 0; i < fileType.NumField(); i++ {
+		name := fileType.Field(i).Name
+		if _, ok := properties[strings.ToLower(name[:1])+name[1:]]; !ok {
+			t.Errorf("The File Spec schema is missing the '%s' property", name)
+		}
+	}
+
+	booleanPattern := regexp.MustCompile(definitions["booleanString"].(map[string]interface{})["pattern"].(string))
+	for value, expected := range map[string]bool{"true": true, "False": true, "${FLAT}": true, "yes": false, "": false} {
+		if booleanPattern.MatchString(value) != expected {
+			t.Errorf("Expected the boolean pattern to match %q: %t", value, expected)
+		}
+	}
+}
+
+func writeTestSpec(t *testing.T, dir, name, content string) string {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(specPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return specPath
+}
+
+func assertSpecError(t *testing.T, err error, expectedSuffix string) {
+	if err == nil || !strings.HasSuffix(err.Error(), expectedSuffix) {
+		t.Errorf("Expected an error ending with '%s', got: %v", expectedSuffix, err)
+	}
+}
@@ -0,0 +1,290 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The property of a file group which includes the file groups of another spec, instead of defining its own.
+const includeProperty = "include"
+
+type rawSpecFiles struct {
+	Files []json.RawMessage
+}
+
+// Reads the file groups of the spec at specFilePath, replacing the includes with the file groups of the included specs.
+// includedBy holds the specs which include this one, and is used to detect include cycles.
+func readSpecFiles(specFilePath string, specVars map[string]string, includedBy []string) ([]File, error) {
+	absPath, err := filepath.Abs(specFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, includingPath := range includedBy {
+		if includingPath == absPath {
+			return nil, errorutils.CheckError(fmt.Errorf("%s: include cycle: %s -> %s", specFilePath, strings.Join(includedBy, " -> "), absPath))
+		}
+	}
+	content, err := fileutils.ReadFile(specFilePath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	content = replaceSpecVars(content, specVars)
+
+	isYaml := isYamlSpec(specFilePath)
+	if isYaml {
+		if content, err = yamlToJson(content); err != nil {
+			return nil, errorutils.CheckError(fmt.Errorf("%s: %s", specFilePath, err.Error()))
+		}
+	}
+	parser := &specParser{content: content, reportLocation: !isYaml}
+	entries, err := parser.parse()
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s: %s", specFilePath, err.Error()))
+	}
+
+	var files []File
+	for _, entry := range entries {
+		if entry.include == "" {
+			files = append(files, entry.file)
+			continue
+		}
+		includePath := entry.include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(specFilePath), includePath)
+		}
+		includedFiles, err := readSpecFiles(includePath, specVars, append(includedBy, absPath))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, includedFiles...)
+	}
+	return files, nil
+}
+
+func isYamlSpec(specFilePath string) bool {
+	ext := strings.ToLower(filepath.Ext(specFilePath))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// A single entry of the files array - either a file group or an include of another spec.
+type specEntry struct {
+	file    File
+	include string
+}
+
+type specParser struct {
+	content []byte
+	// YAML specs are converted to JSON before being parsed, so the locations in the converted content are meaningless.
+	reportLocation bool
+}
+
+func (sp *specParser) parse() ([]specEntry, error) {
+	spec := new(rawSpecFiles)
+	decoder := json.NewDecoder(bytes.NewReader(sp.content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(spec); err != nil {
+		return nil, sp.decodeError(err, 0, len(sp.content))
+	}
+
+	var entries []specEntry
+	searchFrom := 0
+	for i, raw := range spec.Files {
+		// The raw message holds the exact bytes of the entry, so its offset in the content can be found by searching for it.
+		entryOffset := searchFrom + bytes.Index(sp.content[searchFrom:], raw)
+		searchFrom = entryOffset + len(raw)
+		entry, err := sp.parseEntry(raw, entryOffset)
+		if err != nil {
+			return nil, fmt.Errorf("files[%d]: %s", i, err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (sp *specParser) parseEntry(raw json.RawMessage, entryOffset int) (specEntry, error) {
+	include, isInclude, err := getInclude(raw)
+	if err != nil {
+		return specEntry{}, sp.decodeError(err, entryOffset, len(raw))
+	}
+	if isInclude {
+		return specEntry{include: include}, nil
+	}
+	file := File{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&file); err != nil {
+		return specEntry{}, sp.decodeError(err, entryOffset, len(raw))
+	}
+	if err = sp.validateBooleans(file, raw, entryOffset); err != nil {
+		return specEntry{}, err
+	}
+	return specEntry{file: file}, nil
+}
+
+// The boolean properties of a file group are strings, which the commands parse with strconv.ParseBool.
+func (sp *specParser) validateBooleans(file File, raw json.RawMessage, entryOffset int) error {
+	booleans := []struct{ name, value string }{
+		{"explode", file.Explode}, {"recursive", file.Recursive}, {"flat", file.Flat}, {"regexp", file.Regexp}, {"includeDirs", file.IncludeDirs},
+	}
+	for _, property := range booleans {
+		if _, err := strconv.ParseBool(property.value); property.value == "" || err == nil {
+			continue
+		}
+		message := fmt.Sprintf("the value of '%s' must be \"true\" or \"false\", not %q", property.name, property.value)
+		// Property names are matched case-insensitively, so the property is searched for in the same way.
+		errorOffset := bytes.Index(bytes.ToLower(raw), []byte(`"`+strings.ToLower(property.name)+`"`))
+		if errorOffset >= 0 {
+			errorOffset += entryOffset
+		}
+		return sp.locatedError(message, errorOffset)
+	}
+	return nil
+}
+
+// Returns the included spec path, if the entry is an include.
+// An include entry cannot contain any other property.
+func getInclude(raw json.RawMessage) (string, bool, error) {
+	properties := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &properties); err != nil {
+		return "", false, err
+	}
+	for key, value := range properties {
+		if !strings.EqualFold(key, includeProperty) {
+			continue
+		}
+		if len(properties) > 1 {
+			return "", false, errors.New("'include' cannot be combined with other properties")
+		}
+		include := ""
+		if err := json.Unmarshal(value, &include); err != nil || include == "" {
+			return "", false, errors.New("'include' must be a non-empty spec path")
+		}
+		return include, true, nil
+	}
+	return "", false, nil
+}
+
+// Converts a decoding error to a user friendly error, including its line and column.
+// offset and length are the location of the decoded part in the content.
+func (sp *specParser) decodeError(err error, offset, length int) error {
+	message := err.Error()
+	errorOffset := -1
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		errorOffset = offset + int(e.Offset)
+	case *json.UnmarshalTypeError:
+		errorOffset = offset + int(e.Offset)
+		// The reported offset is the end of the value, so the location of its property is preferred.
+		property := e.Field[strings.LastIndex(e.Field, ".")+1:]
+		if index := bytes.LastIndex(sp.content[offset:errorOffset], []byte(`"`+property+`"`)); property != "" && index >= 0 {
+			errorOffset = offset + index
+		}
+		message = fmt.Sprintf("the value of '%s' must be of type %s, not %s", e.Field, e.Type.String(), e.Value)
+	default:
+		// The decoder reports unknown fields without their offset, so it is found by searching for the quoted field name.
+		if strings.HasPrefix(message, "json: unknown field ") {
+			field := strings.TrimPrefix(message, "json: unknown field ")
+			message = "unknown property " + field
+			if index := bytes.Index(sp.content[offset:offset+length], []byte(field)); index >= 0 {
+				errorOffset = offset + index
+			}
+		}
+	}
+	return sp.locatedError(strings.TrimPrefix(message, "json: "), errorOffset)
+}
+
+// Adds the line and column of the offset in the content to the error message, unless the offset is unknown.
+func (sp *specParser) locatedError(message string, errorOffset int) error {
+	if !sp.reportLocation || errorOffset < 0 {
+		return errors.New(message)
+	}
+	line, column := getLineAndColumn(sp.content, errorOffset)
+	return fmt.Errorf("%s (line %d, column %d)", message, line, column)
+}
+
+func getLineAndColumn(content []byte, offset int) (line, column int) {
+	if offset > len(content) {
+		offset = len(content)
+	}
+	before := content[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = offset - bytes.LastIndexByte(before, '\n')
+	return
+}
+
+// Converts YAML content to JSON, so that it can be decoded the same way as a JSON spec.
+func yamlToJson(content []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	value, err := toJsonValue(value)
+	if err != nil {
+		return nil, err
+	}
+	stringifyBooleans(value)
+	return json.Marshal(value)
+}
+
+// The boolean properties of a file group are strings, while YAML users naturally write them as booleans (flat: true).
+func stringifyBooleans(spec interface{}) {
+	specMap, ok := spec.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for key, files := range specMap {
+		filesList, ok := files.([]interface{})
+		if !ok || !strings.EqualFold(key, "files") {
+			continue
+		}
+		for _, file := range filesList {
+			fileMap, ok := file.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for property, value := range fileMap {
+				if boolValue, ok := value.(bool); ok {
+					fileMap[property] = strconv.FormatBool(boolValue)
+				}
+			}
+		}
+	}
+}
+
+// The YAML decoder returns maps with interface{} keys, which cannot be marshaled to JSON.
+func toJsonValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("the key '%v' must be a string", key)
+			}
+			converted, err := toJsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[keyString] = converted
+		}
+		return result, nil
+	case []interface{}:
+		for i, item := range v {
+			converted, err := toJsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	}
+	return value, nil
+}
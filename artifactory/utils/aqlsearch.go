@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"strings"
+)
+
+// The maximum number of criteria searched by a single AQL query.
+const aqlBatchSize = 100
+
+// Searches Artifactory in batches of criteria, using a single items.find({"$or":[...]}) AQL query for each batch.
+// Each criterion is a JSON object, such as {"name":"file.tgz"}, and the results include the given fields.
+// The batches are searched by the given number of threads. The result of each batch is passed to handleBatch,
+// along with the range [start, end) of its criteria. If more than one thread is used, handleBatch is called concurrently.
+func SearchInBatches(servicesManager *artifactory.ArtifactoryServicesManager, criteria, includeFields []string, threads int, handleBatch func(start, end int, result []byte) error) error {
+	producerConsumer := parallel.NewBounedRunner(threads, false)
+	errorsQueue := serviceutils.NewErrorsQueue(1)
+	go func() {
+		defer producerConsumer.Done()
+		for start := 0; start < len(criteria); start += aqlBatchSize {
+			end := start + aqlBatchSize
+			if end > len(criteria) {
+				end = len(criteria)
+			}
+			producerConsumer.AddTaskWithError(createSearchBatchFunc(servicesManager, criteria, includeFields, start, end, handleBatch), errorsQueue.AddError)
+		}
+	}()
+	producerConsumer.Run()
+	return errorsQueue.GetError()
+}
+
+func createSearchBatchFunc(servicesManager *artifactory.ArtifactoryServicesManager, criteria, includeFields []string, start, end int, handleBatch func(start, end int, result []byte) error) parallel.TaskFunc {
+	return func(threadId int) error {
+		log.Debug(clientutils.GetLogMsgPrefix(threadId, false), "Searching", end-start, "items in Artifactory")
+		result, err := servicesManager.Aql(createBatchAqlQuery(criteria[start:end], includeFields))
+		if err != nil {
+			return err
+		}
+		return handleBatch(start, end, result)
+	}
+}
+
+func createBatchAqlQuery(criteria, includeFields []string) string {
+	var quotedFields []string
+	for _, field := range includeFields {
+		quotedFields = append(quotedFields, fmt.Sprintf("%q", field))
+	}
+	return fmt.Sprintf(`items.find({"$or":[%s]}).include(%s)`, strings.Join(criteria, ","), strings.Join(quotedFields, ","))
+}
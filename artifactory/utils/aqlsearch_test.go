@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCreateBatchAqlQuery(t *testing.T) {
+	query := createBatchAqlQuery([]string{`{"name":"a.tgz"}`, `{"name":"b.tgz"}`}, []string{"name", "actual_sha1"})
+	expected := `items.find({"$or":[{"name":"a.tgz"},{"name":"b.tgz"}]}).include("name","actual_sha1")`
+	if query != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, query)
+	}
+}
+
+func TestSearchInBatches(t *testing.T) {
+	log.SetDefaultLogger()
+	var mutex sync.Mutex
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mutex.Lock()
+		queries = append(queries, string(body))
+		mutex.Unlock()
+		w.Write([]byte(`{"results":[]}`))
+	}))
+	defer ts.Close()
+	servicesManager, err := CreateServiceManager(&config.ArtifactoryDetails{Url: ts.URL + "/"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var criteria []string
+	for i := 0; i < 2*aqlBatchSize+1; i++ {
+		criteria = append(criteria, fmt.Sprintf(`{"name":"%d.tgz"}`, i))
+	}
+	searched := make([]int, len(criteria))
+	err = SearchInBatches(servicesManager, criteria, []string{"name"}, 3, func(start, end int, result []byte) error {
+		for i := start; i < end; i++ {
+			searched[i]++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 3 {
+		t.Errorf("Expected 3 queries, got %d.", len(queries))
+	}
+	for i, count := range searched {
+		if count != 1 {
+			t.Errorf("Expected criterion %d to be searched once, got %d.", i, count)
+		}
+	}
+	for _, query := range queries {
+		if !strings.HasPrefix(query, `items.find({"$or":[`) {
+			t.Errorf("Unexpected query: %s", query)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/lock"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+const buildBundleVersion = 1
+
+// A portable bundle of a locally collected build - its partials, general details, module properties and generated build-info files.
+// The bundle allows merging builds collected on different machines before publishing them.
+type BuildBundle struct {
+	Version           int                            `json:"version"`
+	BuildName         string                         `json:"buildName"`
+	BuildNumber       string                         `json:"buildNumber"`
+	Details           *buildinfo.General             `json:"details,omitempty"`
+	Partials          buildinfo.Partials             `json:"partials,omitempty"`
+	ModulesProperties map[string]map[string][]string `json:"modulesProperties,omitempty"`
+	BuildsInfo        []*buildinfo.BuildInfo         `json:"buildsInfo,omitempty"`
+}
+
+// Determines how an imported build bundle is combined with a build which was already collected locally.
+type ImportConflictPolicy string
+
+const (
+	// Add the partials, module properties and generated build-info files of the bundle to the local build. Identical partials are added once.
+	ImportMerge ImportConflictPolicy = "merge"
+	// Remove the local build before importing the bundle.
+	ImportOverwrite ImportConflictPolicy = "overwrite"
+	// Fail if the build was already collected locally.
+	ImportFail ImportConflictPolicy = "fail"
+)
+
+func GetImportConflictPolicy(policy string) (ImportConflictPolicy, error) {
+	switch ImportConflictPolicy(policy) {
+	case "":
+		return ImportMerge, nil
+	case ImportMerge, ImportOverwrite, ImportFail:
+		return ImportConflictPolicy(policy), nil
+	}
+	return "", errorutils.CheckError(fmt.Errorf("Unsupported conflict policy '%s'. Supported values: %s, %s, %s.", policy, ImportMerge, ImportOverwrite, ImportFail))
+}
+
+// Writes the locally collected build to a bundle file.
+func ExportBuild(buildName, buildNumber, bundlePath string) (*BuildBundle, error) {
+	exists, err := LocalBuildExists(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckError(fmt.Errorf("Build %s/%s was not found locally.", buildName, buildNumber))
+	}
+	snapshot, err := ReadBuildSnapshot(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &BuildBundle{Version: buildBundleVersion, BuildName: buildName, BuildNumber: buildNumber, Details: snapshot.GeneralDetails,
+		Partials: snapshot.Partials, ModulesProperties: snapshot.ModulesProperties, BuildsInfo: snapshot.GeneratedBuildsInfo}
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if dir := filepath.Dir(bundlePath); dir != "" {
+		if err = os.MkdirAll(dir, 0777); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+	log.Info(fmt.Sprintf("Exporting build %s/%s to %s", buildName, buildNumber, bundlePath))
+	return bundle, errorutils.CheckError(ioutil.WriteFile(bundlePath, content, 0600))
+}
+
+// Reads a bundle file and adds it to the local build dir, according to the conflict policy.
+func ImportBuild(bundlePath string, policy ImportConflictPolicy) (*BuildBundle, error) {
+	content, err := fileutils.ReadFile(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	bundle := new(BuildBundle)
+	if err = json.Unmarshal(content, bundle); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a valid build bundle: %s", bundlePath, err.Error()))
+	}
+	if bundle.Version != buildBundleVersion || bundle.BuildName == "" || bundle.BuildNumber == "" {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a valid build bundle.", bundlePath))
+	}
+	buildLock, err := lockBuild(bundle.BuildName, bundle.BuildNumber, lock.Exclusive)
+	if err != nil {
+		return nil, err
+	}
+	defer buildLock.Unlock()
+
+	localDetails, err := ReadBuildInfoGeneralDetails(bundle.BuildName, bundle.BuildNumber)
+	exists := err == nil
+	if exists {
+		switch policy {
+		case ImportFail:
+			return nil, errorutils.CheckError(fmt.Errorf("Build %s/%s was already collected locally.", bundle.BuildName, bundle.BuildNumber))
+		case ImportOverwrite:
+			if err = removeBuildDir(bundle.BuildName, bundle.BuildNumber); err != nil {
+				return nil, err
+			}
+			exists = false
+		}
+	}
+	log.Info(fmt.Sprintf("Importing build %s/%s from %s", bundle.BuildName, bundle.BuildNumber, bundlePath))
+
+	// When merging, the build keeps the earliest start time of the merged builds.
+	details := bundle.Details
+	if exists && (details == nil || localDetails.Timestamp.Before(details.Timestamp)) {
+		details = localDetails
+	}
+	if details != nil {
+		if err = saveBuildGeneralDetails(bundle.BuildName, bundle.BuildNumber, details); err != nil {
+			return nil, err
+		}
+	}
+	if err = importPartials(bundle); err != nil {
+		return nil, err
+	}
+	if err = importModulesProperties(bundle); err != nil {
+		return nil, err
+	}
+	return bundle, importGeneratedBuildsInfo(bundle)
+}
+
+// Identical partials are detected by their JSON representation, so that importing the same bundle twice has no effect.
+func importPartials(bundle *BuildBundle) error {
+	localPartials, err := ReadPartialBuildInfoFiles(bundle.BuildName, bundle.BuildNumber)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, partial := range localPartials {
+		content, err := json.Marshal(partial)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		existing[string(content)] = true
+	}
+	for _, partial := range bundle.Partials {
+		content, err := json.Marshal(partial)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if existing[string(content)] {
+			log.Debug("Skipping a partial build-info which already exists locally.")
+			continue
+		}
+		existing[string(content)] = true
+		if err = saveBuildData(partial, bundle.BuildName, bundle.BuildNumber); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Only the properties which differ from the local ones are saved, so that importing the same bundle twice has no effect.
+func importModulesProperties(bundle *BuildBundle) error {
+	localModulesProperties, err := ReadModuleProperties(bundle.BuildName, bundle.BuildNumber)
+	if err != nil {
+		return err
+	}
+	for moduleId, properties := range bundle.ModulesProperties {
+		changed := make(map[string][]string)
+		for key, values := range properties {
+			if !reflect.DeepEqual(localModulesProperties[moduleId][key], values) {
+				changed[key] = values
+			}
+		}
+		if len(changed) == 0 {
+			log.Debug("Skipping the properties of module", moduleId, "which already exist locally.")
+			continue
+		}
+		if err = saveModuleProperties(bundle.BuildName, bundle.BuildNumber, &ModuleProperties{ModuleId: moduleId, Properties: changed}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func importGeneratedBuildsInfo(bundle *BuildBundle) error {
+	localBuildsInfo, err := GetGeneratedBuildsInfo(bundle.BuildName, bundle.BuildNumber)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, buildInfo := range localBuildsInfo {
+		content, err := json.Marshal(buildInfo)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		existing[string(content)] = true
+	}
+	for _, buildInfo := range bundle.BuildsInfo {
+		content, err := json.Marshal(buildInfo)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if existing[string(content)] {
+			log.Debug("Skipping a generated build-info which already exists locally.")
+			continue
+		}
+		existing[string(content)] = true
+		if err = saveBuildInfo(bundle.BuildName, bundle.BuildNumber, buildInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportImportBuild(t *testing.T) {
+	log.SetDefaultLogger()
+	buildName, buildNumber := "build-bundle-test", "1"
+	defer RemoveBuildDir(buildName, buildNumber)
+	tempDir, err := ioutil.TempDir("", "buildbundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	if err := SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	populateArtifact := func(name string) populatePartialBuildInfo {
+		return func(partial *buildinfo.Partial) {
+			partial.ModuleId = "module"
+			partial.Artifacts = []buildinfo.Artifact{{Name: name, Checksum: &buildinfo.Checksum{Sha1: name}}}
+		}
+	}
+	if err := SavePartialBuildInfo(buildName, buildNumber, populateArtifact("first")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveBuildInfo(buildName, buildNumber, &buildinfo.BuildInfo{Name: buildName, Number: buildNumber}); err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(tempDir, "bundle", "build.json")
+	if _, err := ExportBuild(buildName, buildNumber, bundlePath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Importing the bundle to the same build has no effect, since its content already exists.
+	if _, err := ImportBuild(bundlePath, ImportMerge); err != nil {
+		t.Fatal(err)
+	}
+	assertLocalBuild(t, buildName, buildNumber, 1, 1)
+
+	if _, err := ImportBuild(bundlePath, ImportFail); err == nil {
+		t.Error("Expected the import to fail, since the build exists locally.")
+	}
+
+	// A partial collected on another agent is added to the local build.
+	if err := SavePartialBuildInfo(buildName, buildNumber, populateArtifact("second")); err != nil {
+		t.Fatal(err)
+	}
+	secondBundlePath := filepath.Join(tempDir, "build.json")
+	if _, err := ExportBuild(buildName, buildNumber, secondBundlePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ImportBuild(bundlePath, ImportOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	assertLocalBuild(t, buildName, buildNumber, 1, 1)
+	if _, err := ImportBuild(secondBundlePath, ImportMerge); err != nil {
+		t.Fatal(err)
+	}
+	assertLocalBuild(t, buildName, buildNumber, 2, 1)
+}
+
+func assertLocalBuild(t *testing.T, buildName, buildNumber string, expectedPartials, expectedBuildsInfo int) {
+	partials, err := ReadPartialBuildInfoFiles(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partials) != expectedPartials {
+		t.Errorf("Expected %d partials, got %d", expectedPartials, len(partials))
+	}
+	buildsInfo, err := GetGeneratedBuildsInfo(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buildsInfo) != expectedBuildsInfo {
+		t.Errorf("Expected %d generated build-info files, got %d", expectedBuildsInfo, len(buildsInfo))
+	}
+}
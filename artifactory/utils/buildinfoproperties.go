@@ -257,6 +257,10 @@ func setServerDetailsToConfig(contextPrefix string, vConfig *viper.Viper) error
 	if artDetails.GetUrl() == "" {
 		return errorutils.CheckError(errors.New("Server ID " + serverId + " URL is required"))
 	}
+	artDetails, err = artDetails.ResolveCredentials()
+	if err != nil {
+		return err
+	}
 	vConfig.Set(contextPrefix+URL, artDetails.GetUrl())
 
 	if artDetails.GetApiKey() != "" {
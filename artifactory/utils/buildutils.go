@@ -2,17 +2,27 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	"github.com/jfrog/jfrog-cli-go/utils/lock"
 	"github.com/jfrog/jfrog-client-go/artifactory/auth"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -21,10 +31,17 @@ import (
 
 const BuildInfoDetails = "details"
 const BuildTempPath = "jfrog/builds/"
+const BuildLocksTempPath = "jfrog/locks/builds/"
+
+// The prefix of the files which are being written to the build dir. Readers ignore these files until they are renamed.
+const buildTempFilePrefix = ioutils.AtomicWriteTempPrefix
+
+func encodeBuildDirName(buildName, buildNumber string) string {
+	return base64.StdEncoding.EncodeToString([]byte(buildName + "_" + buildNumber))
+}
 
 func GetBuildDir(buildName, buildNumber string) (string, error) {
-	encodedDirName := base64.StdEncoding.EncodeToString([]byte(buildName + "_" + buildNumber))
-	buildsDir := filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildTempPath, encodedDirName)
+	buildsDir := filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildTempPath, encodeBuildDirName(buildName, buildNumber))
 	err := os.MkdirAll(buildsDir, 0777)
 	if errorutils.CheckError(err) != nil {
 		return "", err
@@ -32,6 +49,24 @@ func GetBuildDir(buildName, buildNumber string) (string, error) {
 	return buildsDir, nil
 }
 
+// Returns true if the build was collected locally and not yet published. Unlike GetBuildDir, the build dir isn't created.
+func LocalBuildExists(buildName, buildNumber string) (bool, error) {
+	buildDir := filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildTempPath, encodeBuildDirName(buildName, buildNumber))
+	return fileutils.IsDirExists(buildDir, false)
+}
+
+// Acquires the lock of the build, which serializes the changes of concurrent processes to the local data of the build.
+// The locks are kept outside the build dir, so that removing the build dir doesn't remove them.
+func lockBuild(buildName, buildNumber string, mode lock.Mode) (lock.Lock, error) {
+	return lock.CreateLockFile(getBuildLockFilePath(buildName, buildNumber), mode)
+}
+
+// The lock file is named by a hash of the build, which is a valid file name for any build name and number.
+func getBuildLockFilePath(buildName, buildNumber string) string {
+	hash := sha256.Sum256([]byte(buildName + "_" + buildNumber))
+	return filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildLocksTempPath, hex.EncodeToString(hash[:])+".lck")
+}
+
 func CreateBuildProperties(buildName, buildNumber string) (string, error) {
 	if buildName == "" || buildNumber == "" {
 		return "", nil
@@ -57,55 +92,105 @@ func getPartialsBuildDir(buildName, buildNumber string) (string, error) {
 	return buildDir, nil
 }
 
-func saveBuildData(action interface{}, buildName, buildNumber string) error {
-	b, err := json.Marshal(&action)
-	if errorutils.CheckError(err) != nil {
+// Writes the content to a new file in the dir. The file is written to a temp file first, which readers ignore until it is complete.
+// The caller is expected to hold the lock of the build, so that the name of the new file is unique in the dir.
+func createBuildFile(dirPath string, content []byte) error {
+	log.Debug("Creating build file at:", dirPath)
+	filePath, err := getNewBuildFilePath(dirPath)
+	if err != nil {
 		return err
 	}
+	return ioutils.WriteFileAtomically(filePath, content)
+}
+
+func getNewBuildFilePath(dirPath string) (string, error) {
+	for i := time.Now().UnixNano(); ; i++ {
+		filePath := filepath.Join(dirPath, "temp"+strconv.FormatInt(i, 10))
+		exists, err := fileutils.IsFileExists(filePath, false)
+		if err != nil || !exists {
+			return filePath, err
+		}
+	}
+}
+
+// Replaces the file atomically, so that readers get either its previous or its new content.
+func replaceBuildFile(filePath string, content []byte) error {
+	return ioutils.WriteFileAtomically(filePath, content)
+}
+
+// Returns the complete files in the dir, skipping sub-dirs and files which are still being written.
+func listBuildFiles(dirPath string) ([]string, error) {
+	files, err := fileutils.ListFiles(dirPath, false)
+	if err != nil {
+		return nil, err
+	}
+	var buildFiles []string
+	for _, file := range files {
+		dir, err := fileutils.IsDirExists(file, false)
+		if err != nil {
+			return nil, err
+		}
+		if dir || strings.HasPrefix(filepath.Base(file), buildTempFilePrefix) {
+			continue
+		}
+		buildFiles = append(buildFiles, file)
+	}
+	return buildFiles, nil
+}
+
+func marshalBuildData(data interface{}) ([]byte, error) {
+	b, err := json.Marshal(data)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
 	var content bytes.Buffer
 	err = json.Indent(&content, b, "", "  ")
 	if errorutils.CheckError(err) != nil {
-		return err
+		return nil, err
 	}
-	dirPath, err := getPartialsBuildDir(buildName, buildNumber)
+	return content.Bytes(), nil
+}
+
+// Saves a partial build-info. The caller is expected to hold the lock of the build.
+func saveBuildData(action interface{}, buildName, buildNumber string) error {
+	content, err := marshalBuildData(&action)
 	if err != nil {
 		return err
 	}
-	log.Debug("Creating temp build file at:", dirPath)
-	tempFile, err := ioutil.TempFile(dirPath, "temp")
+	dirPath, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
 	}
-	defer tempFile.Close()
-	_, err = tempFile.Write([]byte(content.String()))
-	return err
+	return createBuildFile(dirPath, content)
 }
 
 func SaveBuildInfo(buildName, buildNumber string, buildInfo *buildinfo.BuildInfo) error {
-	b, err := json.Marshal(buildInfo)
-	if errorutils.CheckError(err) != nil {
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Exclusive)
+	if err != nil {
 		return err
 	}
-	var content bytes.Buffer
-	err = json.Indent(&content, b, "", "  ")
-	if errorutils.CheckError(err) != nil {
+	defer buildLock.Unlock()
+	return saveBuildInfo(buildName, buildNumber, buildInfo)
+}
+
+func saveBuildInfo(buildName, buildNumber string, buildInfo *buildinfo.BuildInfo) error {
+	content, err := marshalBuildData(buildInfo)
+	if err != nil {
 		return err
 	}
 	dirPath, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
 	}
-	log.Debug("Creating temp build file at: " + dirPath)
-	tempFile, err := ioutil.TempFile(dirPath, "temp")
-	if errorutils.CheckError(err) != nil {
-		return err
-	}
-	defer tempFile.Close()
-	_, err = tempFile.Write([]byte(content.String()))
-	return errorutils.CheckError(err)
+	return createBuildFile(dirPath, content)
 }
 
 func SaveBuildGeneralDetails(buildName, buildNumber string) error {
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Exclusive)
+	if err != nil {
+		return err
+	}
+	defer buildLock.Unlock()
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
@@ -123,17 +208,20 @@ func SaveBuildGeneralDetails(buildName, buildNumber string) error {
 	meta := buildinfo.General{
 		Timestamp: time.Now(),
 	}
-	b, err := json.Marshal(&meta)
+	return saveBuildGeneralDetails(buildName, buildNumber, &meta)
+}
+
+// The caller is expected to hold the lock of the build.
+func saveBuildGeneralDetails(buildName, buildNumber string, meta *buildinfo.General) error {
+	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
-		return errorutils.CheckError(err)
+		return err
 	}
-	var content bytes.Buffer
-	err = json.Indent(&content, b, "", "  ")
+	content, err := marshalBuildData(meta)
 	if err != nil {
-		return errorutils.CheckError(err)
+		return err
 	}
-	err = ioutil.WriteFile(detailsFilePath, []byte(content.String()), 0600)
-	return errorutils.CheckError(err)
+	return replaceBuildFile(filepath.Join(partialsBuildDir, BuildInfoDetails), content)
 }
 
 type populatePartialBuildInfo func(partial *buildinfo.Partial)
@@ -142,70 +230,148 @@ func SavePartialBuildInfo(buildName, buildNumber string, populatePartialBuildInf
 	partialBuildInfo := new(buildinfo.Partial)
 	partialBuildInfo.Timestamp = time.Now().UnixNano() / int64(time.Millisecond)
 	populatePartialBuildInfoFunc(partialBuildInfo)
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Exclusive)
+	if err != nil {
+		return err
+	}
+	defer buildLock.Unlock()
 	return saveBuildData(partialBuildInfo, buildName, buildNumber)
 }
 
-func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildInfo, error) {
+// Properties of a build-info module, which are added to the module when the build-info is published.
+type ModuleProperties struct {
+	ModuleId   string              `json:"ModuleId,omitempty"`
+	Properties map[string][]string `json:"Properties,omitempty"`
+}
+
+func getModulePropertiesDir(buildName, buildNumber string) (string, error) {
 	buildDir, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	buildFiles, err := fileutils.ListFiles(buildDir, false)
+	propertiesDir := filepath.Join(buildDir, "properties")
+	return propertiesDir, errorutils.CheckError(os.MkdirAll(propertiesDir, 0777))
+}
+
+func SaveModuleProperties(buildName, buildNumber string, moduleProperties *ModuleProperties) error {
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Exclusive)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer buildLock.Unlock()
+	return saveModuleProperties(buildName, buildNumber, moduleProperties)
+}
 
-	var generatedBuildsInfo []*buildinfo.BuildInfo
-	for _, buildFile := range buildFiles {
-		dir, err := fileutils.IsDirExists(buildFile, false)
+// The caller is expected to hold the lock of the build.
+func saveModuleProperties(buildName, buildNumber string, moduleProperties *ModuleProperties) error {
+	content, err := json.MarshalIndent(moduleProperties, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	dirPath, err := getModulePropertiesDir(buildName, buildNumber)
+	if err != nil {
+		return err
+	}
+	return createBuildFile(dirPath, content)
+}
+
+// Returns the saved module properties, mapped by module ID. The properties of the same module are merged.
+func ReadModuleProperties(buildName, buildNumber string) (map[string]map[string][]string, error) {
+	modulesProperties, _, err := readModuleProperties(buildName, buildNumber)
+	return modulesProperties, err
+}
+
+// Returns the module properties and the files they were read from.
+func readModuleProperties(buildName, buildNumber string) (map[string]map[string][]string, []string, error) {
+	dirPath, err := getModulePropertiesDir(buildName, buildNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := listBuildFiles(dirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	modulesProperties := make(map[string]map[string][]string)
+	for _, file := range files {
+		content, err := fileutils.ReadFile(file)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		if dir {
-			continue
+		moduleProperties := new(ModuleProperties)
+		if err = json.Unmarshal(content, moduleProperties); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		if modulesProperties[moduleProperties.ModuleId] == nil {
+			modulesProperties[moduleProperties.ModuleId] = make(map[string][]string)
 		}
+		for key, values := range moduleProperties.Properties {
+			modulesProperties[moduleProperties.ModuleId][key] = values
+		}
+	}
+	return modulesProperties, files, nil
+}
+
+func GetGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildInfo, error) {
+	generatedBuildsInfo, _, err := readGeneratedBuildsInfo(buildName, buildNumber)
+	return generatedBuildsInfo, err
+}
+
+// Returns the generated build-info and the files they were read from.
+func readGeneratedBuildsInfo(buildName, buildNumber string) ([]*buildinfo.BuildInfo, []string, error) {
+	buildDir, err := GetBuildDir(buildName, buildNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	buildFiles, err := listBuildFiles(buildDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var generatedBuildsInfo []*buildinfo.BuildInfo
+	for _, buildFile := range buildFiles {
 		content, err := fileutils.ReadFile(buildFile)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		buildInfo := new(buildinfo.BuildInfo)
 		json.Unmarshal(content, &buildInfo)
 		generatedBuildsInfo = append(generatedBuildsInfo, buildInfo)
 	}
-	return generatedBuildsInfo, nil
+	return generatedBuildsInfo, buildFiles, nil
 }
 
 func ReadPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partials, error) {
+	partials, _, err := readPartialBuildInfoFiles(buildName, buildNumber)
+	return partials, err
+}
+
+// Returns the partials and the files they were read from.
+func readPartialBuildInfoFiles(buildName, buildNumber string) (buildinfo.Partials, []string, error) {
 	var partials buildinfo.Partials
 	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	buildFiles, err := fileutils.ListFiles(partialsBuildDir, false)
+	buildFiles, err := listBuildFiles(partialsBuildDir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	var partialFiles []string
 	for _, buildFile := range buildFiles {
-		dir, err := fileutils.IsDirExists(buildFile, false)
-		if err != nil {
-			return nil, err
-		}
-		if dir {
-			continue
-		}
 		if strings.HasSuffix(buildFile, BuildInfoDetails) {
 			continue
 		}
 		content, err := fileutils.ReadFile(buildFile)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		partial := new(buildinfo.Partial)
 		json.Unmarshal(content, &partial)
 		partials = append(partials, partial)
+		partialFiles = append(partialFiles, buildFile)
 	}
 
-	return partials, nil
+	return partials, partialFiles, nil
 }
 
 func ReadBuildInfoGeneralDetails(buildName, buildNumber string) (*buildinfo.General, error) {
@@ -223,7 +389,100 @@ func ReadBuildInfoGeneralDetails(buildName, buildNumber string) (*buildinfo.Gene
 	return details, nil
 }
 
+// The local data of a build, as it was when the snapshot was taken.
+type BuildSnapshot struct {
+	BuildName           string
+	BuildNumber         string
+	GeneralDetails      *buildinfo.General
+	Partials            buildinfo.Partials
+	ModulesProperties   map[string]map[string][]string
+	GeneratedBuildsInfo []*buildinfo.BuildInfo
+	// The files the snapshot was read from.
+	files []string
+}
+
+// Reads the local data of the build while holding a shared lock of the build.
+// Data which is saved after the snapshot is taken isn't part of it.
+func ReadBuildSnapshot(buildName, buildNumber string) (*BuildSnapshot, error) {
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Shared)
+	if err != nil {
+		return nil, err
+	}
+	defer buildLock.Unlock()
+	snapshot := &BuildSnapshot{BuildName: buildName, BuildNumber: buildNumber}
+	if snapshot.GeneralDetails, err = ReadBuildInfoGeneralDetails(buildName, buildNumber); err != nil {
+		return nil, err
+	}
+	partials, partialFiles, err := readPartialBuildInfoFiles(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	modulesProperties, propertiesFiles, err := readModuleProperties(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	generatedBuildsInfo, buildInfoFiles, err := readGeneratedBuildsInfo(buildName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Partials = partials
+	snapshot.ModulesProperties = modulesProperties
+	snapshot.GeneratedBuildsInfo = generatedBuildsInfo
+	snapshot.files = append(append(partialFiles, propertiesFiles...), buildInfoFiles...)
+	return snapshot, nil
+}
+
+// Removes the files the snapshot was read from.
+// Data which was saved after the snapshot was taken is kept, together with the general details of the build, so it can be published later.
+// If no such data exists, the build dir is removed.
+func (snapshot *BuildSnapshot) Remove() error {
+	buildLock, err := lockBuild(snapshot.BuildName, snapshot.BuildNumber, lock.Exclusive)
+	if err != nil {
+		return err
+	}
+	defer buildLock.Unlock()
+	for _, file := range snapshot.files {
+		if err = os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+
+	_, partialFiles, err := readPartialBuildInfoFiles(snapshot.BuildName, snapshot.BuildNumber)
+	if err != nil {
+		return err
+	}
+	_, propertiesFiles, err := readModuleProperties(snapshot.BuildName, snapshot.BuildNumber)
+	if err != nil {
+		return err
+	}
+	_, buildInfoFiles, err := readGeneratedBuildsInfo(snapshot.BuildName, snapshot.BuildNumber)
+	if err != nil {
+		return err
+	}
+	if remaining := len(partialFiles) + len(propertiesFiles) + len(buildInfoFiles); remaining > 0 {
+		log.Info(fmt.Sprintf("Keeping %d files of build %s/%s, which were saved while it was published.", remaining, snapshot.BuildName, snapshot.BuildNumber))
+		return nil
+	}
+	if err = removeBuildDir(snapshot.BuildName, snapshot.BuildNumber); err != nil {
+		return err
+	}
+	return buildLock.UnlockAndRemove()
+}
+
 func RemoveBuildDir(buildName, buildNumber string) error {
+	buildLock, err := lockBuild(buildName, buildNumber, lock.Exclusive)
+	if err != nil {
+		return err
+	}
+	defer buildLock.Unlock()
+	if err = removeBuildDir(buildName, buildNumber); err != nil {
+		return err
+	}
+	return buildLock.UnlockAndRemove()
+}
+
+// The caller is expected to hold the lock of the build.
+func removeBuildDir(buildName, buildNumber string) error {
 	tempDirPath, err := GetBuildDir(buildName, buildNumber)
 	if err != nil {
 		return err
@@ -238,6 +497,64 @@ func RemoveBuildDir(buildName, buildNumber string) error {
 	return nil
 }
 
+// Writes a complete build-info to a file, in the format accepted by Artifactory.
+func WriteBuildInfoFile(filePath string, buildInfo *buildinfo.BuildInfo) error {
+	content, err := json.MarshalIndent(buildInfo, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Writing the build-info to", filePath)
+	return errorutils.CheckError(ioutil.WriteFile(filePath, content, 0600))
+}
+
+// Reads a build-info file written by WriteBuildInfoFile.
+func ReadBuildInfoFile(filePath string) (*buildinfo.BuildInfo, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := new(buildinfo.BuildInfo)
+	if err = json.Unmarshal(content, buildInfo); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a valid build-info file: %s", filePath, err.Error()))
+	}
+	if buildInfo.Name == "" || buildInfo.Number == "" {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a valid build-info file: the build name and number are missing.", filePath))
+	}
+	return buildInfo, nil
+}
+
+// Returns the build-info of a published build.
+func GetBuildInfoFromArtifactory(artDetails *config.ArtifactoryDetails, buildName, buildNumber string) (*buildinfo.BuildInfo, error) {
+	servicesManager, err := CreateServiceManager(artDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	rtDetails := servicesManager.GetConfig().GetArtDetails()
+	restApi := path.Join("api/build", url.PathEscape(buildName), url.PathEscape(buildNumber))
+	httpClientsDetails := rtDetails.CreateHttpClientDetails()
+	log.Debug("Getting build-info from Artifactory:", buildName+"/"+buildNumber)
+	resp, body, _, err := servicesManager.Client().SendGet(rtDetails.GetUrl()+restApi, true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errorutils.CheckError(fmt.Errorf("Build %s/%s was not found in Artifactory.", buildName, buildNumber))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	response := struct {
+		BuildInfo *buildinfo.BuildInfo `json:"buildInfo"`
+	}{}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if response.BuildInfo == nil {
+		return nil, errorutils.CheckError(fmt.Errorf("Build %s/%s was not found in the Artifactory response: %s", buildName, buildNumber, clientutils.IndentJson(body)))
+	}
+	return response.BuildInfo, nil
+}
+
 type BuildInfoConfiguration struct {
 	artDetails auth.ArtifactoryDetails
 	DryRun     bool
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSavePartialBuildInfo(t *testing.T) {
+	log.SetDefaultLogger()
+	buildName, buildNumber := "build-concurrent-save-test", "1"
+	defer RemoveBuildDir(buildName, buildNumber)
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = SavePartialBuildInfo(buildName, buildNumber, func(partial *buildinfo.Partial) { partial.ModuleId = "module" })
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	partials, err := ReadPartialBuildInfoFiles(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partials) != len(errs) {
+		t.Errorf("Expected %d partials, got %d", len(errs), len(partials))
+	}
+}
+
+func TestBuildSnapshotRemove(t *testing.T) {
+	log.SetDefaultLogger()
+	buildName, buildNumber := "build-snapshot-test", "1"
+	defer RemoveBuildDir(buildName, buildNumber)
+	if err := SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	populateModule := func(partial *buildinfo.Partial) { partial.ModuleId = "module" }
+	if err := SavePartialBuildInfo(buildName, buildNumber, populateModule); err != nil {
+		t.Fatal(err)
+	}
+	// A file which is still being written isn't part of the snapshot.
+	partialsDir, err := getPartialsBuildDir(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(partialsDir, buildTempFilePrefix+"temp1"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := ReadBuildSnapshot(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Partials) != 1 {
+		t.Fatalf("Expected 1 partial in the snapshot, got %d", len(snapshot.Partials))
+	}
+
+	// A partial saved after the snapshot was taken is kept when the snapshot is removed.
+	if err = SavePartialBuildInfo(buildName, buildNumber, populateModule); err != nil {
+		t.Fatal(err)
+	}
+	if err = snapshot.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	partials, err := ReadPartialBuildInfoFiles(buildName, buildNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(partials) != 1 {
+		t.Errorf("Expected 1 partial to be kept, got %d", len(partials))
+	}
+	if _, err = ReadBuildInfoGeneralDetails(buildName, buildNumber); err != nil {
+		t.Error("Expected the general details to be kept:", err)
+	}
+
+	// Once the snapshot covers all the data, the build dir is removed.
+	if snapshot, err = ReadBuildSnapshot(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	if err = snapshot.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	buildDir := filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildTempPath, encodeBuildDirName(buildName, buildNumber))
+	exists, err := fileutils.IsDirExists(buildDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Error("Expected the build dir to be removed.")
+	}
+}
+
+func TestGetBuildInfoFromArtifactoryWithoutBuildInfo(t *testing.T) {
+	log.SetDefaultLogger()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"uri": "http://localhost/api/build/name/1"}`))
+	}))
+	defer ts.Close()
+	buildInfo, err := GetBuildInfoFromArtifactory(&config.ArtifactoryDetails{Url: ts.URL + "/"}, "name", "1")
+	if err == nil || buildInfo != nil {
+		t.Error("Expected an error for a response without a build-info, got:", buildInfo, err)
+	}
+}
+
+func TestBuildLockFile(t *testing.T) {
+	log.SetDefaultLogger()
+	buildName, buildNumber := "build/lock+test", "1"
+	lockFilePath := getBuildLockFilePath(buildName, buildNumber)
+	if filepath.Dir(lockFilePath) != filepath.Join(cliutils.GetCliPersistentTempDirPath(), BuildLocksTempPath) {
+		t.Errorf("Expected the lock file to be in the build locks dir, got %s", lockFilePath)
+	}
+	if err := SaveBuildGeneralDetails(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := fileutils.IsFileExists(lockFilePath, false); err != nil || !exists {
+		t.Fatalf("Expected the lock file %s to exist: %v", lockFilePath, err)
+	}
+	// The lock file is removed with the build dir.
+	if err := RemoveBuildDir(buildName, buildNumber); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := fileutils.IsFileExists(lockFilePath, false); err != nil || exists {
+		t.Errorf("Expected the lock file %s to be removed: %v", lockFilePath, err)
+	}
+}
@@ -0,0 +1,58 @@
+package dependencytree
+
+import "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+
+type dfsHelper struct {
+	visited  bool
+	notRoot  bool
+	circular bool
+}
+
+// Returns the dependencies which aren't required by any other dependency.
+// Dependencies which are part of a circle are returned as well, since the circle may not be required by any other dependency.
+func RootDependencies(allDependencies map[string]*buildinfo.Dependency, childrenMap map[string][]string) []string {
+	helper := map[string]*dfsHelper{}
+	for id := range allDependencies {
+		helper[id] = &dfsHelper{}
+	}
+
+	for id := range allDependencies {
+		if helper[id].visited {
+			continue
+		}
+		searchRootDependencies(helper, id, allDependencies, childrenMap, map[string]bool{id: true})
+	}
+	var rootDependencies []string
+	for id, nodeData := range helper {
+		if !nodeData.notRoot || nodeData.circular {
+			rootDependencies = append(rootDependencies, id)
+		}
+	}
+
+	return rootDependencies
+}
+
+func searchRootDependencies(dfsHelper map[string]*dfsHelper, currentId string, allDependencies map[string]*buildinfo.Dependency, childrenMap map[string][]string, traversePath map[string]bool) {
+	if dfsHelper[currentId].visited {
+		return
+	}
+	for _, next := range childrenMap[currentId] {
+		if _, ok := allDependencies[next]; !ok {
+			// No such dependency
+			continue
+		}
+		if traversePath[next] {
+			for circular := range traversePath {
+				dfsHelper[circular].circular = true
+			}
+			continue
+		}
+
+		// Not root dependency
+		dfsHelper[next].notRoot = true
+		traversePath[next] = true
+		searchRootDependencies(dfsHelper, next, allDependencies, childrenMap, traversePath)
+		delete(traversePath, next)
+	}
+	dfsHelper[currentId].visited = true
+}
@@ -0,0 +1,66 @@
+package dependencytree
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// Dependency tree
+type Tree interface {
+	MarshalJSON() ([]byte, error)
+}
+
+type root []*tree
+
+type tree struct {
+	Dependency         *buildinfo.Dependency `json:"dependencies,omitempty"`
+	DirectDependencies []*tree
+	id                 string
+}
+
+func (r root) MarshalJSON() ([]byte, error) {
+	type Alias root
+	return json.Marshal(Alias(r))
+}
+
+func (t tree) MarshalJSON() ([]byte, error) {
+	type Alias []*tree
+	return json.Marshal(&struct {
+		*buildinfo.Dependency
+		Alias `json:"dependencies,omitempty"`
+	}{
+		Dependency: t.Dependency,
+		Alias:      t.DirectDependencies,
+	})
+}
+
+// Create dependency tree using the root dependencies, all the dependencies by their ids, and the children ids of each dependency.
+func Create(rootDependencies []string, allDependencies map[string]*buildinfo.Dependency, childrenMap map[string][]string) Tree {
+	var rootTree root
+	for _, root := range rootDependencies {
+		if _, ok := allDependencies[root]; !ok {
+			//No such root, skip...
+			continue
+		}
+		subTree := &tree{id: root, Dependency: allDependencies[root]}
+		subTree.addChildren(allDependencies, childrenMap, map[string]bool{root: true})
+		rootTree = append(rootTree, subTree)
+	}
+	return rootTree
+}
+
+// Add children nodes for a dependency.
+// A child which is already in the path from the root is skipped, so that circular dependencies end the branch.
+func (t *tree) addChildren(allDependencies map[string]*buildinfo.Dependency, children map[string][]string, traversePath map[string]bool) {
+	for _, child := range children[t.id] {
+		if _, ok := allDependencies[child]; !ok || traversePath[child] {
+			//No such child or circular, skip...
+			continue
+		}
+		childTree := &tree{id: child, Dependency: allDependencies[child]}
+		traversePath[child] = true
+		childTree.addChildren(allDependencies, children, traversePath)
+		delete(traversePath, child)
+		t.DirectDependencies = append(t.DirectDependencies, childTree)
+	}
+}
@@ -0,0 +1,20 @@
+package dependencytree
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	childrenMap := map[string][]string{"a": {"b", "nonexisting"}, "b": {"c"}, "c": {"a"}, "d": {}}
+	tree := Create([]string{"a", "d", "nonexisting"}, getAllDependencies(childrenMap), childrenMap)
+	content, err := json.Marshal(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The circular dependency of c on a ends the branch.
+	expected := `[{"id":"a","dependencies":[{"id":"b","dependencies":[{"id":"c"}]}]},{"id":"d"}]`
+	if string(content) != expected {
+		t.Errorf("Expected: %s, Got: %s", expected, content)
+	}
+}
@@ -1,6 +1,8 @@
 package docker
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +24,10 @@ const (
 
 const ImageNotFoundErrorMessage string = "Could not find docker image in Artifactory, expecting image ID: %s"
 
+// Artifactory stores the manifest list of a multi-platform image in the tag folder,
+// and the image of each platform in a folder named after its manifest digest, next to the tag folder.
+const manifestListFile = "list.manifest.json"
+
 // Docker image build info builder
 type Builder interface {
 	Build(module string) (*buildinfo.BuildInfo, error)
@@ -52,6 +58,54 @@ type buildInfoBuilder struct {
 	artifacts    []buildinfo.Artifact
 	dependencies []buildinfo.Dependency
 	commandType  CommandType
+	// The digest of the manifest list, if the image is multi-platform.
+	manifestListDigest string
+	// The modules of the multi-platform image platforms.
+	platformModules []platformModule
+}
+
+// The artifacts and dependencies of a single platform of a multi-platform image.
+type platformModule struct {
+	platform       string
+	imageId        string
+	manifestDigest string
+	artifacts      []buildinfo.Artifact
+	dependencies   []buildinfo.Dependency
+}
+
+// Returns the dependencies of a base image, which was pulled from the resolver repository by docker build.
+// The base image is searched the same way a pulled image is, so it must exist in the local Docker daemon.
+func BaseImageDependencies(baseImageTag, resolverRepo string, serviceManager *artifactory.ArtifactoryServicesManager) ([]buildinfo.Dependency, error) {
+	imageId, err := New(baseImageTag).Id()
+	if err != nil {
+		return nil, err
+	}
+	if imageId == "" {
+		return nil, errorutils.CheckError(errors.New("Could not find the base image " + baseImageTag + " in the local Docker daemon."))
+	}
+	builder := &buildInfoBuilder{image: New(NormalizeImageTag(baseImageTag)), repository: resolverRepo, serviceManager: serviceManager, commandType: Pull, imageId: imageId}
+	if err = builder.updateArtifactsAndDependencies(); err != nil {
+		return nil, err
+	}
+	dependencies := builder.dependencies
+	for _, platformModule := range builder.platformModules {
+		dependencies = append(dependencies, platformModule.dependencies...)
+	}
+	return dependencies, nil
+}
+
+// Adds the implicit Docker Hub registry and 'library' namespace to image tags such as ubuntu:18.04, so that
+// their path can be resolved the same way as the path of images with a registry.
+func NormalizeImageTag(imageTag string) string {
+	indexOfFirstSlash := strings.Index(imageTag, "/")
+	if indexOfFirstSlash < 0 {
+		return "docker.io/library/" + imageTag
+	}
+	firstComponent := imageTag[:indexOfFirstSlash]
+	if !strings.ContainsAny(firstComponent, ".:") && firstComponent != "localhost" {
+		return "docker.io/" + imageTag
+	}
+	return imageTag
 }
 
 // Create build info for docker image
@@ -85,6 +139,9 @@ func (builder *buildInfoBuilder) updateArtifactsAndDependencies() error {
 	if err != nil {
 		return err
 	}
+	if listItem, ok := searchResults[manifestListFile]; ok {
+		return builder.handleManifestList(listItem)
+	}
 
 	manifest, manifestArtifact, manifestDependency, err := getManifest(builder.imageId, searchResults, builder.serviceManager)
 	if err != nil {
@@ -211,6 +268,13 @@ func (builder *buildInfoBuilder) setBuildProperties() (int, error) {
 
 // Create docker build info
 func (builder *buildInfoBuilder) createBuildInfo(module string) (*buildinfo.BuildInfo, error) {
+	if module == "" {
+		module = builder.image.Name()
+	}
+	if builder.manifestListDigest != "" {
+		return builder.createMultiPlatformBuildInfo(module), nil
+	}
+
 	imageProperties := map[string]string{}
 	imageProperties["docker.image.id"] = builder.imageId
 	imageProperties["docker.image.tag"] = builder.image.Tag()
@@ -223,9 +287,6 @@ func (builder *buildInfoBuilder) createBuildInfo(module string) (*buildinfo.Buil
 		imageProperties["docker.image.parent"] = parentId
 	}
 
-	if module == "" {
-		module = builder.image.Name()
-	}
 	buildInfo := &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
 		Id:           module,
 		Properties:   imageProperties,
@@ -235,6 +296,101 @@ func (builder *buildInfoBuilder) createBuildInfo(module string) (*buildinfo.Buil
 	return buildInfo, nil
 }
 
+// The build info of a multi-platform image has a module for the manifest list,
+// followed by a module for each platform, which is linked to the manifest list by its digest.
+// The image ID is platform specific, so it is set on the platform modules only.
+func (builder *buildInfoBuilder) createMultiPlatformBuildInfo(module string) *buildinfo.BuildInfo {
+	imageProperties := map[string]string{
+		"docker.image.tag":                  builder.image.Tag(),
+		"docker.image.manifest.list.digest": builder.manifestListDigest,
+	}
+	modules := []buildinfo.Module{{
+		Id:           module,
+		Properties:   imageProperties,
+		Artifacts:    builder.artifacts,
+		Dependencies: builder.dependencies,
+	}}
+	for _, platformModule := range builder.platformModules {
+		modules = append(modules, buildinfo.Module{
+			Id: module + "/" + platformModule.platform,
+			Properties: map[string]string{
+				"docker.image.id":                   platformModule.imageId,
+				"docker.image.tag":                  builder.image.Tag(),
+				"docker.image.platform":             platformModule.platform,
+				"docker.image.manifest.digest":      platformModule.manifestDigest,
+				"docker.image.manifest.list.digest": builder.manifestListDigest,
+			},
+			Artifacts:    platformModule.artifacts,
+			Dependencies: platformModule.dependencies,
+		})
+	}
+	return &buildinfo.BuildInfo{Modules: modules}
+}
+
+// Read the manifest list of a multi-platform image and collect the image of each platform.
+// When pushing, all the platforms are collected. When pulling, only the platform of the pulled image is collected.
+func (builder *buildInfoBuilder) handleManifestList(listItem utils.ResultItem) error {
+	content, err := readRemoteFile(listItem, builder.serviceManager)
+	if err != nil {
+		return err
+	}
+	manifestList := new(imageIndex)
+	if err = json.Unmarshal(content, manifestList); err != nil {
+		return errorutils.CheckError(err)
+	}
+	hash := sha256.Sum256(content)
+	builder.manifestListDigest = "sha256:" + hex.EncodeToString(hash[:])
+	checksum := &buildinfo.Checksum{Sha1: listItem.Actual_Sha1, Md5: listItem.Actual_Md5}
+	if builder.commandType == Push {
+		builder.artifacts = append(builder.artifacts, buildinfo.Artifact{Name: manifestListFile, Checksum: checksum})
+		builder.layers = append(builder.layers, listItem)
+	} else {
+		builder.dependencies = append(builder.dependencies, buildinfo.Dependency{Id: manifestListFile, Checksum: checksum})
+	}
+
+	imagesPath := path.Join(listItem.Repo, path.Dir(listItem.Path))
+	for _, manifestDesc := range manifestList.Manifests {
+		searchResults, err := searchPlatformImage(imagesPath, manifestDesc.Digest, builder.serviceManager)
+		if err != nil {
+			return err
+		}
+		platformBuilder := &buildInfoBuilder{image: builder.image, serviceManager: builder.serviceManager, commandType: builder.commandType}
+		manifest, manifestArtifact, manifestDependency, err := getManifest("", searchResults, builder.serviceManager)
+		if err != nil {
+			return err
+		}
+		platformBuilder.imageId = manifest.Config.Digest
+		// Docker pulls a single platform of the image, so the other platforms are not dependencies.
+		if builder.commandType == Pull && platformBuilder.imageId != builder.imageId {
+			continue
+		}
+		configLayer, configLayerArtifact, configLayerDependency, err := getConfigLayer(platformBuilder.imageId, searchResults, builder.serviceManager)
+		if err != nil {
+			return err
+		}
+		if builder.commandType == Push {
+			err = platformBuilder.handlePush(manifestArtifact, configLayerArtifact, manifest, configLayer, searchResults)
+		} else {
+			err = platformBuilder.handlePull(manifestDependency, configLayerDependency, manifest, searchResults)
+		}
+		if err != nil {
+			return err
+		}
+		builder.layers = append(builder.layers, platformBuilder.layers...)
+		builder.platformModules = append(builder.platformModules, platformModule{
+			platform:       manifestDesc.Platform.String(),
+			imageId:        platformBuilder.imageId,
+			manifestDigest: manifestDesc.Digest,
+			artifacts:      platformBuilder.artifacts,
+			dependencies:   platformBuilder.dependencies,
+		})
+	}
+	if builder.commandType == Pull && len(builder.platformModules) == 0 {
+		return errorutils.CheckError(errors.New(fmt.Sprintf(ImageNotFoundErrorMessage, builder.imageId)))
+	}
+	return nil
+}
+
 // Download and read the manifest from Artifactory.
 // Returned values:
 // imageManifest - pointer to the manifest struct, retrieved from Artifactory.
@@ -242,12 +398,7 @@ func (builder *buildInfoBuilder) createBuildInfo(module string) (*buildinfo.Buil
 // dependency - manifest as buildinfo.Dependency object.
 func getManifest(imageId string, searchResults map[string]utils.ResultItem, serviceManager *artifactory.ArtifactoryServicesManager) (imageManifest *manifest, artifact buildinfo.Artifact, dependency buildinfo.Dependency, err error) {
 	item := searchResults["manifest.json"]
-	ioReaderCloser, err := serviceManager.ReadRemoteFile(item.GetItemRelativePath())
-	if err != nil {
-		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, err
-	}
-	defer ioReaderCloser.Close()
-	content, err := ioutil.ReadAll(ioReaderCloser)
+	content, err := readRemoteFile(item, serviceManager)
 	if err != nil {
 		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, err
 	}
@@ -258,8 +409,8 @@ func getManifest(imageId string, searchResults map[string]utils.ResultItem, serv
 		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, err
 	}
 
-	// Check that the manifest ID is the right one
-	if imageManifest.Config.Digest != imageId {
+	// Check that the manifest ID is the right one. The image ID of a multi-platform image is read from the manifest, so it isn't checked.
+	if imageId != "" && imageManifest.Config.Digest != imageId {
 		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, errorutils.CheckError(errors.New("Found incorrect manifest.json file, expecting image ID: " + imageId))
 	}
 
@@ -274,13 +425,11 @@ func getManifest(imageId string, searchResults map[string]utils.ResultItem, serv
 // artifact - configuration layer as buildinfo.Artifact object.
 // dependency - configuration layer as buildinfo.Dependency object.
 func getConfigLayer(imageId string, searchResults map[string]utils.ResultItem, serviceManager *artifactory.ArtifactoryServicesManager) (configurationLayer *configLayer, artifact buildinfo.Artifact, dependency buildinfo.Dependency, err error) {
-	item := searchResults[digestToLayer(imageId)]
-	ioReaderCloser, err := serviceManager.ReadRemoteFile(item.GetItemRelativePath())
-	if err != nil {
-		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, err
+	item, ok := searchResults[digestToLayer(imageId)]
+	if !ok {
+		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, errorutils.CheckError(errors.New(fmt.Sprintf(ImageNotFoundErrorMessage, imageId)))
 	}
-	defer ioReaderCloser.Close()
-	content, err := ioutil.ReadAll(ioReaderCloser)
+	content, err := readRemoteFile(item, serviceManager)
 	if err != nil {
 		return nil, buildinfo.Artifact{}, buildinfo.Dependency{}, err
 	}
@@ -298,9 +447,39 @@ func getConfigLayer(imageId string, searchResults map[string]utils.ResultItem, s
 
 // Search for image layers in Artifactory
 func searchImageLayers(imageId, imagePathPattern string, serviceManager *artifactory.ArtifactoryServicesManager) (map[string]utils.ResultItem, error) {
+	resultMap, err := searchFiles(imagePathPattern, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate image ID layer exists, or that the image is multi-platform.
+	_, imageIdExists := resultMap[digestToLayer(imageId)]
+	_, manifestListExists := resultMap[manifestListFile]
+	if !imageIdExists && !manifestListExists {
+		return nil, nil
+	}
+	return resultMap, nil
+}
+
+// Search for the image of a single platform of a multi-platform image.
+// The image folder is named after the manifest digest, with or without the colon replaced.
+func searchPlatformImage(imagesPath, manifestDigest string, serviceManager *artifactory.ArtifactoryServicesManager) (map[string]utils.ResultItem, error) {
+	for _, folder := range []string{manifestDigest, digestToLayer(manifestDigest)} {
+		resultMap, err := searchFiles(path.Join(imagesPath, folder, "*"), serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := resultMap["manifest.json"]; ok {
+			return resultMap, nil
+		}
+	}
+	return nil, errorutils.CheckError(errors.New("Could not find the image of manifest " + manifestDigest + " in Artifactory, under " + imagesPath))
+}
+
+func searchFiles(pattern string, serviceManager *artifactory.ArtifactoryServicesManager) (map[string]utils.ResultItem, error) {
 	searchParams := services.NewSearchParams()
 	searchParams.ArtifactoryCommonParams = &utils.ArtifactoryCommonParams{}
-	searchParams.Pattern = imagePathPattern
+	searchParams.Pattern = pattern
 	results, err := serviceManager.SearchFiles(searchParams)
 	if err != nil {
 		return nil, err
@@ -309,12 +488,17 @@ func searchImageLayers(imageId, imagePathPattern string, serviceManager *artifac
 	for _, v := range results {
 		resultMap[v.Name] = v
 	}
+	return resultMap, nil
+}
 
-	// Validate image ID layer exists.
-	if _, ok := resultMap[digestToLayer(imageId)]; !ok {
-		return nil, nil
+func readRemoteFile(item utils.ResultItem, serviceManager *artifactory.ArtifactoryServicesManager) ([]byte, error) {
+	ioReaderCloser, err := serviceManager.ReadRemoteFile(item.GetItemRelativePath())
+	if err != nil {
+		return nil, err
 	}
-	return resultMap, nil
+	defer ioReaderCloser.Close()
+	content, err := ioutil.ReadAll(ioReaderCloser)
+	return content, errorutils.CheckError(err)
 }
 
 // Digest of type sha256:30daa5c11544632449b01f450bebfef6b89644e9e683258ed05797abe7c32a6e to
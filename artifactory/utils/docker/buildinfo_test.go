@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"testing"
+)
+
+func TestCreateMultiPlatformBuildInfo(t *testing.T) {
+	builder := &buildInfoBuilder{
+		image:              New("domain/docker-local/app:1.0"),
+		imageId:            "sha256:amd64config",
+		artifacts:          []buildinfo.Artifact{{Name: manifestListFile}},
+		manifestListDigest: "sha256:list",
+		platformModules: []platformModule{
+			{platform: "linux/amd64", imageId: "sha256:amd64config", manifestDigest: "sha256:amd64", artifacts: []buildinfo.Artifact{{Name: "manifest.json"}}},
+			{platform: "linux/arm64/v8", imageId: "sha256:arm64config", manifestDigest: "sha256:arm64", artifacts: []buildinfo.Artifact{{Name: "manifest.json"}}},
+		},
+	}
+	buildInfo, err := builder.createBuildInfo("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buildInfo.Modules) != 3 {
+		t.Fatalf("Expected a module for the manifest list and for each platform, got %d modules", len(buildInfo.Modules))
+	}
+	listModule := buildInfo.Modules[0]
+	listProperties := listModule.Properties.(map[string]string)
+	if listModule.Id != "app:1.0" || listProperties["docker.image.manifest.list.digest"] != "sha256:list" || listProperties["docker.image.id"] != "" {
+		t.Errorf("Unexpected manifest list module: %v", listModule)
+	}
+	armModule := buildInfo.Modules[2]
+	armProperties := armModule.Properties.(map[string]string)
+	if armModule.Id != "app:1.0/linux/arm64/v8" || armProperties["docker.image.id"] != "sha256:arm64config" ||
+		armProperties["docker.image.manifest.digest"] != "sha256:arm64" || armProperties["docker.image.manifest.list.digest"] != "sha256:list" {
+		t.Errorf("Unexpected platform module: %v", armModule)
+	}
+}
+
+func TestPlatformString(t *testing.T) {
+	tests := []struct {
+		platform *platform
+		expected string
+	}{
+		{&platform{Os: "linux", Architecture: "amd64"}, "linux/amd64"},
+		{&platform{Os: "linux", Architecture: "arm64", Variant: "v8"}, "linux/arm64/v8"},
+		{nil, "unknown"},
+	}
+	for _, test := range tests {
+		if result := test.platform.String(); result != test.expected {
+			t.Errorf("String() => '%s', want '%s'", result, test.expected)
+		}
+	}
+}
@@ -219,6 +219,40 @@ func (loginCmd *LoginCmd) GetErrWriter() io.WriteCloser {
 	return nil
 }
 
+// Image build command
+type BuildCmd struct {
+	ImageTag       string
+	DockerfilePath string
+	ContextPath    string
+	// Additional docker build options, such as --build-arg.
+	BuildArgs []string
+}
+
+func (buildCmd *BuildCmd) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, "docker")
+	cmd = append(cmd, "build")
+	cmd = append(cmd, "--tag", buildCmd.ImageTag)
+	if buildCmd.DockerfilePath != "" {
+		cmd = append(cmd, "--file", buildCmd.DockerfilePath)
+	}
+	cmd = append(cmd, buildCmd.BuildArgs...)
+	cmd = append(cmd, buildCmd.ContextPath)
+	return exec.Command(cmd[0], cmd[1:]...)
+}
+
+func (buildCmd *BuildCmd) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (buildCmd *BuildCmd) GetStdWriter() io.WriteCloser {
+	return nil
+}
+
+func (buildCmd *BuildCmd) GetErrWriter() io.WriteCloser {
+	return nil
+}
+
 // Image pull command
 type pullCmd struct {
 	image *image
@@ -276,16 +310,20 @@ func DockerLogin(imageTag string, config *DockerLoginConfig) error {
 		return err
 	}
 
-	username := config.ArtifactoryDetails.User
-	password := config.ArtifactoryDetails.Password
+	artDetails, err := config.ArtifactoryDetails.ResolveCredentials()
+	if err != nil {
+		return err
+	}
+	username := artDetails.User
+	password := artDetails.Password
 	// If access-token exists, perform login with it.
-	if config.ArtifactoryDetails.AccessToken != "" {
+	if artDetails.AccessToken != "" {
 		log.Debug("Using access-token details in docker-login command.")
-		username, err = auth.ExtractUsernameFromAccessToken(config.ArtifactoryDetails.AccessToken)
+		username, err = auth.ExtractUsernameFromAccessToken(artDetails.AccessToken)
 		if err != nil {
 			return err
 		}
-		password = config.ArtifactoryDetails.AccessToken
+		password = artDetails.AccessToken
 	}
 
 	// Perform login.
@@ -303,7 +341,7 @@ func DockerLogin(imageTag string, config *DockerLoginConfig) error {
 		return errorutils.CheckError(errors.New(fmt.Sprintf(DockerLoginFailureMessage, imageRegistry)))
 	}
 
-	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: config.ArtifactoryDetails.User, Password: config.ArtifactoryDetails.Password}
+	cmd = &LoginCmd{DockerRegistry: imageRegistry[:indexOfSlash], Username: username, Password: password}
 	err = gofrogcmd.RunCmd(cmd)
 	if err != nil {
 		// Login failed for both attempts
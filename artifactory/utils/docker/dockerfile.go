@@ -0,0 +1,124 @@
+package docker
+
+import (
+	"bufio"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var dockerfileVarRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// Returns the base images of a Dockerfile, as they appear in its FROM instructions.
+// In multi-stage builds, FROM instructions which refer to previous stages and 'scratch' are skipped.
+// Variables in FROM instructions are replaced by the build args, or by the defaults of the ARG instructions which precede the first FROM.
+func ParseDockerfileBaseImages(dockerfilePath string, buildArgs map[string]string) ([]string, error) {
+	file, err := os.Open(dockerfilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	instructions, err := readDockerfileInstructions(file)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]string)
+	stages := make(map[string]bool)
+	seenFrom := false
+	var baseImages []string
+	for _, instruction := range instructions {
+		fields := strings.Fields(instruction)
+		switch strings.ToUpper(fields[0]) {
+		case "ARG":
+			// Only the ARG instructions before the first FROM apply to FROM instructions.
+			if seenFrom || len(fields) < 2 {
+				continue
+			}
+			name, value := fields[1], ""
+			if index := strings.Index(name, "="); index >= 0 {
+				name, value = name[:index], strings.Trim(name[index+1:], `"'`)
+			}
+			if buildArg, ok := buildArgs[name]; ok {
+				value = buildArg
+			}
+			args[name] = value
+		case "FROM":
+			seenFrom = true
+			fields = fields[1:]
+			// Skip flags such as --platform.
+			for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			baseImage := expandDockerfileVars(fields[0], args)
+			if len(fields) >= 3 && strings.ToUpper(fields[1]) == "AS" {
+				stages[strings.ToLower(fields[2])] = true
+			}
+			if strings.Contains(baseImage, "$") {
+				log.Warn("Skipping the base image " + fields[0] + ", since it refers to an undefined build arg.")
+				continue
+			}
+			if baseImage == "scratch" || stages[strings.ToLower(baseImage)] && !isSameStage(fields, baseImage) || contains(baseImages, baseImage) {
+				continue
+			}
+			baseImages = append(baseImages, baseImage)
+		}
+	}
+	return baseImages, nil
+}
+
+// Returns the Dockerfile instructions, after joining continuation lines and removing comments and empty lines.
+func readDockerfileInstructions(file *os.File) ([]string, error) {
+	var instructions []string
+	current := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+		instructions = append(instructions, current+line)
+		current = ""
+	}
+	if strings.TrimSpace(current) != "" {
+		instructions = append(instructions, current)
+	}
+	return instructions, errorutils.CheckError(scanner.Err())
+}
+
+func expandDockerfileVars(value string, args map[string]string) string {
+	return dockerfileVarRegexp.ReplaceAllStringFunc(value, func(match string) string {
+		groups := dockerfileVarRegexp.FindStringSubmatch(match)
+		name := groups[1] + groups[4]
+		if argValue, ok := args[name]; ok && argValue != "" {
+			return argValue
+		}
+		if groups[2] != "" {
+			return groups[3]
+		}
+		return match
+	})
+}
+
+// A stage named like its own base image, as in 'FROM node AS node', refers to the image rather than to itself.
+func isSameStage(fromFields []string, baseImage string) bool {
+	return len(fromFields) >= 3 && strings.EqualFold(fromFields[2], baseImage)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
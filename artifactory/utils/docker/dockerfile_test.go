@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDockerfileBaseImages(t *testing.T) {
+	log.SetDefaultLogger()
+	tests := []struct {
+		name       string
+		dockerfile string
+		buildArgs  map[string]string
+		expected   []string
+	}{
+		{"single", "FROM ubuntu:18.04\nRUN echo hello", nil, []string{"ubuntu:18.04"}},
+		{"multiStage", "FROM golang:1.12 AS builder\nRUN go build\nFROM alpine:3.9\nCOPY --from=builder /app /app", nil, []string{"golang:1.12", "alpine:3.9"}},
+		{"stageReference", "FROM node:10 as base\nFROM base AS test\nFROM base", nil, []string{"node:10"}},
+		{"scratch", "FROM scratch\nADD app /", nil, nil},
+		{"platformAndComments", "# syntax comment\nFROM --platform=linux/amd64 \\\n  domain/docker-remote/ubuntu:18.04", nil, []string{"domain/docker-remote/ubuntu:18.04"}},
+		{"argDefault", "ARG VERSION=3.9\nFROM alpine:${VERSION}", nil, []string{"alpine:3.9"}},
+		{"buildArg", "ARG VERSION=3.9\nFROM alpine:$VERSION", map[string]string{"VERSION": "3.10"}, []string{"alpine:3.10"}},
+		{"undefinedArg", "FROM alpine:${VERSION}", nil, nil},
+		{"duplicate", "FROM alpine:3.9 AS a\nFROM alpine:3.9 AS b", nil, []string{"alpine:3.9"}},
+	}
+	tempDir, err := ioutil.TempDir("", "dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dockerfilePath := filepath.Join(tempDir, test.name)
+			if err := ioutil.WriteFile(dockerfilePath, []byte(test.dockerfile), 0644); err != nil {
+				t.Fatal(err)
+			}
+			baseImages, err := ParseDockerfileBaseImages(dockerfilePath, test.buildArgs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(baseImages, test.expected) {
+				t.Errorf("Expected base images %v, got %v", test.expected, baseImages)
+			}
+		})
+	}
+}
+
+func TestNormalizeImageTag(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"ubuntu:18.04", "docker.io/library/ubuntu:18.04"},
+		{"jfrog/app:1.0", "docker.io/jfrog/app:1.0"},
+		{"artifactory.example.com/docker-remote/ubuntu:18.04", "artifactory.example.com/docker-remote/ubuntu:18.04"},
+		{"domain:8080/ubuntu:18.04", "domain:8080/ubuntu:18.04"},
+		{"localhost/ubuntu", "localhost/ubuntu"},
+	}
+	for _, test := range tests {
+		if result := NormalizeImageTag(test.in); result != test.expected {
+			t.Errorf("NormalizeImageTag(\"%s\") => '%s', want '%s'", test.in, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package docker
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	ociLayoutFile      = "oci-layout"
+	ociIndexFile       = "index.json"
+	ociRefNameKey      = "org.opencontainers.image.ref.name"
+	ociBaseNameKey     = "org.opencontainers.image.base.name"
+	dockerManifestFile = "manifest.json"
+)
+
+// A descriptor of a blob, as it appears in OCI indexes and image manifests.
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *platform         `json:"platform,omitempty"`
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// Returns the platform in the os/architecture[/variant] form, e.g. linux/arm64/v8
+func (p *platform) String() string {
+	if p == nil {
+		return "unknown"
+	}
+	platformName := p.Os + "/" + p.Architecture
+	if p.Variant != "" {
+		platformName += "/" + p.Variant
+	}
+	return platformName
+}
+
+// An OCI image manifest or a Docker image manifest V2 schema 2.
+type imageManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	Config        descriptor        `json:"config"`
+	Layers        []descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// An OCI image index or a Docker manifest list.
+type imageIndex struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Manifests     []descriptor `json:"manifests"`
+}
+
+// An entry of the manifest.json file of a 'docker save' tarball.
+type dockerSaveManifest struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+// An image in the OCI image layout format, see https://github.com/opencontainers/image-spec/blob/master/image-layout.md
+type ociLayout struct {
+	dir string
+}
+
+func isOciLayout(dir string) (bool, error) {
+	return fileutils.IsFileExists(filepath.Join(dir, ociLayoutFile), false)
+}
+
+func (layout *ociLayout) blobPath(digest string) string {
+	return filepath.Join(layout.dir, "blobs", strings.Replace(digest, ":", string(filepath.Separator), 1))
+}
+
+func (layout *ociLayout) readBlob(digest string) ([]byte, error) {
+	content, err := ioutil.ReadFile(layout.blobPath(digest))
+	return content, errorutils.CheckError(err)
+}
+
+func (layout *ociLayout) readIndex() (*imageIndex, error) {
+	content, err := ioutil.ReadFile(filepath.Join(layout.dir, ociIndexFile))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	index := new(imageIndex)
+	return index, errorutils.CheckError(json.Unmarshal(content, index))
+}
+
+// Returns the descriptor of the image in the layout, which is either an image manifest or an image index.
+// If the layout holds several images, the one annotated with the tag is chosen.
+func (layout *ociLayout) getRootDescriptor(tag string) (*descriptor, error) {
+	index, err := layout.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	if len(index.Manifests) == 0 {
+		return nil, errorutils.CheckError(errors.New("The OCI image layout at " + layout.dir + " contains no images."))
+	}
+	desc := index.Manifests[0]
+	for _, manifestDesc := range index.Manifests {
+		if refName := manifestDesc.Annotations[ociRefNameKey]; refName != "" && (refName == tag || strings.HasSuffix(tag, ":"+refName)) {
+			desc = manifestDesc
+			break
+		}
+	}
+	return &desc, nil
+}
+
+// Returns the image manifest of the layout, with its descriptor and raw content.
+// If the image is multi-platform, the manifest matching the current platform is chosen.
+func (layout *ociLayout) getImageManifest(tag string) (*descriptor, []byte, *imageManifest, error) {
+	desc, err := layout.getRootDescriptor(tag)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for isIndexMediaType(desc.MediaType) {
+		nestedIndex, _, err := layout.readImageIndex(desc.Digest)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		platformDesc := selectPlatformManifest(nestedIndex.Manifests)
+		desc = &platformDesc
+	}
+	content, manifest, err := layout.readImageManifest(desc.Digest)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if desc.MediaType == "" {
+		desc.MediaType = manifest.MediaType
+	}
+	return desc, content, manifest, nil
+}
+
+func (layout *ociLayout) readImageManifest(digest string) ([]byte, *imageManifest, error) {
+	content, err := layout.readBlob(digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := new(imageManifest)
+	return content, manifest, errorutils.CheckError(json.Unmarshal(content, manifest))
+}
+
+func (layout *ociLayout) readImageIndex(digest string) (*imageIndex, []byte, error) {
+	content, err := layout.readBlob(digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := new(imageIndex)
+	if err = json.Unmarshal(content, index); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	if len(index.Manifests) == 0 {
+		return nil, nil, errorutils.CheckError(errors.New("The image index " + digest + " contains no images."))
+	}
+	return index, content, nil
+}
+
+// Adds a blob to the layout and returns its descriptor. The content is read to its end.
+func (layout *ociLayout) writeBlob(mediaType string, content io.Reader) (*descriptor, error) {
+	blobsDir := filepath.Join(layout.dir, "blobs", "sha256")
+	if err := os.MkdirAll(blobsDir, 0755); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	tempFile, err := ioutil.TempFile(blobsDir, "blob")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), content)
+	tempFile.Close()
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, errorutils.CheckError(err)
+	}
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	if err = os.Rename(tempFile.Name(), layout.blobPath(digest)); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &descriptor{MediaType: mediaType, Digest: digest, Size: size}, nil
+}
+
+// Writes the index.json and oci-layout files, referencing a single image with the tag as its name.
+func (layout *ociLayout) writeIndex(manifestDesc descriptor, tag string) error {
+	manifestDesc.Annotations = map[string]string{ociRefNameKey: tag}
+	index := imageIndex{SchemaVersion: 2, Manifests: []descriptor{manifestDesc}}
+	content, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(layout.dir, ociIndexFile), content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(layout.dir, ociLayoutFile), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644))
+}
+
+func isIndexMediaType(mediaType string) bool {
+	return mediaType == MediaTypeOciIndex || mediaType == MediaTypeDockerManifestList
+}
+
+// Returns the manifest matching the current platform, or the first manifest if none matches.
+func selectPlatformManifest(manifests []descriptor) descriptor {
+	for _, manifestDesc := range manifests {
+		if manifestDesc.Platform != nil && manifestDesc.Platform.Os == "linux" && manifestDesc.Platform.Architecture == runtime.GOARCH {
+			return manifestDesc
+		}
+	}
+	return manifests[0]
+}
+
+// Returns an OCI layout of the image source, which is either an OCI layout dir or a 'docker save' tarball.
+// A tarball is extracted to a temp dir, which is returned as the second value and should be removed by the caller.
+func openImageSource(source string) (*ociLayout, string, error) {
+	isDir, err := fileutils.IsDirExists(source, false)
+	if err != nil {
+		return nil, "", err
+	}
+	if isDir {
+		isLayout, err := isOciLayout(source)
+		if err != nil {
+			return nil, "", err
+		}
+		if !isLayout {
+			return nil, "", errorutils.CheckError(errors.New(source + " is not an OCI image layout - the oci-layout file is missing."))
+		}
+		return &ociLayout{dir: source}, "", nil
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, "", err
+	}
+	layout, err := readDockerSaveTarball(source, tempDir)
+	if err != nil {
+		fileutils.RemoveTempDir(tempDir)
+		return nil, "", err
+	}
+	return layout, tempDir, nil
+}
+
+// Extracts a 'docker save' tarball into the dir. Recent Docker versions save images as OCI layouts.
+// Older versions save the uncompressed layers with a manifest.json file, which are converted here to an OCI layout.
+func readDockerSaveTarball(tarballPath, dir string) (*ociLayout, error) {
+	extractedDir := filepath.Join(dir, "extracted")
+	if err := extractTar(tarballPath, extractedDir); err != nil {
+		return nil, err
+	}
+	isLayout, err := isOciLayout(extractedDir)
+	if err != nil || isLayout {
+		return &ociLayout{dir: extractedDir}, err
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(extractedDir, dockerManifestFile))
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not a 'docker save' tarball: %s", tarballPath, err.Error()))
+	}
+	var saveManifests []dockerSaveManifest
+	if err = json.Unmarshal(content, &saveManifests); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(saveManifests) != 1 {
+		return nil, errorutils.CheckError(fmt.Errorf("%s must contain exactly one image, but it contains %d.", tarballPath, len(saveManifests)))
+	}
+	saveManifest := saveManifests[0]
+
+	layout := &ociLayout{dir: filepath.Join(dir, "layout")}
+	configDesc, err := writeFileBlob(layout, MediaTypeDockerConfig, filepath.Join(extractedDir, saveManifest.Config), false)
+	if err != nil {
+		return nil, err
+	}
+	manifest := imageManifest{SchemaVersion: 2, MediaType: MediaTypeDockerManifest, Config: *configDesc}
+	for _, layerPath := range saveManifest.Layers {
+		layerDesc, err := writeFileBlob(layout, MediaTypeDockerLayer, filepath.Join(extractedDir, layerPath), true)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Layers = append(manifest.Layers, *layerDesc)
+	}
+	manifestContent, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	manifestDesc, err := layout.writeBlob(MediaTypeDockerManifest, strings.NewReader(string(manifestContent)))
+	if err != nil {
+		return nil, err
+	}
+	tag := ""
+	if len(saveManifest.RepoTags) > 0 {
+		tag = saveManifest.RepoTags[0]
+	}
+	return layout, layout.writeIndex(*manifestDesc, tag)
+}
+
+// Adds a file to the layout as a blob, optionally compressing it with gzip.
+func writeFileBlob(layout *ociLayout, mediaType, path string, compress bool) (*descriptor, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	if !compress {
+		return layout.writeBlob(mediaType, file)
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		gzipWriter := gzip.NewWriter(writer)
+		_, err := io.Copy(gzipWriter, file)
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+	return layout.writeBlob(mediaType, reader)
+}
+
+func extractTar(tarballPath, destDir string) error {
+	file, err := os.Open(tarballPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	tarReader := tar.NewReader(file)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(fmt.Errorf("Failed reading %s: %s", tarballPath, err.Error()))
+		}
+		target := filepath.Join(destDir, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(filepath.Separator)) {
+			return errorutils.CheckError(fmt.Errorf("%s contains an illegal path: %s", tarballPath, header.Name))
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+			outFile, err := os.Create(target)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			_, err = io.Copy(outFile, tarReader)
+			outFile.Close()
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+		case tar.TypeSymlink:
+			// Older Docker versions link identical layers to each other.
+			linkTarget := filepath.Join(filepath.Dir(target), header.Linkname)
+			if !strings.HasPrefix(linkTarget, filepath.Clean(destDir)+string(filepath.Separator)) {
+				return errorutils.CheckError(fmt.Errorf("%s contains an illegal link: %s", tarballPath, header.Name))
+			}
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+			if err = os.Symlink(header.Linkname, target); err != nil {
+				return errorutils.CheckError(err)
+			}
+		}
+	}
+}
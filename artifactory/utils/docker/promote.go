@@ -0,0 +1,154 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// The body of the Artifactory promote docker image REST API.
+type PromoteParams struct {
+	TargetRepo             string `json:"targetRepo"`
+	DockerRepository       string `json:"dockerRepository"`
+	TargetDockerRepository string `json:"targetDockerRepository,omitempty"`
+	Tag                    string `json:"tag,omitempty"`
+	TargetTag              string `json:"targetTag,omitempty"`
+	Copy                   bool   `json:"copy"`
+}
+
+// A docker image stored in an Artifactory repository.
+type RepoImage struct {
+	// The image config digest, or empty for a multi-platform image.
+	ImageId string
+	// The manifest, config, layers and manifest list files of the image.
+	Files []utils.ResultItem
+}
+
+// Splits an image name such as hello-world:1.0 into the name and the tag. The tag defaults to latest.
+func SplitImageTag(image string) (name, tag string) {
+	indexOfLastColon := strings.LastIndex(image, ":")
+	if indexOfLastColon < 0 || indexOfLastColon < strings.LastIndex(image, "/") {
+		return image, "latest"
+	}
+	return image[:indexOfLastColon], image[indexOfLastColon+1:]
+}
+
+// Returns the files of an image stored in a repository, after validating that its manifest and all its layers exist.
+func GetRepoImage(repo, imageName, tag string, serviceManager *artifactory.ArtifactoryServicesManager) (*RepoImage, error) {
+	tagPath := path.Join(repo, imageName, tag)
+	searchResults, err := searchFiles(path.Join(tagPath, "*"), serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	listItem, isManifestList := searchResults[manifestListFile]
+	if !isManifestList {
+		return getRepoImageFiles(tagPath, searchResults, serviceManager)
+	}
+
+	content, err := readRemoteFile(listItem, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	manifestList := new(imageIndex)
+	if err = json.Unmarshal(content, manifestList); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	image := &RepoImage{Files: []utils.ResultItem{listItem}}
+	for _, manifestDesc := range manifestList.Manifests {
+		platformResults, err := searchPlatformImage(path.Join(repo, imageName), manifestDesc.Digest, serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		platformImage, err := getRepoImageFiles(path.Join(repo, imageName, manifestDesc.Digest), platformResults, serviceManager)
+		if err != nil {
+			return nil, err
+		}
+		image.Files = append(image.Files, platformImage.Files...)
+	}
+	return image, nil
+}
+
+func getRepoImageFiles(imagePath string, searchResults map[string]utils.ResultItem, serviceManager *artifactory.ArtifactoryServicesManager) (*RepoImage, error) {
+	manifestItem, ok := searchResults["manifest.json"]
+	if !ok {
+		return nil, errorutils.CheckError(errors.New("Could not find the image manifest in Artifactory, under " + imagePath))
+	}
+	content, err := readRemoteFile(manifestItem, serviceManager)
+	if err != nil {
+		return nil, err
+	}
+	imageManifest := new(manifest)
+	if err = json.Unmarshal(content, imageManifest); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	image := &RepoImage{ImageId: imageManifest.Config.Digest, Files: []utils.ResultItem{manifestItem}}
+	var missingLayers []string
+	layerNames := []string{digestToLayer(imageManifest.Config.Digest)}
+	for _, layer := range imageManifest.Layers {
+		layerNames = append(layerNames, digestToLayer(layer.Digest))
+	}
+	for _, layerName := range layerNames {
+		item, ok := searchResults[layerName]
+		if !ok {
+			missingLayers = append(missingLayers, layerName)
+			continue
+		}
+		image.Files = append(image.Files, item)
+	}
+	if len(missingLayers) > 0 {
+		return nil, errorutils.CheckError(fmt.Errorf("The following layers of the image under %s are missing in Artifactory:\n%s", imagePath, strings.Join(missingLayers, "\n")))
+	}
+	return image, nil
+}
+
+// Promotes a docker image from the source repo, using the Artifactory promote docker image REST API.
+func PromoteImage(sourceRepo string, params PromoteParams, serviceManager *artifactory.ArtifactoryServicesManager) error {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	rtDetails := serviceManager.GetConfig().GetArtDetails()
+	httpClientsDetails := rtDetails.CreateHttpClientDetails()
+	utils.SetContentType("application/json", &httpClientsDetails.Headers)
+	restApi := path.Join("api/docker", sourceRepo, "v2/promote")
+	log.Debug("Sending docker promote request to:", restApi)
+	resp, body, err := serviceManager.Client().SendPost(rtDetails.GetUrl()+restApi, content, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	return nil
+}
+
+// Creates the build info of a promoted image. The promoted files are identical to the source files, so their checksums are taken from the source.
+func CreatePromotionBuildInfo(module, sourceRepo, sourceImage, targetRepo, targetImage string, image *RepoImage) *buildinfo.BuildInfo {
+	imageProperties := map[string]string{
+		"docker.image.tag":           targetImage,
+		"docker.image.promoted.from": sourceRepo + "/" + sourceImage,
+		"docker.image.promoted.to":   targetRepo + "/" + targetImage,
+	}
+	if image.ImageId != "" {
+		imageProperties["docker.image.id"] = image.ImageId
+	}
+	var artifacts []buildinfo.Artifact
+	for _, item := range image.Files {
+		artifacts = append(artifacts, item.ToArtifact())
+	}
+	return &buildinfo.BuildInfo{Modules: []buildinfo.Module{{
+		Id:         module,
+		Properties: imageProperties,
+		Artifacts:  artifacts,
+	}}}
+}
@@ -0,0 +1,71 @@
+package docker
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSplitImageTag(t *testing.T) {
+	tests := []struct {
+		image string
+		name  string
+		tag   string
+	}{
+		{"hello-world:1.0", "hello-world", "1.0"},
+		{"hello-world", "hello-world", "latest"},
+		{"org/hello-world:1.0", "org/hello-world", "1.0"},
+		{"org/hello-world", "org/hello-world", "latest"},
+	}
+	for _, test := range tests {
+		name, tag := SplitImageTag(test.image)
+		if name != test.name || tag != test.tag {
+			t.Errorf("SplitImageTag(\"%s\") => '%s', '%s', want '%s', '%s'", test.image, name, tag, test.name, test.tag)
+		}
+	}
+}
+
+func TestPromoteImage(t *testing.T) {
+	log.SetDefaultLogger()
+	var requestPath string
+	var params PromoteParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		content, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(content, &params)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serviceManager, err := CreateServiceManager(&config.ArtifactoryDetails{Url: server.URL + "/"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := PromoteParams{TargetRepo: "docker-prod", DockerRepository: "app", TargetDockerRepository: "app", Tag: "1.0", TargetTag: "1.0-release", Copy: true}
+	if err = PromoteImage("docker-dev", expected, serviceManager); err != nil {
+		t.Fatal(err)
+	}
+	if requestPath != "/api/docker/docker-dev/v2/promote" {
+		t.Errorf("Unexpected request path: %s", requestPath)
+	}
+	if params != expected {
+		t.Errorf("Expected promotion params %v, got %v", expected, params)
+	}
+}
+
+func TestCreatePromotionBuildInfo(t *testing.T) {
+	image := &RepoImage{ImageId: "sha256:config", Files: []utils.ResultItem{{Name: "manifest.json", Actual_Sha1: "1"}, {Name: "sha256__config", Actual_Sha1: "2"}}}
+	buildInfo := CreatePromotionBuildInfo("app:1.0-release", "docker-dev", "app:1.0", "docker-prod", "app:1.0-release", image)
+	if len(buildInfo.Modules) != 1 || len(buildInfo.Modules[0].Artifacts) != 2 {
+		t.Fatalf("Expected a single module with 2 artifacts, got %v", buildInfo.Modules)
+	}
+	properties := buildInfo.Modules[0].Properties.(map[string]string)
+	if properties["docker.image.id"] != "sha256:config" || properties["docker.image.promoted.from"] != "docker-dev/app:1.0" || properties["docker.image.promoted.to"] != "docker-prod/app:1.0-release" {
+		t.Errorf("Unexpected module properties: %v", properties)
+	}
+}
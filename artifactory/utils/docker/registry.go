@@ -0,0 +1,329 @@
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/auth"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerConfig       = "application/vnd.docker.container.image.v1+json"
+	MediaTypeDockerLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	MediaTypeOciManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOciIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+// The manifest media types accepted when pulling an image.
+var acceptedManifestTypes = []string{MediaTypeOciManifest, MediaTypeDockerManifest, MediaTypeOciIndex, MediaTypeDockerManifestList}
+
+// A client of the Docker registry HTTP API V2, used to push and pull images without a Docker daemon.
+type RegistryClient struct {
+	client *http.Client
+	// The registry base URL, e.g. https://artifactory.example.com
+	registryUrl string
+	// The image name in the registry, e.g. docker-local/hello-world
+	name     string
+	username string
+	password string
+	// The bearer token received from the registry token service, if the registry requires one.
+	token         string
+	authenticated bool
+}
+
+// Splits an image tag into the registry host, the image name in the registry and the tag or digest reference.
+// For example, artifactory.example.com/docker-local/hello-world:1.0 is split into
+// artifactory.example.com, docker-local/hello-world and 1.0.
+func parseImageReference(imageTag string) (host, name, reference string, err error) {
+	if _, err = ResolveRegistryFromTag(imageTag); err != nil {
+		return
+	}
+	indexOfFirstSlash := strings.Index(imageTag, "/")
+	host, name, reference = imageTag[:indexOfFirstSlash], imageTag[indexOfFirstSlash+1:], "latest"
+	if index := strings.Index(name, "@"); index >= 0 {
+		return host, name[:index], name[index+1:], nil
+	}
+	if index := strings.LastIndex(name, ":"); index >= 0 && !strings.Contains(name[index:], "/") {
+		name, reference = name[:index], name[index+1:]
+	}
+	return
+}
+
+func NewRegistryClient(imageTag string, rtDetails *config.ArtifactoryDetails, insecureRegistry bool) (*RegistryClient, error) {
+	host, name, _, err := parseImageReference(imageTag)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "https://"
+	if insecureRegistry {
+		scheme = "http://"
+	}
+
+	certPath, err := utils.GetJfrogSecurityDir()
+	if err != nil {
+		return nil, err
+	}
+	client, err := httpclient.ClientBuilder().SetCertificatesPath(certPath).SetInsecureTls(rtDetails.InsecureTls).Build()
+	if err != nil {
+		return nil, err
+	}
+	if rtDetails, err = rtDetails.ResolveCredentials(); err != nil {
+		return nil, err
+	}
+	username, password := rtDetails.User, rtDetails.Password
+	// The access token is used as the password, the same way DockerLogin uses it.
+	if rtDetails.AccessToken != "" {
+		if username, err = auth.ExtractUsernameFromAccessToken(rtDetails.AccessToken); err != nil {
+			return nil, err
+		}
+		password = rtDetails.AccessToken
+	}
+	return &RegistryClient{client: client.Client, registryUrl: scheme + host, name: name, username: username, password: password}, nil
+}
+
+// Returns true if the blob exists in the image repository.
+func (rc *RegistryClient) BlobExists(digest string) (bool, error) {
+	resp, err := rc.send("HEAD", rc.url("blobs", digest), nil, -1, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// Tries to mount a blob from another image of the same registry, to avoid uploading it.
+// Returns false if the registry did not mount the blob.
+func (rc *RegistryClient) MountBlob(digest, fromName string) (bool, error) {
+	mountUrl := rc.url("blobs", "uploads/") + "?mount=" + url.QueryEscape(digest) + "&from=" + url.QueryEscape(fromName)
+	resp, err := rc.send("POST", mountUrl, nil, 0, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated {
+		return true, nil
+	}
+	// The registry started a regular upload session instead. It is abandoned, since a new session is started by UploadBlob.
+	if location := resp.Header.Get("Location"); location != "" && resp.StatusCode == http.StatusAccepted {
+		if cancelResp, err := rc.send("DELETE", rc.resolveLocation(location), nil, -1, nil); err == nil {
+			cancelResp.Body.Close()
+		}
+	}
+	return false, nil
+}
+
+// Uploads a blob in a single request, after starting an upload session.
+func (rc *RegistryClient) UploadBlob(digest string, content io.Reader, size int64) error {
+	resp, err := rc.send("POST", rc.url("blobs", "uploads/"), nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return errorutils.CheckError(fmt.Errorf("Failed starting the upload of %s. Registry response: %s", digest, resp.Status))
+	}
+	uploadUrl := rc.resolveLocation(resp.Header.Get("Location"))
+	separator := "?"
+	if strings.Contains(uploadUrl, "?") {
+		separator = "&"
+	}
+	headers := map[string]string{"Content-Type": "application/octet-stream"}
+	resp, err = rc.send("PUT", uploadUrl+separator+"digest="+url.QueryEscape(digest), content, size, headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return rc.responseError("Failed uploading "+digest, resp)
+	}
+	return nil
+}
+
+// Returns the content of a blob. The caller is responsible for closing it.
+func (rc *RegistryClient) GetBlob(digest string) (io.ReadCloser, error) {
+	resp, err := rc.send("GET", rc.url("blobs", digest), nil, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, rc.responseError("Failed downloading "+digest, resp)
+	}
+	return resp.Body, nil
+}
+
+func (rc *RegistryClient) PutManifest(reference, mediaType string, content []byte) error {
+	resp, err := rc.send("PUT", rc.url("manifests", reference), strings.NewReader(string(content)), int64(len(content)), map[string]string{"Content-Type": mediaType})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return rc.responseError("Failed uploading the manifest of "+rc.name+":"+reference, resp)
+	}
+	return nil
+}
+
+// Returns the manifest content and its media type.
+func (rc *RegistryClient) GetManifest(reference string) ([]byte, string, error) {
+	resp, err := rc.send("GET", rc.url("manifests", reference), nil, -1, map[string]string{"Accept": strings.Join(acceptedManifestTypes, ", ")})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", rc.responseError("Failed downloading the manifest of "+rc.name+":"+reference, resp)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", errorutils.CheckError(err)
+	}
+	mediaType := resp.Header.Get("Content-Type")
+	if index := strings.Index(mediaType, ";"); index >= 0 {
+		mediaType = mediaType[:index]
+	}
+	return content, strings.TrimSpace(mediaType), nil
+}
+
+// Returns the image name in the registry.
+func (rc *RegistryClient) Name() string {
+	return rc.name
+}
+
+// Returns the registry host, e.g. artifactory.example.com
+func (rc *RegistryClient) Host() string {
+	return rc.registryUrl[strings.Index(rc.registryUrl, "://")+3:]
+}
+
+func (rc *RegistryClient) url(kind, reference string) string {
+	return rc.registryUrl + "/v2/" + rc.name + "/" + kind + "/" + reference
+}
+
+// The Location header of an upload session may be relative to the registry URL.
+func (rc *RegistryClient) resolveLocation(location string) string {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return location
+	}
+	return rc.registryUrl + "/" + strings.TrimPrefix(location, "/")
+}
+
+// Sends a request to the registry. If the registry requires a bearer token, the token is requested and the request is resent.
+// Requests with a body can't be resent, so a token is requested in advance by sending a body-less request first.
+func (rc *RegistryClient) send(method, requestUrl string, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	if body != nil && !rc.authenticated {
+		if err := rc.authenticate(); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := rc.doSend(method, requestUrl, body, size, headers)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || body != nil {
+		return resp, err
+	}
+	resp.Body.Close()
+	if err = rc.requestToken(resp.Header.Get("WWW-Authenticate")); err != nil {
+		return nil, err
+	}
+	return rc.doSend(method, requestUrl, nil, size, headers)
+}
+
+func (rc *RegistryClient) doSend(method, requestUrl string, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, requestUrl, body)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if rc.token != "" {
+		req.Header.Set("Authorization", "Bearer "+rc.token)
+	} else if rc.username != "" {
+		req.SetBasicAuth(rc.username, rc.password)
+	}
+	log.Debug("Sending", method, "request to:", requestUrl)
+	resp, err := rc.client.Do(req)
+	return resp, errorutils.CheckError(err)
+}
+
+// Checks whether the registry requires a bearer token, and requests one if it does.
+func (rc *RegistryClient) authenticate() error {
+	resp, err := rc.doSend("GET", rc.registryUrl+"/v2/", nil, -1, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		rc.authenticated = true
+		return nil
+	}
+	return rc.requestToken(resp.Header.Get("WWW-Authenticate"))
+}
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Requests a bearer token from the token service, according to the WWW-Authenticate challenge of the registry.
+func (rc *RegistryClient) requestToken(challenge string) error {
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return errorutils.CheckError(errors.New("The registry rejected the provided credentials."))
+	}
+	params := make(map[string]string)
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+	if params["realm"] == "" {
+		return errorutils.CheckError(errors.New("The registry returned an authentication challenge without a realm: " + challenge))
+	}
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	query.Set("scope", "repository:"+rc.name+":pull,push")
+	req, err := http.NewRequest("GET", params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if rc.username != "" {
+		req.SetBasicAuth(rc.username, rc.password)
+	}
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return rc.responseError("Failed getting a token from the registry", resp)
+	}
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return errorutils.CheckError(err)
+	}
+	rc.token = tokenResponse.Token
+	if rc.token == "" {
+		rc.token = tokenResponse.AccessToken
+	}
+	rc.authenticated = true
+	return nil
+}
+
+func (rc *RegistryClient) responseError(message string, resp *http.Response) error {
+	body, _ := ioutil.ReadAll(resp.Body)
+	return errorutils.CheckError(fmt.Errorf("%s. Registry response: %s\n%s", message, resp.Status, string(body)))
+}
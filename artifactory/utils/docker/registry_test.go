@@ -0,0 +1,343 @@
+package docker
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// A minimal Docker registry, which requires a bearer token and stores blobs and manifests in memory.
+type fakeRegistry struct {
+	mutex     sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	mounts    int
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+}
+
+func (fr *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fr.mutex.Lock()
+	defer fr.mutex.Unlock()
+	if r.URL.Path == "/token" {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"token":"test-token"}`))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v2/docker-local/app/")
+	switch {
+	case r.URL.Path == "/v2/":
+		w.WriteHeader(http.StatusOK)
+	case strings.HasPrefix(path, "blobs/uploads/"):
+		if r.Method == "POST" && r.URL.Query().Get("mount") != "" {
+			if _, ok := fr.blobs[r.URL.Query().Get("mount")]; ok {
+				fr.mounts++
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		if r.Method == "POST" {
+			w.Header().Set("Location", "/v2/docker-local/app/blobs/uploads/session")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		content, _ := ioutil.ReadAll(r.Body)
+		fr.blobs[r.URL.Query().Get("digest")] = content
+		fr.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "blobs/"):
+		content, ok := fr.blobs[strings.TrimPrefix(path, "blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case strings.HasPrefix(path, "manifests/"):
+		reference := strings.TrimPrefix(path, "manifests/")
+		if r.Method == "PUT" {
+			content, _ := ioutil.ReadAll(r.Body)
+			fr.manifests[reference] = content
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		content, ok := fr.manifests[reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", MediaTypeOciManifest)
+		w.Write(content)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		imageTag  string
+		host      string
+		name      string
+		reference string
+	}{
+		{"domain:8080/path:1.0", "domain:8080", "path", "1.0"},
+		{"domain:8080/path/in/artifactory", "domain:8080", "path/in/artifactory", "latest"},
+		{"domain/path/in/artifactory:1.0", "domain", "path/in/artifactory", "1.0"},
+		{"domain/path@sha256:abc", "domain", "path", "sha256:abc"},
+	}
+	for _, test := range tests {
+		host, name, reference, err := parseImageReference(test.imageTag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if host != test.host || name != test.name || reference != test.reference {
+			t.Errorf("parseImageReference(\"%s\") => '%s', '%s', '%s', want '%s', '%s', '%s'", test.imageTag, host, name, reference, test.host, test.name, test.reference)
+		}
+	}
+}
+
+func TestRegistryImagePushAndPull(t *testing.T) {
+	log.SetDefaultLogger()
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	rtDetails := &config.ArtifactoryDetails{User: "admin", Password: "password"}
+	imageTag := strings.TrimPrefix(server.URL, "http://") + "/docker-local/app:1.0"
+
+	sourceDir, err := ioutil.TempDir("", "oci-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sourceDir)
+	configDigest := createTestLayout(t, sourceDir, "1.0")
+
+	image := NewRegistryImage(imageTag, sourceDir, rtDetails, true)
+	if err = image.Push(); err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := image.Id(); id != configDigest {
+		t.Errorf("Expected image ID %s, got %s", configDigest, id)
+	}
+	if registry.uploads != 2 || registry.manifests["1.0"] == nil {
+		t.Errorf("Expected 2 uploaded blobs and a manifest, got %d blobs and manifests %v", registry.uploads, registry.manifests)
+	}
+	// Pushing again doesn't upload existing blobs.
+	if err = image.Push(); err != nil {
+		t.Fatal(err)
+	}
+	if registry.uploads != 2 {
+		t.Errorf("Expected existing blobs to be skipped, but %d blobs were uploaded", registry.uploads)
+	}
+
+	targetDir, err := ioutil.TempDir("", "oci-target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(targetDir)
+	pulledImage := NewRegistryImage(imageTag, targetDir, rtDetails, true)
+	if err = pulledImage.Pull(); err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := pulledImage.Id(); id != configDigest {
+		t.Errorf("Expected pulled image ID %s, got %s", configDigest, id)
+	}
+	_, content, _, err := (&ociLayout{dir: targetDir}).getImageManifest("1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != string(registry.manifests["1.0"]) {
+		t.Errorf("The pulled manifest differs from the pushed one:\n%s\n%s", content, registry.manifests["1.0"])
+	}
+}
+
+func TestRegistryImagePushIndex(t *testing.T) {
+	log.SetDefaultLogger()
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	imageTag := strings.TrimPrefix(server.URL, "http://") + "/docker-local/app:1.0"
+
+	sourceDir, err := ioutil.TempDir("", "oci-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sourceDir)
+	layout := &ociLayout{dir: sourceDir}
+	var manifests []descriptor
+	for _, arch := range []string{"amd64", "arm64"} {
+		configDesc, err := layout.writeBlob("application/vnd.oci.image.config.v1+json", strings.NewReader(`{"architecture":"`+arch+`"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := json.Marshal(imageManifest{SchemaVersion: 2, MediaType: MediaTypeOciManifest, Config: *configDesc})
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifestDesc, err := layout.writeBlob(MediaTypeOciManifest, strings.NewReader(string(content)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifestDesc.Platform = &platform{Os: "linux", Architecture: arch}
+		manifests = append(manifests, *manifestDesc)
+	}
+	content, err := json.Marshal(imageIndex{SchemaVersion: 2, MediaType: MediaTypeOciIndex, Manifests: manifests})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDesc, err := layout.writeBlob(MediaTypeOciIndex, strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = layout.writeIndex(*indexDesc, "1.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = NewRegistryImage(imageTag, sourceDir, &config.ArtifactoryDetails{User: "admin", Password: "password"}, true).Push(); err != nil {
+		t.Fatal(err)
+	}
+	if string(registry.manifests["1.0"]) != string(content) {
+		t.Errorf("Expected the tag to reference the image index, got %s", registry.manifests["1.0"])
+	}
+	for _, manifestDesc := range manifests {
+		if registry.manifests[manifestDesc.Digest] == nil {
+			t.Errorf("The manifest of %s was not pushed", manifestDesc.Platform)
+		}
+	}
+}
+
+func TestRegistryImagePushWithCredentialsHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test credentials helper is a shell script.")
+	}
+	log.SetDefaultLogger()
+	registry := newFakeRegistry()
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	imageTag := strings.TrimPrefix(server.URL, "http://") + "/docker-local/app:1.0"
+
+	sourceDir, err := ioutil.TempDir("", "oci-helper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(sourceDir)
+	createTestLayout(t, sourceDir, "1.0")
+	helper := filepath.Join(sourceDir, "helper.sh")
+	if err = ioutil.WriteFile(helper, []byte("#!/bin/sh\necho '{\"user\": \"admin\", \"password\": \"password\"}'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = NewRegistryImage(imageTag, sourceDir, &config.ArtifactoryDetails{CredentialsHelper: helper}, true).Push(); err != nil {
+		t.Fatal(err)
+	}
+	if registry.manifests["1.0"] == nil {
+		t.Error("Expected the image to be pushed with the credentials of the helper")
+	}
+}
+
+func TestReadDockerSaveTarball(t *testing.T) {
+	log.SetDefaultLogger()
+	tempDir, err := ioutil.TempDir("", "docker-save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	configContent := `{"architecture":"amd64","os":"linux"}`
+	tarballPath := filepath.Join(tempDir, "image.tar")
+	writeTestTar(t, tarballPath, map[string]string{
+		"config.json":      configContent,
+		"layer1/layer.tar": "layer content",
+		"manifest.json":    `[{"Config":"config.json","RepoTags":["docker-local/app:1.0"],"Layers":["layer1/layer.tar"]}]`,
+	})
+
+	layout, layoutTempDir, err := openImageSource(tarballPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(layoutTempDir)
+	desc, _, manifest, err := layout.getImageManifest("docker-local/app:1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.MediaType != MediaTypeDockerManifest || manifest.Config.Digest != sha256Digest(configContent) {
+		t.Errorf("Unexpected manifest %s with config %s", desc.MediaType, manifest.Config.Digest)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].MediaType != MediaTypeDockerLayer {
+		t.Fatalf("Expected a single compressed layer, got %v", manifest.Layers)
+	}
+	if _, err = os.Stat(layout.blobPath(manifest.Layers[0].Digest)); err != nil {
+		t.Error(err)
+	}
+}
+
+// Creates an OCI layout with a single layer image, and returns the image config digest.
+func createTestLayout(t *testing.T, dir, tag string) string {
+	layout := &ociLayout{dir: dir}
+	configDesc, err := layout.writeBlob("application/vnd.oci.image.config.v1+json", strings.NewReader(`{"os":"linux"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	layerDesc, err := layout.writeBlob("application/vnd.oci.image.layer.v1.tar+gzip", strings.NewReader("layer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(imageManifest{SchemaVersion: 2, MediaType: MediaTypeOciManifest, Config: *configDesc, Layers: []descriptor{*layerDesc}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDesc, err := layout.writeBlob(MediaTypeOciManifest, strings.NewReader(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = layout.writeIndex(*manifestDesc, tag); err != nil {
+		t.Fatal(err)
+	}
+	return configDesc.Digest
+}
+
+func writeTestTar(t *testing.T, path string, files map[string]string) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	tarWriter := tar.NewWriter(file)
+	for name, content := range files {
+		if err = tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func sha256Digest(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,247 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+)
+
+// Creates a Docker image, which is pushed and pulled through the Docker registry API, without a Docker daemon.
+// When pushing, the layoutPath is an OCI image layout dir or a 'docker save' tarball.
+// When pulling, the image is written to the layoutPath as an OCI image layout.
+func NewRegistryImage(imageTag, layoutPath string, rtDetails *config.ArtifactoryDetails, insecureRegistry bool) Image {
+	return &registryImage{image: image{tag: imageTag}, layoutPath: layoutPath, rtDetails: rtDetails, insecureRegistry: insecureRegistry}
+}
+
+// Internal implementation of a daemonless docker image.
+// Tag, Path and Name are shared with the docker image.
+type registryImage struct {
+	image
+	layoutPath       string
+	rtDetails        *config.ArtifactoryDetails
+	insecureRegistry bool
+	// The digest of the image config, which Docker uses as the image ID.
+	imageId string
+}
+
+// Push the image blobs and manifest to the registry.
+// Blobs which already exist in the repository are skipped, and blobs of the base image are mounted rather than uploaded.
+// A multi-platform image is pushed with the images of all its platforms, and its index is tagged.
+func (ri *registryImage) Push() error {
+	layout, tempDir, err := openImageSource(ri.layoutPath)
+	if tempDir != "" {
+		defer fileutils.RemoveTempDir(tempDir)
+	}
+	if err != nil {
+		return err
+	}
+	rootDesc, err := layout.getRootDescriptor(ri.tag)
+	if err != nil {
+		return err
+	}
+	client, err := NewRegistryClient(ri.tag, ri.rtDetails, ri.insecureRegistry)
+	if err != nil {
+		return err
+	}
+	_, _, reference, err := parseImageReference(ri.tag)
+	if err != nil {
+		return err
+	}
+	if !isIndexMediaType(rootDesc.MediaType) {
+		manifest, err := pushManifest(client, layout, *rootDesc, reference)
+		if err != nil {
+			return err
+		}
+		ri.imageId = manifest.Config.Digest
+		return nil
+	}
+
+	index, indexContent, err := layout.readImageIndex(rootDesc.Digest)
+	if err != nil {
+		return err
+	}
+	for _, manifestDesc := range index.Manifests {
+		if isIndexMediaType(manifestDesc.MediaType) {
+			return errorutils.CheckError(errors.New("Nested image indexes are not supported. Found in " + rootDesc.Digest))
+		}
+		manifest, err := pushManifest(client, layout, manifestDesc, manifestDesc.Digest)
+		if err != nil {
+			return err
+		}
+		if manifestDesc.Digest == selectPlatformManifest(index.Manifests).Digest {
+			ri.imageId = manifest.Config.Digest
+		}
+	}
+	log.Info(fmt.Sprintf("Pushing the image index of %s (%s)", ri.tag, rootDesc.Digest))
+	return client.PutManifest(reference, rootDesc.MediaType, indexContent)
+}
+
+// Pull the image from the registry into a local OCI image layout.
+// Blobs which already exist in the layout are not downloaded again.
+func (ri *registryImage) Pull() error {
+	client, err := NewRegistryClient(ri.tag, ri.rtDetails, ri.insecureRegistry)
+	if err != nil {
+		return err
+	}
+	_, _, reference, err := parseImageReference(ri.tag)
+	if err != nil {
+		return err
+	}
+	manifestContent, mediaType, err := client.GetManifest(reference)
+	if err != nil {
+		return err
+	}
+	if isIndexMediaType(mediaType) {
+		index := new(imageIndex)
+		if err = json.Unmarshal(manifestContent, index); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if len(index.Manifests) == 0 {
+			return errorutils.CheckError(errors.New("The image index of " + ri.tag + " contains no images."))
+		}
+		if manifestContent, mediaType, err = client.GetManifest(selectPlatformManifest(index.Manifests).Digest); err != nil {
+			return err
+		}
+	}
+	manifest := new(imageManifest)
+	if err = json.Unmarshal(manifestContent, manifest); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	layout := &ociLayout{dir: ri.layoutPath}
+	for _, blob := range append([]descriptor{manifest.Config}, manifest.Layers...) {
+		if err = pullBlob(client, layout, blob); err != nil {
+			return err
+		}
+	}
+	manifestDesc, err := layout.writeBlob(mediaType, bytes.NewReader(manifestContent))
+	if err != nil {
+		return err
+	}
+	if err = layout.writeIndex(*manifestDesc, reference); err != nil {
+		return err
+	}
+	ri.imageId = manifest.Config.Digest
+	return nil
+}
+
+// Get the image ID, which is the digest of the image config.
+func (ri *registryImage) Id() (string, error) {
+	if ri.imageId != "" {
+		return ri.imageId, nil
+	}
+	layout, tempDir, err := openImageSource(ri.layoutPath)
+	if tempDir != "" {
+		defer fileutils.RemoveTempDir(tempDir)
+	}
+	if err != nil {
+		return "", err
+	}
+	_, _, manifest, err := layout.getImageManifest(ri.tag)
+	if err != nil {
+		return "", err
+	}
+	ri.imageId = manifest.Config.Digest
+	return ri.imageId, nil
+}
+
+// The parent image ID is known only to the Docker daemon which built the image.
+func (ri *registryImage) ParentId() (string, error) {
+	return "", nil
+}
+
+// Push the blobs of an image manifest, and then the manifest itself under the reference.
+func pushManifest(client *RegistryClient, layout *ociLayout, manifestDesc descriptor, reference string) (*imageManifest, error) {
+	content, manifest, err := layout.readImageManifest(manifestDesc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	mediaType := manifestDesc.MediaType
+	if mediaType == "" {
+		mediaType = manifest.MediaType
+	}
+	if mediaType == "" {
+		mediaType = MediaTypeOciManifest
+	}
+	mountFrom := getBaseImageName(manifest, client.Host())
+	for _, blob := range append([]descriptor{manifest.Config}, manifest.Layers...) {
+		if err = pushBlob(client, layout, blob, mountFrom); err != nil {
+			return nil, err
+		}
+	}
+	log.Info(fmt.Sprintf("Pushing the manifest %s", manifestDesc.Digest))
+	return manifest, client.PutManifest(reference, mediaType, content)
+}
+
+func pushBlob(client *RegistryClient, layout *ociLayout, blob descriptor, mountFrom string) error {
+	exists, err := client.BlobExists(blob.Digest)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Debug("Blob", blob.Digest, "already exists in the registry.")
+		return nil
+	}
+	if mountFrom != "" {
+		mounted, err := client.MountBlob(blob.Digest, mountFrom)
+		if err != nil {
+			return err
+		}
+		if mounted {
+			log.Debug("Blob", blob.Digest, "was mounted from", mountFrom+".")
+			return nil
+		}
+	}
+	file, err := os.Open(layout.blobPath(blob.Digest))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	log.Info("Uploading blob", blob.Digest)
+	return client.UploadBlob(blob.Digest, file, blob.Size)
+}
+
+func pullBlob(client *RegistryClient, layout *ociLayout, blob descriptor) error {
+	exists, err := fileutils.IsFileExists(layout.blobPath(blob.Digest), false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Debug("Blob", blob.Digest, "already exists in the layout.")
+		return nil
+	}
+	log.Info("Downloading blob", blob.Digest)
+	content, err := client.GetBlob(blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	written, err := layout.writeBlob(blob.MediaType, content)
+	if err != nil {
+		return err
+	}
+	if written.Digest != blob.Digest {
+		os.Remove(layout.blobPath(written.Digest))
+		return errorutils.CheckError(fmt.Errorf("The digest of the downloaded blob %s does not match its content: %s", blob.Digest, written.Digest))
+	}
+	return nil
+}
+
+// Returns the name of the base image, if the manifest annotates it and it is stored in the same registry.
+func getBaseImageName(manifest *imageManifest, registryHost string) string {
+	baseName := manifest.Annotations[ociBaseNameKey]
+	if baseName == "" {
+		return ""
+	}
+	host, name, _, err := parseImageReference(baseName)
+	if err != nil || host != registryHost {
+		return ""
+	}
+	return name
+}
@@ -35,4 +35,5 @@ type DownloadConfiguration struct {
 	Symlink         bool
 	ValidateSymlink bool
 	Retries         int
+	Resume          bool
 }
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const tagsRefPrefix = "refs/tags/"
+
+// Returns the names of the tags of the repository, from both the loose refs and the packed-refs file.
+func (m *manager) ListTags() ([]string, error) {
+	if m.path == "" {
+		return nil, errorutils.CheckError(errors.New(".git path must be defined."))
+	}
+	tags := make(map[string]bool)
+	tagsDir := filepath.Join(m.path, "refs", "tags")
+	err := filepath.Walk(tagsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == tagsDir {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		tag, err := filepath.Rel(tagsDir, path)
+		if err != nil {
+			return err
+		}
+		tags[filepath.ToSlash(tag)] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	packedTags, err := m.readPackedTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range packedTags {
+		tags[tag] = true
+	}
+
+	var tagsList []string
+	for tag := range tags {
+		tagsList = append(tagsList, tag)
+	}
+	sort.Strings(tagsList)
+	return tagsList, nil
+}
+
+// Each line of the packed-refs file is in the form of '<revision> <ref>'.
+// Comments start with '#', and lines starting with '^' hold the revisions peeled annotated tags point to.
+func (m *manager) readPackedTags() ([]string, error) {
+	file, err := os.Open(filepath.Join(m.path, "packed-refs"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+
+	var tags []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "^") {
+			continue
+		}
+		if strings.HasPrefix(fields[1], tagsRefPrefix) {
+			tags = append(tags, strings.TrimPrefix(fields[1], tagsRefPrefix))
+		}
+	}
+	return tags, errorutils.CheckError(scanner.Err())
+}
+
+// Extracts the files of the repository at the tag into the destination directory, using 'git archive'.
+func (m *manager) ExtractTag(tag, destDir string) error {
+	cmd := exec.Command("git", "--git-dir="+m.path, "archive", "--format=tar", tagsRefPrefix+tag)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err = cmd.Start(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	extractErr := extractTar(stdout, destDir)
+	// Drain the output, so that git doesn't block if the extraction stopped early.
+	io.Copy(ioutil.Discard, stdout)
+	if err = cmd.Wait(); err != nil {
+		return errorutils.CheckError(errors.New("git archive of tag " + tag + " failed: " + err.Error() + "\n" + stderr.String()))
+	}
+	return extractErr
+}
+
+func extractTar(reader io.Reader, destDir string) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		targetPath := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(targetPath, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return errorutils.CheckError(errors.New("Illegal path in archive: " + header.Name))
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(targetPath, 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+		case tar.TypeReg:
+			if err = extractFile(tarReader, targetPath, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(reader io.Reader, targetPath string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
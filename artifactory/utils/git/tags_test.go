@@ -0,0 +1,45 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListTags(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(baseDir)
+	dotGitPath := filepath.Join(baseDir, ".git")
+	for _, tag := range []string{"v1.0.0", "sub/v0.1.0"} {
+		tagPath := filepath.Join(dotGitPath, "refs", "tags", filepath.FromSlash(tag))
+		if err = os.MkdirAll(filepath.Dir(tagPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(tagPath, []byte("6d1d51c2f5ba0bd4b5c6ba2b9b4a3d5e1c2d3e4f\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	packedRefs := `# pack-refs with: peeled fully-peeled sorted
+1b2c3d4e5f60718293a4b5c6d7e8f90112233445 refs/heads/master
+2b2c3d4e5f60718293a4b5c6d7e8f90112233445 refs/tags/v0.9.0
+^3b2c3d4e5f60718293a4b5c6d7e8f90112233445
+4b2c3d4e5f60718293a4b5c6d7e8f90112233445 refs/tags/v1.0.0
+`
+	if err = ioutil.WriteFile(filepath.Join(dotGitPath, "packed-refs"), []byte(packedRefs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := NewManager(baseDir).ListTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"sub/v0.1.0", "v0.9.0", "v1.0.0"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected: %s, Got: %s", expected, tags)
+	}
+}
@@ -33,6 +33,7 @@ type Go interface {
 	PublishDependencies(targetRepo string, servicesManager *artifactory.ArtifactoryServicesManager, includeDepSlice []string) (succeeded, failed int, err error)
 	BuildInfo(includeArtifacts bool, module string) *buildinfo.BuildInfo
 	LoadDependencies() error
+	ModuleName() string
 }
 
 type goProject struct {
@@ -46,11 +47,25 @@ type goProject struct {
 
 // Load go project.
 func Load(version string) (Go, error) {
-	goProject := &goProject{version: version}
+	projectPath, err := cmd.GetProjectRoot()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return LoadFromPath(projectPath, version)
+}
+
+// Load the go project in the directory of its go.mod file.
+func LoadFromPath(projectPath, version string) (Go, error) {
+	goProject := &goProject{version: version, projectPath: projectPath}
 	err := goProject.readModFile()
 	return goProject, err
 }
 
+// Get the go project module name, as declared in go.mod.
+func (project *goProject) ModuleName() string {
+	return project.moduleName
+}
+
 // Get the go project dependencies.
 func (project *goProject) Dependencies() []executers.Package {
 	return project.dependencies
@@ -227,12 +242,6 @@ func (project *goProject) getId() string {
 
 // Read go.mod file and add it as an artifact to the build info.
 func (project *goProject) readModFile() error {
-	var err error
-	project.projectPath, err = cmd.GetProjectRoot()
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-
 	modFilePath := filepath.Join(project.projectPath, "go.mod")
 	modFile, err := os.Open(modFilePath)
 	if err != nil {
@@ -0,0 +1,53 @@
+package project
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Canonical semantic versions, as required by Go modules. Build metadata isn't allowed.
+var semverRegexp = regexp.MustCompile(`^v(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// The major version suffix of a module path, such as /v2 or .v2 for gopkg.in modules.
+var majorSuffixRegexp = regexp.MustCompile(`([/.])v([0-9]+)$`)
+
+// A git tag and the version of the module it holds.
+type TagVersion struct {
+	Tag     string
+	Version string
+}
+
+// Returns the versions of the module held by the git tags.
+// The tags of a module in a subdirectory of the repository are prefixed by the directory, such as 'sub/v1.0.0'.
+// Only semantic versions which match the major version of the module path are returned.
+func VersionsFromTags(tags []string, moduleDir, moduleName string) []TagVersion {
+	prefix := ""
+	if moduleDir != "" && moduleDir != "." {
+		prefix = strings.TrimSuffix(moduleDir, "/") + "/"
+	}
+	var versions []TagVersion
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		version := strings.TrimPrefix(tag, prefix)
+		match := semverRegexp.FindStringSubmatch(version)
+		if match == nil || !isModuleMajorVersion(moduleName, match[1]) {
+			continue
+		}
+		versions = append(versions, TagVersion{Tag: tag, Version: version})
+	}
+	return versions
+}
+
+// Modules without a major version suffix hold versions v0 and v1, while modules with a /vN suffix hold versions vN only.
+func isModuleMajorVersion(moduleName, major string) bool {
+	match := majorSuffixRegexp.FindStringSubmatch(moduleName)
+	if match != nil {
+		if suffixMajor, _ := strconv.Atoi(match[2]); suffixMajor >= 2 || match[1] == "." {
+			return match[2] == major
+		}
+	}
+	return major == "0" || major == "1"
+}
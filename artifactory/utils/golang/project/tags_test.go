@@ -0,0 +1,29 @@
+package project
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersionsFromTags(t *testing.T) {
+	tags := []string{"latest", "sub/v1.2.0", "v0.1.0", "v1.0.0", "v1.0.1-rc.1", "v1.0.1+build", "v1.2", "v2.0.0", "v2.1.0"}
+	tests := []struct {
+		name       string
+		moduleDir  string
+		moduleName string
+		expected   []TagVersion
+	}{
+		{"root", ".", "github.com/jfrog/example", []TagVersion{{"v0.1.0", "v0.1.0"}, {"v1.0.0", "v1.0.0"}, {"v1.0.1-rc.1", "v1.0.1-rc.1"}}},
+		{"majorSuffix", "", "github.com/jfrog/example/v2", []TagVersion{{"v2.0.0", "v2.0.0"}, {"v2.1.0", "v2.1.0"}}},
+		{"gopkg", "", "gopkg.in/example.v1", []TagVersion{{"v1.0.0", "v1.0.0"}, {"v1.0.1-rc.1", "v1.0.1-rc.1"}}},
+		{"subdirectory", "sub", "github.com/jfrog/example/sub", []TagVersion{{"sub/v1.2.0", "v1.2.0"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := VersionsFromTags(tags, test.moduleDir, test.moduleName)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Expected: %v, Got: %v", test.expected, actual)
+			}
+		})
+	}
+}
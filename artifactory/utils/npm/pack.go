@@ -4,6 +4,7 @@ import (
 	gofrogcmd "github.com/jfrog/gofrog/io"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/mattn/go-shellwords"
+	"strings"
 )
 
 func Pack(npmFlags, executablePath string) error {
@@ -20,11 +21,33 @@ func Pack(npmFlags, executablePath string) error {
 	return nil
 }
 
+// Packs the package in the given directory. The tarball is created in the current working directory.
+// The publish path given in the npm flags, if any, is ignored, so that only the given directory is packed.
+func PackDirectory(npmFlags, executablePath, packageDir string) error {
+	_, splitFlags, err := SplitPublishPath(npmFlags)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(gofrogcmd.RunCmd(createPackCmdConfig(executablePath, append(splitFlags, packageDir))))
+}
+
+// Splits the npm flags of npm publish into the path of the published package, given as a leading positional argument, and the rest of the flags.
+func SplitPublishPath(npmFlags string) (publishPath string, splitFlags []string, err error) {
+	splitFlags, err = shellwords.Parse(npmFlags)
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	if len(splitFlags) > 0 && !strings.HasPrefix(strings.TrimSpace(splitFlags[0]), "-") {
+		return strings.TrimSpace(splitFlags[0]), splitFlags[1:], nil
+	}
+	return "", splitFlags, nil
+}
+
 func createPackCmdConfig(executablePath string, splitFlags []string) *NpmConfig {
 	return &NpmConfig{
 		Npm:          executablePath,
 		Command:      []string{"pack"},
-		CommandFlags: append(splitFlags),
+		CommandFlags: splitFlags,
 		StrWriter:    nil,
 		ErrWriter:    nil,
 	}
@@ -0,0 +1,33 @@
+package npm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPublishPath(t *testing.T) {
+	tests := []struct {
+		npmFlags            string
+		expectedPublishPath string
+		expectedFlags       []string
+	}{
+		{"", "", nil},
+		{"./mono", "./mono", []string{}},
+		{"./mono --tag beta", "./mono", []string{"--tag", "beta"}},
+		{"--tag beta", "", []string{"--tag", "beta"}},
+	}
+	for _, test := range tests {
+		t.Run(test.npmFlags, func(t *testing.T) {
+			publishPath, splitFlags, err := SplitPublishPath(test.npmFlags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if publishPath != test.expectedPublishPath {
+				t.Errorf("Expected the publish path %q, got %q.", test.expectedPublishPath, publishPath)
+			}
+			if len(splitFlags) != len(test.expectedFlags) || (len(splitFlags) > 0 && !reflect.DeepEqual(splitFlags, test.expectedFlags)) {
+				t.Errorf("Expected the flags %v, got %v.", test.expectedFlags, splitFlags)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 type PackageInfo struct {
 	Name    string `json:"name,omitempty"`
 	Version string `json:"version,omitempty"`
+	// Private packages are not published.
+	Private bool `json:"private,omitempty"`
 	Scope   string
 }
 
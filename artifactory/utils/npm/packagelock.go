@@ -0,0 +1,291 @@
+package npm
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const packageLockFileName = "package-lock.json"
+
+// npm 7 and above keep a hidden lockfile of the installed packages inside node_modules.
+var hiddenLockfilePath = filepath.Join("node_modules", ".package-lock.json")
+
+// A package installed by npm, as recorded in package-lock.json.
+type LockedPackage struct {
+	Name    string
+	Version string
+	// The sha1 checksum of the package tarball, if the integrity recorded in the lockfile includes it.
+	Sha1 string
+	// Development packages are required only by the development dependencies of the project.
+	Dev bool
+	// The packages which lead from the project to this package, starting with a direct dependency of the project and ending with the package itself.
+	Path []string
+}
+
+func (lp *LockedPackage) Id() string {
+	return lp.Name + ":" + lp.Version
+}
+
+// The file name of the package tarball, such as core-7.10.4.tgz for @babel/core.
+func (lp *LockedPackage) TarballName() string {
+	name := lp.Name
+	if index := strings.LastIndex(name, "/"); index >= 0 {
+		name = name[index+1:]
+	}
+	return name + "-" + lp.Version + ".tgz"
+}
+
+type packageLock struct {
+	LockfileVersion int `json:"lockfileVersion"`
+	// lockfileVersion 2 and above.
+	Packages map[string]*lockEntry `json:"packages"`
+	// lockfileVersion 1.
+	Dependencies map[string]*lockEntry `json:"dependencies"`
+}
+
+type lockEntry struct {
+	Name                 string                 `json:"name"`
+	Version              string                 `json:"version"`
+	Resolved             string                 `json:"resolved"`
+	Integrity            string                 `json:"integrity"`
+	Dev                  bool                   `json:"dev"`
+	Link                 bool                   `json:"link"`
+	Dependencies         map[string]interface{} `json:"dependencies"`
+	OptionalDependencies map[string]interface{} `json:"optionalDependencies"`
+	DevDependencies      map[string]interface{} `json:"devDependencies"`
+	// lockfileVersion 1 only.
+	Requires map[string]interface{} `json:"requires"`
+}
+
+// Returns the packages recorded in the package-lock.json of the project.
+// Each package is returned once, even if it's installed at several locations in node_modules.
+// Integrity missing from package-lock.json is taken from node_modules/.package-lock.json, if it exists.
+func ReadPackageLock(projectDir string) ([]*LockedPackage, error) {
+	lockPath := filepath.Join(projectDir, packageLockFileName)
+	log.Debug("Reading", lockPath)
+	content, err := ioutil.ReadFile(lockPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lock := new(packageLock)
+	if err = json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	// Entries by their location in node_modules, such as node_modules/a/node_modules/b.
+	var entries map[string]*lockEntry
+	var rootRequires map[string]interface{}
+	if lock.Packages != nil {
+		entries = lock.Packages
+		if root, ok := entries[""]; ok {
+			rootRequires = mergeRequires(root.Dependencies, root.OptionalDependencies, root.DevDependencies)
+		}
+	} else {
+		entries = make(map[string]*lockEntry)
+		flattenV1Dependencies("", lock.Dependencies, entries)
+		if rootRequires, err = readPackageJsonRequires(projectDir); err != nil {
+			return nil, err
+		}
+	}
+
+	hiddenEntries, err := readHiddenLockfile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	for location, entry := range entries {
+		if hiddenEntry, ok := hiddenEntries[location]; ok && !strings.Contains(entry.Integrity, "sha1-") {
+			entry.Integrity = strings.TrimSpace(entry.Integrity + " " + hiddenEntry.Integrity)
+		}
+	}
+	return collectLockedPackages(entries, rootRequires), nil
+}
+
+// Walks the dependency graph from the project, so that the path to each package is the shortest one.
+func collectLockedPackages(entries map[string]*lockEntry, rootRequires map[string]interface{}) []*LockedPackage {
+	type queued struct {
+		location string
+		path     []string
+	}
+	packages := make(map[string]*LockedPackage)
+	visited := make(map[string]bool)
+	var queue []queued
+	enqueue := func(from string, requires map[string]interface{}, path []string) {
+		for _, name := range sortedKeys(requires) {
+			location := resolveLocation(entries, from, name)
+			if location == "" || visited[location] {
+				continue
+			}
+			visited[location] = true
+			queue = append(queue, queued{location: location, path: path})
+		}
+	}
+	enqueue("", rootRequires, nil)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		entry := entries[current.location]
+		if entry.Link || entry.Version == "" || strings.HasPrefix(entry.Resolved, "file:") {
+			continue
+		}
+		name := entry.Name
+		if name == "" {
+			name = current.location[strings.LastIndex(current.location, "node_modules/")+len("node_modules/"):]
+		}
+		pkg := &LockedPackage{Name: name, Version: entry.Version, Sha1: integritySha1(entry.Integrity), Dev: entry.Dev}
+		pkg.Path = append(append([]string{}, current.path...), pkg.Id())
+		if existing, ok := packages[pkg.Id()]; ok {
+			// A package is a development package only if all its installations are.
+			existing.Dev = existing.Dev && pkg.Dev
+			if existing.Sha1 == "" {
+				existing.Sha1 = pkg.Sha1
+			}
+		} else {
+			packages[pkg.Id()] = pkg
+		}
+		enqueue(current.location, mergeRequires(entry.Dependencies, entry.OptionalDependencies, entry.Requires), pkg.Path)
+	}
+
+	var lockedPackages []*LockedPackage
+	for _, pkg := range packages {
+		lockedPackages = append(lockedPackages, pkg)
+	}
+	sort.Slice(lockedPackages, func(i, j int) bool {
+		return lockedPackages[i].Id() < lockedPackages[j].Id()
+	})
+	return lockedPackages
+}
+
+// Resolves a dependency the way Node.js does, by looking for it in the node_modules of the requiring package and then in the node_modules of its ancestors.
+func resolveLocation(entries map[string]*lockEntry, from, name string) string {
+	for {
+		location := "node_modules/" + name
+		if from != "" {
+			location = from + "/" + location
+		}
+		if _, ok := entries[location]; ok {
+			return location
+		}
+		if from == "" {
+			return ""
+		}
+		index := strings.LastIndex(from, "node_modules/")
+		if index <= 0 {
+			from = ""
+		} else {
+			from = strings.TrimSuffix(from[:index], "/")
+		}
+	}
+}
+
+// Converts the nested dependencies of lockfileVersion 1 into entries by location.
+func flattenV1Dependencies(parentLocation string, dependencies map[string]*lockEntry, entries map[string]*lockEntry) {
+	for name, entry := range dependencies {
+		location := "node_modules/" + name
+		if parentLocation != "" {
+			location = parentLocation + "/" + location
+		}
+		nested := make(map[string]*lockEntry)
+		for nestedName, nestedEntry := range entry.Dependencies {
+			// In lockfileVersion 1, dependencies holds the nested packages rather than the required ones.
+			if nestedLockEntry, ok := toLockEntry(nestedEntry); ok {
+				nested[nestedName] = nestedLockEntry
+			}
+		}
+		entry.Dependencies = nil
+		// Aliased packages record their version as npm:name@version.
+		if strings.HasPrefix(entry.Version, "npm:") {
+			entry.Name, entry.Version = splitAlias(strings.TrimPrefix(entry.Version, "npm:"))
+		}
+		entries[location] = entry
+		flattenV1Dependencies(location, nested, entries)
+	}
+}
+
+func toLockEntry(value interface{}) (*lockEntry, bool) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	entry := new(lockEntry)
+	return entry, json.Unmarshal(content, entry) == nil
+}
+
+func splitAlias(alias string) (name, version string) {
+	index := strings.LastIndex(alias, "@")
+	if index <= 0 {
+		return alias, ""
+	}
+	return alias[:index], alias[index+1:]
+}
+
+func readHiddenLockfile(projectDir string) (map[string]*lockEntry, error) {
+	hiddenLockPath := filepath.Join(projectDir, hiddenLockfilePath)
+	exists, err := fileutils.IsFileExists(hiddenLockPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	log.Debug("Reading", hiddenLockPath)
+	content, err := ioutil.ReadFile(hiddenLockPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	hiddenLock := new(packageLock)
+	if err = json.Unmarshal(content, hiddenLock); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return hiddenLock.Packages, nil
+}
+
+func readPackageJsonRequires(projectDir string) (map[string]interface{}, error) {
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	project := new(lockEntry)
+	if err = json.Unmarshal(content, project); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return mergeRequires(project.Dependencies, project.OptionalDependencies, project.DevDependencies), nil
+}
+
+func mergeRequires(requiresMaps ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, requires := range requiresMaps {
+		for name, value := range requires {
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// Returns the sha1 checksum in hex, if the subresource integrity string, such as "sha1-FqvYo...=", includes it.
+func integritySha1(integrity string) string {
+	for _, hash := range strings.Fields(integrity) {
+		if !strings.HasPrefix(hash, "sha1-") {
+			continue
+		}
+		checksum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hash, "sha1-"))
+		if err == nil && len(checksum) == sha1.Size {
+			return hex.EncodeToString(checksum)
+		}
+	}
+	return ""
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package npm
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const packageJson = `{"name": "app", "version": "1.0.0", "dependencies": {"express": "^4.17.1"}, "devDependencies": {"ms": "^2.1.2"}}`
+
+const packageLockV1 = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 1,
+  "requires": true,
+  "dependencies": {
+    "debug": {
+      "version": "2.6.9",
+      "integrity": "sha512-bC7ElrdJaJnPbAP+1EotYvqZsb3ecl5wi6Bfi6BJTUcNowp6cvspg0jXznRTKDjm/E7AdgFBVeAPVMNcKGsHMA==",
+      "requires": {"ms": "2.0.0"},
+      "dependencies": {
+        "ms": {"version": "2.0.0", "integrity": "sha1-VgiurfwAvmwpAd9fmGF4jeDVl8g="}
+      }
+    },
+    "express": {
+      "version": "4.17.1",
+      "integrity": "sha1-TfDC1jp0YJ8Cx3XqRRfKoL7CXTQ=",
+      "requires": {"debug": "2.6.9"}
+    },
+    "ms": {"version": "2.1.2", "integrity": "sha512-sGkPx+VjMtmA6MX27oA4FBFELFCZZ4S4XqeGOXCv68tT+jb3vk/RyaKWP0PTKyWtmLSM0b+adUTEvbs1PEaH2w==", "dev": true}
+  }
+}`
+
+const packageLockV2 = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 2,
+  "requires": true,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0", "dependencies": {"express": "^4.17.1"}, "devDependencies": {"ms": "^2.1.2"}},
+    "node_modules/debug": {
+      "version": "2.6.9",
+      "integrity": "sha512-bC7ElrdJaJnPbAP+1EotYvqZsb3ecl5wi6Bfi6BJTUcNowp6cvspg0jXznRTKDjm/E7AdgFBVeAPVMNcKGsHMA==",
+      "dependencies": {"ms": "2.0.0"}
+    },
+    "node_modules/debug/node_modules/ms": {"version": "2.0.0", "integrity": "sha1-VgiurfwAvmwpAd9fmGF4jeDVl8g="},
+    "node_modules/express": {"version": "4.17.1", "integrity": "sha1-TfDC1jp0YJ8Cx3XqRRfKoL7CXTQ=", "dependencies": {"debug": "2.6.9"}},
+    "node_modules/ms": {"version": "2.1.2", "dev": true},
+    "node_modules/local": {"resolved": "packages/local", "link": true}
+  }
+}`
+
+const hiddenLockfile = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 2,
+  "requires": true,
+  "packages": {
+    "node_modules/ms": {"version": "2.1.2", "integrity": "sha1-9ENQ6MZ4fRMcD4MgJk9wvtFbB0Y=", "dev": true}
+  }
+}`
+
+func TestReadPackageLock(t *testing.T) {
+	log.SetDefaultLogger()
+	expected := []*LockedPackage{
+		{Name: "debug", Version: "2.6.9", Path: []string{"express:4.17.1", "debug:2.6.9"}},
+		{Name: "express", Version: "4.17.1", Sha1: "4df0c2d63a74609f02c775ea4517caa0bec25d34", Path: []string{"express:4.17.1"}},
+		{Name: "ms", Version: "2.0.0", Sha1: "5608aeadfc00be6c2901df5f9861788de0d597c8", Path: []string{"express:4.17.1", "debug:2.6.9", "ms:2.0.0"}},
+		{Name: "ms", Version: "2.1.2", Dev: true, Path: []string{"ms:2.1.2"}},
+	}
+	for name, lockContent := range map[string]string{"v1": packageLockV1, "v2": packageLockV2} {
+		t.Run(name, func(t *testing.T) {
+			projectDir := createTestProject(t, map[string]string{"package.json": packageJson, "package-lock.json": lockContent})
+			defer os.RemoveAll(projectDir)
+			packages, err := ReadPackageLock(projectDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertLockedPackages(t, expected, packages)
+		})
+	}
+}
+
+func TestReadPackageLockWithHiddenLockfile(t *testing.T) {
+	log.SetDefaultLogger()
+	projectDir := createTestProject(t, map[string]string{
+		"package.json":                    packageJson,
+		"package-lock.json":               packageLockV2,
+		"node_modules/.package-lock.json": hiddenLockfile,
+	})
+	defer os.RemoveAll(projectDir)
+	packages, err := ReadPackageLock(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pkg := range packages {
+		if pkg.Id() == "ms:2.1.2" && pkg.Sha1 != "f44350e8c6787d131c0f8320264f70bed15b0746" {
+			t.Errorf("Expected the sha1 of ms:2.1.2 to be taken from the hidden lockfile, got '%s'", pkg.Sha1)
+		}
+	}
+}
+
+func TestTarballName(t *testing.T) {
+	tests := map[string]string{"lodash": "lodash-4.17.21.tgz", "@babel/core": "core-4.17.21.tgz"}
+	for name, expected := range tests {
+		pkg := &LockedPackage{Name: name, Version: "4.17.21"}
+		if pkg.TarballName() != expected {
+			t.Errorf("Expected the tarball name of %s to be %s, got %s", name, expected, pkg.TarballName())
+		}
+	}
+}
+
+func assertLockedPackages(t *testing.T, expected, actual []*LockedPackage) {
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d packages, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(expected[i], actual[i]) {
+			t.Errorf("Expected:\n%+v\nGot:\n%+v", *expected[i], *actual[i])
+		}
+	}
+}
+
+func createTestProject(t *testing.T, files map[string]string) string {
+	projectDir, err := ioutil.TempDir("", "npm-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		filePath := filepath.Join(projectDir, path)
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return projectDir
+}
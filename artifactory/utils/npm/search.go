@@ -0,0 +1,62 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The fields included in the results of a packages search.
+var packageSearchFields = []string{"name", "repo", "path", "actual_sha1", "actual_md5", "@npm.name", "@npm.version"}
+
+// Identifies a package by its full name, such as @babel/core, and its version.
+type PackageVersion struct {
+	Name    string
+	Version string
+}
+
+// Searches the packages in Artifactory by their npm.name and npm.version properties, using an AQL query for each batch of packages.
+// Returns the items found for each of the packages, in the order of the packages.
+func SearchPackages(servicesManager *artifactory.ArtifactoryServicesManager, packages []PackageVersion, threads int) ([][]serviceutils.ResultItem, error) {
+	var criteria []string
+	for _, pkg := range packages {
+		criteria = append(criteria, createPackageCriterion(pkg))
+	}
+	items := make([][]serviceutils.ResultItem, len(packages))
+	err := utils.SearchInBatches(servicesManager, criteria, packageSearchFields, threads, func(start, end int, result []byte) error {
+		parsedResult := new(serviceutils.AqlSearchResult)
+		if err := json.Unmarshal(result, parsedResult); err != nil {
+			return errorutils.CheckError(err)
+		}
+		found := groupItemsByPackage(parsedResult.Results)
+		for i := start; i < end; i++ {
+			items[i] = found[packages[i]]
+		}
+		return nil
+	})
+	return items, err
+}
+
+func createPackageCriterion(pkg PackageVersion) string {
+	return fmt.Sprintf(`{"$and":[{"@npm.name":%q},{"@npm.version":%q}]}`, pkg.Name, pkg.Version)
+}
+
+func groupItemsByPackage(items []serviceutils.ResultItem) map[PackageVersion][]serviceutils.ResultItem {
+	found := make(map[PackageVersion][]serviceutils.ResultItem)
+	for _, item := range items {
+		var pkg PackageVersion
+		for _, property := range item.Properties {
+			switch property.Key {
+			case "npm.name":
+				pkg.Name = property.Value
+			case "npm.version":
+				pkg.Version = property.Value
+			}
+		}
+		found[pkg] = append(found[pkg], item)
+	}
+	return found
+}
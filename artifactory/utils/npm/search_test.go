@@ -0,0 +1,28 @@
+package npm
+
+import (
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"testing"
+)
+
+func TestCreatePackageCriterion(t *testing.T) {
+	expected := `{"$and":[{"@npm.name":"@babel/core"},{"@npm.version":"7.10.4"}]}`
+	if actual := createPackageCriterion(PackageVersion{Name: "@babel/core", Version: "7.10.4"}); actual != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestGroupItemsByPackage(t *testing.T) {
+	items := []serviceutils.ResultItem{
+		{Name: "core-7.10.4.tgz", Properties: []serviceutils.Property{{Key: "npm.name", Value: "@babel/core"}, {Key: "npm.version", Value: "7.10.4"}}},
+		{Name: "lodash-4.17.21.tgz", Properties: []serviceutils.Property{{Key: "npm.name", Value: "lodash"}, {Key: "npm.version", Value: "4.17.21"}}},
+		{Name: "other-lodash-4.17.21.tgz", Properties: []serviceutils.Property{{Key: "npm.version", Value: "4.17.21"}, {Key: "npm.name", Value: "lodash"}}},
+	}
+	found := groupItemsByPackage(items)
+	if lodash := found[PackageVersion{Name: "lodash", Version: "4.17.21"}]; len(lodash) != 2 {
+		t.Errorf("Expected 2 items of lodash, got %v", lodash)
+	}
+	if core := found[PackageVersion{Name: "@babel/core", Version: "7.10.4"}]; len(core) != 1 || core[0].Name != "core-7.10.4.tgz" {
+		t.Errorf("Unexpected items of @babel/core: %v", core)
+	}
+}
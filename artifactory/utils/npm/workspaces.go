@@ -0,0 +1,88 @@
+package npm
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The workspaces of package.json are either a list of patterns, or an object with the patterns under 'packages', as used by Yarn.
+type workspacesPackageJson struct {
+	Workspaces json.RawMessage `json:"workspaces,omitempty"`
+}
+
+type workspacesObject struct {
+	Packages []string `json:"packages,omitempty"`
+}
+
+// Returns the directories of the workspace packages under the root directory.
+// The directories are matched by the glob patterns, or by the 'workspaces' of the root package.json if no patterns are given.
+// Patterns which start with '!' exclude the directories they match. Directories without a package.json are skipped.
+func FindWorkspacePackages(rootDir string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		var err error
+		if patterns, err = readWorkspacesPatterns(rootDir); err != nil {
+			return nil, err
+		}
+	}
+
+	included := make(map[string]bool)
+	excluded := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches := included
+		if strings.HasPrefix(pattern, "!") {
+			matches = excluded
+			pattern = strings.TrimPrefix(pattern, "!")
+		}
+		paths, err := filepath.Glob(filepath.Join(rootDir, filepath.FromSlash(strings.TrimSuffix(pattern, "/"))))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, path := range paths {
+			matches[path] = true
+		}
+	}
+
+	var packageDirs []string
+	for dir := range included {
+		if excluded[dir] || strings.Contains(filepath.ToSlash(dir), "/node_modules/") {
+			continue
+		}
+		exists, err := fileutils.IsFileExists(filepath.Join(dir, "package.json"), false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			packageDirs = append(packageDirs, dir)
+		}
+	}
+	sort.Strings(packageDirs)
+	return packageDirs, nil
+}
+
+func readWorkspacesPatterns(rootDir string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(rootDir, "package.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	packageJson := new(workspacesPackageJson)
+	if err = json.Unmarshal(content, packageJson); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var patterns []string
+	if err = json.Unmarshal(packageJson.Workspaces, &patterns); err != nil {
+		object := new(workspacesObject)
+		if json.Unmarshal(packageJson.Workspaces, object) == nil {
+			patterns = object.Packages
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, errorutils.CheckError(errors.New("No workspaces are defined in " + filepath.Join(rootDir, "package.json")))
+	}
+	return patterns, nil
+}
@@ -0,0 +1,53 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindWorkspacePackages(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageJson string
+		globs       []string
+		expected    []string
+	}{
+		{"array", `{"workspaces": ["packages/*"]}`, nil, []string{"packages/a", "packages/b"}},
+		{"object", `{"workspaces": {"packages": ["packages/*", "tools/cli"]}}`, nil, []string{"packages/a", "packages/b", "tools/cli"}},
+		{"globs", `{}`, []string{"packages/*", "tools/*", "!packages/b"}, []string{"packages/a", "tools/cli"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rootDir := createTestProject(t, map[string]string{
+				"package.json":              test.packageJson,
+				"packages/a/package.json":   `{"name": "a", "version": "1.0.0"}`,
+				"packages/b/package.json":   `{"name": "b", "version": "1.0.0"}`,
+				"packages/docs/README.md":   "Not a package",
+				"tools/cli/package.json":    `{"name": "cli", "version": "1.0.0"}`,
+				"tools/cli/node_modules/.x": "",
+			})
+			defer os.RemoveAll(rootDir)
+			packageDirs, err := FindWorkspacePackages(rootDir, test.globs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected []string
+			for _, dir := range test.expected {
+				expected = append(expected, filepath.Join(rootDir, filepath.FromSlash(dir)))
+			}
+			if !reflect.DeepEqual(packageDirs, expected) {
+				t.Errorf("Expected %v, got %v", expected, packageDirs)
+			}
+		})
+	}
+}
+
+func TestFindWorkspacePackagesWithoutWorkspaces(t *testing.T) {
+	rootDir := createTestProject(t, map[string]string{"package.json": `{"name": "app"}`})
+	defer os.RemoveAll(rootDir)
+	if _, err := FindWorkspacePackages(rootDir, nil); err == nil {
+		t.Error("Expected an error for a package.json without workspaces")
+	}
+}
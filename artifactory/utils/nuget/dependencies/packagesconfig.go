@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/dependencytree"
 	"github.com/jfrog/jfrog-cli-go/artifactory/utils/nuget"
 	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -39,7 +40,7 @@ func (extractor *packagesExtractor) IsCompatible(projectName, projectRoot string
 }
 
 func (extractor *packagesExtractor) DirectDependencies() ([]string, error) {
-	return getDirectDependencies(extractor.allDependencies, extractor.childrenMap), nil
+	return dependencytree.RootDependencies(extractor.allDependencies, extractor.childrenMap), nil
 }
 
 func (extractor *packagesExtractor) AllDependencies() (map[string]*buildinfo.Dependency, error) {
@@ -147,59 +148,6 @@ func (extractor *packagesExtractor) loadPackagesConfig(rootPath string) (*packag
 	return config, nil
 }
 
-type dfsHelper struct {
-	visited  bool
-	notRoot  bool
-	circular bool
-}
-
-func getDirectDependencies(allDependencies map[string]*buildinfo.Dependency, childrenMap map[string][]string) []string {
-	helper := map[string]*dfsHelper{}
-	for id := range allDependencies {
-		helper[id] = &dfsHelper{}
-	}
-
-	for id := range allDependencies {
-		if helper[id].visited {
-			continue
-		}
-		searchRootDependencies(helper, id, allDependencies, childrenMap, map[string]bool{id: true})
-	}
-	var rootDependencies []string
-	for id, nodeData := range helper {
-		if !nodeData.notRoot || nodeData.circular {
-			rootDependencies = append(rootDependencies, id)
-		}
-	}
-
-	return rootDependencies
-}
-
-func searchRootDependencies(dfsHelper map[string]*dfsHelper, currentId string, allDependencies map[string]*buildinfo.Dependency, childrenMap map[string][]string, traversePath map[string]bool) {
-	if dfsHelper[currentId].visited {
-		return
-	}
-	for _, next := range childrenMap[currentId] {
-		if _, ok := allDependencies[next]; !ok {
-			// No such dependency
-			continue
-		}
-		if traversePath[next] {
-			for circular := range traversePath {
-				dfsHelper[circular].circular = true
-			}
-			continue
-		}
-
-		// Not root dependency
-		dfsHelper[next].notRoot = true
-		traversePath[next] = true
-		searchRootDependencies(dfsHelper, next, allDependencies, childrenMap, traversePath)
-		delete(traversePath, next)
-	}
-	dfsHelper[currentId].visited = true
-}
-
 func createNugetPackage(packagesPath string, nuget xmlPackage, nPackage *nugetPackage) (*nugetPackage, error) {
 	nupkgPath := filepath.Join(packagesPath, nPackage.id, nPackage.version, strings.Join([]string{nPackage.id, nPackage.version, "nupkg"}, "."))
 
@@ -10,42 +10,6 @@ import (
 	"testing"
 )
 
-func getAllDependencies(dependencies map[string][]string) map[string]*buildinfo.Dependency {
-	allDependencies := map[string]*buildinfo.Dependency{}
-	for id := range dependencies {
-		allDependencies[id] = &buildinfo.Dependency{Id: id}
-	}
-	return allDependencies
-}
-
-func TestGetRootDependencies(t *testing.T) {
-	tests := []struct {
-		name         string
-		dependencies map[string][]string
-		expected     []string
-	}{
-		{"simple1", map[string][]string{"a": {"b", "c"}, "b": {}, "c": {}}, []string{"a"}},
-		{"simple2", map[string][]string{"a": {}, "b": {}, "c": {}}, []string{"a", "b", "c"}},
-		{"simple3", map[string][]string{"a": {"b"}, "b": {}, "c": {}}, []string{"a", "c"}},
-		{"simple4", map[string][]string{"a": {"b"}, "b": {}, "c": {"d"}, "d": {}}, []string{"a", "c"}},
-		{"simple5", map[string][]string{"a": {"c"}, "b": {"c"}, "c": {"d", "e"}, "d": {}, "e": {}}, []string{"a", "b"}},
-		{"nonexisting", map[string][]string{"a": {"nonexisting"}}, []string{"a"}},
-		{"circular1", map[string][]string{"a": {"b", "c"}, "b": {}, "c": {"a"}}, []string{"a", "c"}},
-		{"circular2", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}}, []string{"a", "b", "c"}},
-		{"circular3", map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}, "d": {"a"}}, []string{"a", "b", "c", "d"}},
-	}
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			actual := getDirectDependencies(getAllDependencies(test.dependencies), test.dependencies)
-			sort.Strings(actual)
-			sort.Strings(test.expected)
-			if !reflect.DeepEqual(test.expected, actual) {
-				t.Errorf("Expected: %s, Got: %s", test.expected, actual)
-			}
-		})
-	}
-}
-
 func TestAlternativeVersionsForms(t *testing.T) {
 	tests := []struct {
 		version  string
@@ -0,0 +1,65 @@
+package pip
+
+import (
+	"io"
+	"os/exec"
+)
+
+const indexUrlEnv = "PIP_INDEX_URL"
+
+func (config *PipConfig) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, config.Pip)
+	cmd = append(cmd, config.Command...)
+	return exec.Command(cmd[0], cmd[1:]...)
+}
+
+// The index URL is passed through the environment rather than as an argument, so that the credentials it includes aren't exposed in the process list.
+func (config *PipConfig) GetEnv() map[string]string {
+	if config.IndexUrl == "" {
+		return map[string]string{}
+	}
+	return map[string]string{indexUrlEnv: config.IndexUrl}
+}
+
+func (config *PipConfig) GetStdWriter() io.WriteCloser {
+	return config.StrWriter
+}
+
+func (config *PipConfig) GetErrWriter() io.WriteCloser {
+	return config.ErrWriter
+}
+
+type PipConfig struct {
+	Pip       string
+	Command   []string
+	IndexUrl  string
+	StrWriter io.WriteCloser
+	ErrWriter io.WriteCloser
+}
+
+func (config *PythonConfig) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, config.Python)
+	cmd = append(cmd, config.Command...)
+	return exec.Command(cmd[0], cmd[1:]...)
+}
+
+func (config *PythonConfig) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (config *PythonConfig) GetStdWriter() io.WriteCloser {
+	return config.StrWriter
+}
+
+func (config *PythonConfig) GetErrWriter() io.WriteCloser {
+	return config.ErrWriter
+}
+
+type PythonConfig struct {
+	Python    string
+	Command   []string
+	StrWriter io.WriteCloser
+	ErrWriter io.WriteCloser
+}
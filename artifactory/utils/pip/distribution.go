@@ -0,0 +1,65 @@
+package pip
+
+import (
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"regexp"
+	"strings"
+)
+
+var sdistExtensions = []string{".tar.gz", ".tar.bz2", ".tgz", ".zip"}
+
+var nameSeparatorsRegexp = regexp.MustCompile(`[-_.]+`)
+
+// A Python distribution file - a wheel or a source distribution (sdist).
+type Distribution struct {
+	Name     string
+	Version  string
+	FileName string
+	Wheel    bool
+}
+
+// The name of the project, normalized as defined by PEP 503.
+func (d *Distribution) NormalizedName() string {
+	return NormalizeName(d.Name)
+}
+
+// The path of the distribution in an Artifactory PyPI repository.
+func (d *Distribution) DeployPath() string {
+	return d.NormalizedName() + "/" + d.Version + "/" + d.FileName
+}
+
+// The properties Artifactory sets on packages deployed to PyPI repositories.
+func (d *Distribution) Properties() string {
+	return "pypi.name=" + d.Name + ";pypi.version=" + d.Version + ";pypi.normalized.name=" + d.NormalizedName()
+}
+
+// Normalizes the name of a project as defined by PEP 503, such as 'Foo.Bar_baz' to 'foo-bar-baz'.
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparatorsRegexp.ReplaceAllString(name, "-"))
+}
+
+// Parses the name and version of the distribution from its file name.
+// Wheels are named {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl, and sdists are named {name}-{version}.tar.gz.
+func ParseDistributionFileName(fileName string) (*Distribution, error) {
+	if strings.HasSuffix(fileName, ".whl") {
+		parts := strings.Split(strings.TrimSuffix(fileName, ".whl"), "-")
+		if len(parts) != 5 && len(parts) != 6 {
+			return nil, errorutils.CheckError(errors.New("Invalid wheel file name: " + fileName))
+		}
+		return &Distribution{Name: parts[0], Version: parts[1], FileName: fileName, Wheel: true}, nil
+	}
+	for _, extension := range sdistExtensions {
+		if !strings.HasSuffix(fileName, extension) {
+			continue
+		}
+		baseName := strings.TrimSuffix(fileName, extension)
+		// The version of an sdist can't include a dash, while the name of a legacy sdist can.
+		index := strings.LastIndex(baseName, "-")
+		if index <= 0 || index == len(baseName)-1 {
+			return nil, errorutils.CheckError(errors.New("Invalid source distribution file name: " + fileName))
+		}
+		return &Distribution{Name: baseName[:index], Version: baseName[index+1:], FileName: fileName}, nil
+	}
+	return nil, errorutils.CheckError(errors.New("Unsupported Python distribution file: " + fileName + ". Only wheels and source distributions are supported."))
+}
@@ -0,0 +1,55 @@
+package pip
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"requests":        "requests",
+		"Foo.Bar_baz":     "foo-bar-baz",
+		"zope.interface":  "zope-interface",
+		"typing__extras-": "typing-extras-",
+	}
+	for name, expected := range tests {
+		if actual := NormalizeName(name); actual != expected {
+			t.Errorf("NormalizeName(%q) => '%s', want '%s'", name, actual, expected)
+		}
+	}
+}
+
+func TestParseDistributionFileName(t *testing.T) {
+	tests := map[string]*Distribution{
+		"requests-2.31.0-py3-none-any.whl":                   {Name: "requests", Version: "2.31.0", FileName: "requests-2.31.0-py3-none-any.whl", Wheel: true},
+		"zope.interface-6.0-1-cp311-cp311-manylinux_x86.whl": {Name: "zope.interface", Version: "6.0", FileName: "zope.interface-6.0-1-cp311-cp311-manylinux_x86.whl", Wheel: true},
+		"my_project-1.0.0.tar.gz":                            {Name: "my_project", Version: "1.0.0", FileName: "my_project-1.0.0.tar.gz"},
+		"python-dateutil-2.8.2.zip":                          {Name: "python-dateutil", Version: "2.8.2", FileName: "python-dateutil-2.8.2.zip"},
+	}
+	for fileName, expected := range tests {
+		actual, err := ParseDistributionFileName(fileName)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("ParseDistributionFileName(%q) => %+v, want %+v", fileName, actual, expected)
+		}
+	}
+
+	for _, fileName := range []string{"requests-2.31.0.whl", "requests.tar.gz", "requests-.tar.gz", "requests-2.31.0.egg"} {
+		if _, err := ParseDistributionFileName(fileName); err == nil {
+			t.Errorf("ParseDistributionFileName(%q) should fail", fileName)
+		}
+	}
+}
+
+func TestDistributionDeployPath(t *testing.T) {
+	distribution := &Distribution{Name: "Foo.Bar", Version: "1.0", FileName: "Foo.Bar-1.0.tar.gz"}
+	if actual := distribution.DeployPath(); actual != "foo-bar/1.0/Foo.Bar-1.0.tar.gz" {
+		t.Errorf("Unexpected deploy path: %s", actual)
+	}
+	if actual := distribution.Properties(); actual != "pypi.name=Foo.Bar;pypi.version=1.0;pypi.normalized.name=foo-bar" {
+		t.Errorf("Unexpected properties: %s", actual)
+	}
+}
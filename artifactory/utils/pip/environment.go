@@ -0,0 +1,153 @@
+package pip
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-go/artifactory/utils/dependencytree"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const metadataFileName = "METADATA"
+
+// Prints the directories of the environment where pure and platform specific distributions are installed.
+const sitePackagesScript = "import json, sysconfig; paths = sysconfig.get_paths(); print(json.dumps([paths['purelib'], paths['platlib']]))"
+
+var requirementNameRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+var extraMarkerRegexp = regexp.MustCompile(`\bextra\s*==`)
+
+// The distributions installed in a Python environment, by their normalized names.
+type Environment struct {
+	allDependencies map[string]*buildinfo.Dependency
+	childrenMap     map[string][]string
+}
+
+// Returns the site-packages directories of the environment of the Python executable.
+func SitePackagesDirs(pythonExecutable string) ([]string, error) {
+	output, err := gofrogcmd.RunCmdOutput(&PythonConfig{Python: pythonExecutable, Command: []string{"-c", sitePackagesScript}})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var dirs []string
+	if err = json.Unmarshal([]byte(output), &dirs); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(dirs) == 2 && dirs[0] == dirs[1] {
+		dirs = dirs[:1]
+	}
+	return dirs, nil
+}
+
+// Loads the distributions installed in the site-packages directories, from the metadata of their *.dist-info directories.
+func LoadEnvironment(sitePackagesDirs []string) (*Environment, error) {
+	env := &Environment{allDependencies: map[string]*buildinfo.Dependency{}, childrenMap: map[string][]string{}}
+	for _, dir := range sitePackagesDirs {
+		metadataPaths, err := filepath.Glob(filepath.Join(dir, "*.dist-info", metadataFileName))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, metadataPath := range metadataPaths {
+			content, err := ioutil.ReadFile(metadataPath)
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			name, version, requires := parseMetadata(content)
+			if name == "" {
+				log.Debug("Skipping", metadataPath, "since it doesn't include the name of the distribution.")
+				continue
+			}
+			id := NormalizeName(name)
+			if _, ok := env.allDependencies[id]; ok {
+				// The first site-packages directory takes precedence, as in the Python import path.
+				continue
+			}
+			env.allDependencies[id] = &buildinfo.Dependency{Id: id + ":" + version}
+			env.childrenMap[id] = requires
+		}
+	}
+	return env, nil
+}
+
+func (env *Environment) AllDependencies() map[string]*buildinfo.Dependency {
+	return env.allDependencies
+}
+
+// The normalized names of the installed distributions required by each distribution.
+func (env *Environment) ChildrenMap() map[string][]string {
+	return env.childrenMap
+}
+
+// Returns the normalized names of the required distributions which are installed.
+// If no requirements are given, the installed distributions which aren't required by any other distribution are returned.
+func (env *Environment) RootDependencies(requirements []string) []string {
+	if requirements == nil {
+		rootDependencies := dependencytree.RootDependencies(env.allDependencies, env.childrenMap)
+		sort.Strings(rootDependencies)
+		return rootDependencies
+	}
+	var rootDependencies []string
+	added := map[string]bool{}
+	for _, requirement := range requirements {
+		id := NormalizeName(requirement)
+		if _, ok := env.allDependencies[id]; !ok {
+			log.Warn(fmt.Sprintf("The requirement %s is not installed in the environment.", requirement))
+			continue
+		}
+		if !added[id] {
+			added[id] = true
+			rootDependencies = append(rootDependencies, id)
+		}
+	}
+	return rootDependencies
+}
+
+// Parses the name, version and requirements from the headers of a METADATA file, as defined by the core metadata specifications.
+// Requirements which are needed only for extras are skipped, since extras aren't installed by default.
+func parseMetadata(content []byte) (name, version string, requires []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// The headers end with an empty line, followed by the description.
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		index := strings.Index(line, ":")
+		if index < 0 || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		value := strings.TrimSpace(line[index+1:])
+		switch line[:index] {
+		case "Name":
+			name = value
+		case "Version":
+			version = value
+		case "Requires-Dist":
+			if markerIndex := strings.Index(value, ";"); markerIndex >= 0 && extraMarkerRegexp.MatchString(value[markerIndex:]) {
+				continue
+			}
+			if requirementName := parseRequirementName(value); requirementName != "" {
+				requires = append(requires, NormalizeName(requirementName))
+			}
+		}
+	}
+	return
+}
+
+// Returns the project name of a requirement specifier, such as 'requests' for 'requests[socks]>=2.0; python_version>"3"'.
+func parseRequirementName(requirement string) string {
+	match := requirementNameRegexp.FindStringSubmatch(requirement)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
@@ -0,0 +1,58 @@
+package pip
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+	content := []byte("Metadata-Version: 2.1\nName: requests\nVersion: 2.31.0\nLicense: Apache 2.0\n  Name: continued\n" +
+		"Requires-Dist: charset-normalizer (<4,>=2)\nRequires-Dist: zope.interface>=5; python_version >= \"3.7\"\n" +
+		"Requires-Dist: PySocks (!=1.5.7,>=1.5.6) ; extra == 'socks'\n\nRequires-Dist: description\n")
+	name, version, requires := parseMetadata(content)
+	if name != "requests" || version != "2.31.0" {
+		t.Errorf("Expected requests 2.31.0, got %s %s", name, version)
+	}
+	expected := []string{"charset-normalizer", "zope-interface"}
+	if !reflect.DeepEqual(requires, expected) {
+		t.Errorf("Expected %s, got %s", expected, requires)
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	log.SetDefaultLogger()
+	env, err := LoadEnvironment([]string{filepath.Join("testdata", "sitepackages")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDependencies := map[string]*buildinfo.Dependency{
+		"certifi":                 {Id: "certifi:2023.5.7"},
+		"charset-normalizer":      {Id: "charset-normalizer:3.1.0"},
+		"idna":                    {Id: "idna:3.4"},
+		"jinja2":                  {Id: "jinja2:3.1.2"},
+		"requests":                {Id: "requests:2.31.0"},
+		"sphinx":                  {Id: "sphinx:7.0.1"},
+		"sphinxcontrib-applehelp": {Id: "sphinxcontrib-applehelp:1.0.4"},
+		"urllib3":                 {Id: "urllib3:2.0.2"},
+	}
+	if !reflect.DeepEqual(env.AllDependencies(), expectedDependencies) {
+		t.Errorf("Expected %v, got %v", expectedDependencies, env.AllDependencies())
+	}
+	expectedRequires := []string{"charset-normalizer", "idna", "urllib3", "certifi"}
+	if !reflect.DeepEqual(env.ChildrenMap()["requests"], expectedRequires) {
+		t.Errorf("Expected %s, got %s", expectedRequires, env.ChildrenMap()["requests"])
+	}
+
+	// Distributions in a circle are roots, since nothing else requires them.
+	expectedRoots := []string{"requests", "sphinx", "sphinxcontrib-applehelp"}
+	if roots := env.RootDependencies(nil); !reflect.DeepEqual(roots, expectedRoots) {
+		t.Errorf("Expected %s, got %s", expectedRoots, roots)
+	}
+	expectedRoots = []string{"idna", "sphinx"}
+	if roots := env.RootDependencies([]string{"idna", "not-installed", "Sphinx", "sphinx"}); !reflect.DeepEqual(roots, expectedRoots) {
+		t.Errorf("Expected %s, got %s", expectedRoots, roots)
+	}
+}
@@ -0,0 +1,35 @@
+package pip
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"net/url"
+)
+
+// Returns the simple index URL of the PyPI repository, including the credentials of the Artifactory details.
+func GetIndexUrl(repo string, artDetails auth.ArtifactoryDetails) (string, error) {
+	rtUrl, err := url.Parse(artDetails.GetUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+
+	username := artDetails.GetUser()
+	password := artDetails.GetPassword()
+
+	// Get credentials from access-token if exists.
+	if artDetails.GetAccessToken() != "" {
+		log.Debug("Using the index URL with access-token.")
+		username, err = auth.ExtractUsernameFromAccessToken(artDetails.GetAccessToken())
+		if err != nil {
+			return "", err
+		}
+		password = artDetails.GetAccessToken()
+	}
+
+	if username != "" && password != "" {
+		rtUrl.User = url.UserPassword(username, password)
+	}
+	rtUrl.Path += "api/pypi/" + repo + "/simple"
+	return rtUrl.String(), nil
+}
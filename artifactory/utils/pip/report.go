@@ -0,0 +1,105 @@
+package pip
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// A distribution installed by pip, as recorded in the installation report.
+type InstalledDistribution struct {
+	Name     string
+	Version  string
+	FileName string
+	// The sha256 checksum of the distribution file, if pip recorded it.
+	Sha256 string
+	// Requested distributions were specified by the user, rather than installed as dependencies.
+	Requested bool
+}
+
+func (id *InstalledDistribution) Id() string {
+	return id.Name + ":" + id.Version
+}
+
+// The installation report written by 'pip install --report', as defined by https://pip.pypa.io/en/stable/reference/installation-report/
+type installReport struct {
+	Install []*installReportItem `json:"install"`
+}
+
+type installReportItem struct {
+	DownloadInfo struct {
+		Url         string `json:"url"`
+		ArchiveInfo *struct {
+			Hash   string            `json:"hash"`
+			Hashes map[string]string `json:"hashes"`
+		} `json:"archive_info"`
+	} `json:"download_info"`
+	Requested bool `json:"requested"`
+	Metadata  struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"metadata"`
+}
+
+// Returns the distributions installed from archives, as recorded in the installation report.
+// Projects installed from local directories or VCS URLs aren't distributions and are skipped.
+func ReadInstallReport(reportPath string) ([]*InstalledDistribution, error) {
+	content, err := ioutil.ReadFile(reportPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseInstallReport(content)
+}
+
+func parseInstallReport(content []byte) ([]*InstalledDistribution, error) {
+	report := new(installReport)
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var distributions []*InstalledDistribution
+	for _, item := range report.Install {
+		if item.DownloadInfo.ArchiveInfo == nil {
+			log.Debug("Skipping", item.Metadata.Name, "since it wasn't installed from a distribution archive.")
+			continue
+		}
+		fileName, err := fileNameFromUrl(item.DownloadInfo.Url)
+		if err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, &InstalledDistribution{
+			Name:      item.Metadata.Name,
+			Version:   item.Metadata.Version,
+			FileName:  fileName,
+			Sha256:    archiveSha256(item.DownloadInfo.ArchiveInfo.Hash, item.DownloadInfo.ArchiveInfo.Hashes),
+			Requested: item.Requested,
+		})
+	}
+	sort.Slice(distributions, func(i, j int) bool {
+		return distributions[i].Id() < distributions[j].Id()
+	})
+	return distributions, nil
+}
+
+func fileNameFromUrl(rawUrl string) (string, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return path.Base(parsedUrl.Path), nil
+}
+
+// Older versions of the report include only the 'hash' field, in the form of <algorithm>=<value>.
+func archiveSha256(hash string, hashes map[string]string) string {
+	if sha256, ok := hashes["sha256"]; ok {
+		return sha256
+	}
+	if strings.HasPrefix(hash, "sha256=") {
+		return strings.TrimPrefix(hash, "sha256=")
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package pip
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"reflect"
+	"testing"
+)
+
+func TestParseInstallReport(t *testing.T) {
+	log.SetDefaultLogger()
+	report := `{
+  "version": "1",
+  "pip_version": "23.1",
+  "install": [
+    {
+      "download_info": {
+        "url": "https://acme.jfrog.io/artifactory/api/pypi/pypi-virtual/packages/packages/ab/cd/urllib3-2.0.2-py3-none-any.whl#sha256=1234",
+        "archive_info": {"hash": "sha256=1234", "hashes": {"sha256": "1234"}}
+      },
+      "requested": false,
+      "metadata": {"name": "urllib3", "version": "2.0.2"}
+    },
+    {
+      "download_info": {
+        "url": "https://acme.jfrog.io/artifactory/api/pypi/pypi-virtual/packages/packages/ef/01/requests-2.31.0.tar.gz",
+        "archive_info": {"hash": "sha256=5678"}
+      },
+      "requested": true,
+      "metadata": {"name": "requests", "version": "2.31.0"}
+    },
+    {
+      "download_info": {"url": "file:///home/user/project", "dir_info": {"editable": true}},
+      "requested": true,
+      "metadata": {"name": "project", "version": "0.1.0"}
+    }
+  ]
+}`
+	actual, err := parseInstallReport([]byte(report))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*InstalledDistribution{
+		{Name: "requests", Version: "2.31.0", FileName: "requests-2.31.0.tar.gz", Sha256: "5678", Requested: true},
+		{Name: "urllib3", Version: "2.0.2", FileName: "urllib3-2.0.2-py3-none-any.whl", Sha256: "1234"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestParseVersionOutput(t *testing.T) {
+	version, err := parseVersionOutput("pip 23.0.1 from /usr/lib/python3/site-packages/pip (python 3.11)\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "23.0.1" {
+		t.Errorf("Expected 23.0.1, got %s", version)
+	}
+	if _, err = parseVersionOutput("Python 3.11"); err == nil {
+		t.Error("Parsing an unexpected output should fail")
+	}
+}
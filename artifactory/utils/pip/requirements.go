@@ -0,0 +1,84 @@
+package pip
+
+import (
+	"bufio"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var directReferenceRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*\s*(\[[^\]]*\])?\s*@`)
+
+// Returns the names of the projects required by a requirements file.
+// Files included by -r are read as well. Other options, editable projects and URLs are skipped.
+func ReadRequirements(requirementsPath string) ([]string, error) {
+	file, err := os.Open(requirementsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+
+	requirements := []string{}
+	scanner := bufio.NewScanner(file)
+	var line string
+	for scanner.Scan() {
+		line += scanner.Text()
+		// Lines ending with a backslash continue in the next line.
+		if strings.HasSuffix(line, "\\") {
+			line = strings.TrimSuffix(line, "\\")
+			continue
+		}
+		current := stripComment(line)
+		line = ""
+		if current == "" {
+			continue
+		}
+		if strings.HasPrefix(current, "-") {
+			includedPath := includedRequirementsPath(current)
+			if includedPath == "" {
+				continue
+			}
+			if !filepath.IsAbs(includedPath) {
+				includedPath = filepath.Join(filepath.Dir(requirementsPath), includedPath)
+			}
+			included, err := ReadRequirements(includedPath)
+			if err != nil {
+				return nil, err
+			}
+			requirements = append(requirements, included...)
+			continue
+		}
+		// URLs are skipped, unless they are direct references of named projects, such as 'name @ https://...'.
+		if strings.Contains(current, "://") && !directReferenceRegexp.MatchString(current) {
+			continue
+		}
+		if name := parseRequirementName(current); name != "" {
+			requirements = append(requirements, name)
+		}
+	}
+	return requirements, errorutils.CheckError(scanner.Err())
+}
+
+// Comments start with a '#' at the beginning of the line, or after a whitespace.
+func stripComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	if index := strings.Index(line, " #"); index >= 0 {
+		line = line[:index]
+	}
+	return strings.TrimSpace(line)
+}
+
+// Returns the path of the requirements file included by the option, such as '-r base.txt' or '--requirement=base.txt'.
+func includedRequirementsPath(option string) string {
+	for _, prefix := range []string{"--requirement", "-r"} {
+		if strings.HasPrefix(option, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(option, prefix), "="))
+		}
+	}
+	return ""
+}
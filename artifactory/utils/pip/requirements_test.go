@@ -0,0 +1,18 @@
+package pip
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadRequirements(t *testing.T) {
+	requirements, err := ReadRequirements(filepath.Join("testdata", "requirements", "requirements.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"idna", "requests", "pip", "Sphinx"}
+	if !reflect.DeepEqual(requirements, expected) {
+		t.Errorf("Expected %s, got %s", expected, requirements)
+	}
+}
@@ -0,0 +1,48 @@
+package pip
+
+import (
+	"errors"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// The version of pip which added the --report option of pip install.
+const MinReportVersion = "22.2"
+
+func Version(executablePath string) (string, error) {
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close()
+	var pipError error
+
+	versionCmdConfig := &PipConfig{
+		Pip:       executablePath,
+		Command:   []string{"--version"},
+		StrWriter: pipeWriter,
+		ErrWriter: nil,
+	}
+	go func() {
+		pipError = gofrogcmd.RunCmd(versionCmdConfig)
+	}()
+
+	data, err := ioutil.ReadAll(pipeReader)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+
+	if pipError != nil {
+		return "", errorutils.CheckError(pipError)
+	}
+	return parseVersionOutput(string(data))
+}
+
+// The output of pip --version looks like: pip 23.0.1 from /usr/lib/python3/site-packages/pip (python 3.11)
+func parseVersionOutput(output string) (string, error) {
+	fields := strings.Fields(output)
+	if len(fields) < 2 || fields[0] != "pip" {
+		return "", errorutils.CheckError(errors.New("Unexpected pip version output: " + output))
+	}
+	return fields[1], nil
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const JournalsDir = "journals"
+
+// Records the files already transferred by an upload or download command, so that an interrupted run can be resumed.
+// The journal is stored under the JFrog home dir, and is keyed by the hash of the command name, the Artifactory URL and the File Spec.
+type TransferJournal struct {
+	path  string
+	mutex sync.Mutex
+	// The key is the source of the transfer - the local path for uploads and the Artifactory path for downloads.
+	Completed map[string]clientutils.FileInfo `json:"completed"`
+}
+
+// Reads the journal of the given command, or creates an empty one if it does not exist.
+func OpenTransferJournal(commandName, artifactoryUrl string, spec interface{}) (*TransferJournal, error) {
+	key, err := getJournalKey(commandName, artifactoryUrl, spec)
+	if err != nil {
+		return nil, err
+	}
+	journalsDir, err := config.CreateDirInJfrogHome(JournalsDir)
+	if err != nil {
+		return nil, err
+	}
+	journal := &TransferJournal{path: filepath.Join(journalsDir, key+".json"), Completed: make(map[string]clientutils.FileInfo)}
+	exists, err := fileutils.IsFileExists(journal.path, false)
+	if err != nil || !exists {
+		return journal, err
+	}
+	content, err := ioutil.ReadFile(journal.path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, journal); err != nil {
+		log.Warn("Ignoring the corrupted transfer journal at", journal.path)
+		journal.Completed = make(map[string]clientutils.FileInfo)
+		return journal, nil
+	}
+	log.Debug("Resuming from the transfer journal at", journal.path)
+	return journal, nil
+}
+
+func getJournalKey(commandName, artifactoryUrl string, spec interface{}) (string, error) {
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	hash := sha256.New()
+	hash.Write([]byte(commandName + "\n" + artifactoryUrl + "\n"))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (tj *TransferJournal) Path() string {
+	return tj.path
+}
+
+// Returns the recorded details of a completed transfer.
+func (tj *TransferJournal) GetCompleted(source string) (clientutils.FileInfo, bool) {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+	fileInfo, ok := tj.Completed[source]
+	return fileInfo, ok
+}
+
+func (tj *TransferJournal) AddCompleted(source string, fileInfo clientutils.FileInfo) {
+	tj.mutex.Lock()
+	defer tj.mutex.Unlock()
+	tj.Completed[source] = fileInfo
+}
+
+// Returns the directory in which the chunks of a split download are kept between runs.
+func (tj *TransferJournal) ChunksDir(sha1 string) (string, error) {
+	chunksDir := filepath.Join(tj.chunksRootDir(), sha1)
+	return chunksDir, errorutils.CheckError(os.MkdirAll(chunksDir, 0700))
+}
+
+func (tj *TransferJournal) chunksRootDir() string {
+	return tj.path[:len(tj.path)-len(filepath.Ext(tj.path))] + "-chunks"
+}
+
+// Writes the journal atomically, so that an interruption never leaves a partially written journal.
+func (tj *TransferJournal) Save() error {
+	tj.mutex.Lock()
+	content, err := json.Marshal(tj)
+	tj.mutex.Unlock()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return ioutils.WriteFileAtomically(tj.path, content)
+}
+
+// Removes the journal and the kept chunks, once all files were transferred successfully.
+func (tj *TransferJournal) Remove() error {
+	if err := os.RemoveAll(tj.chunksRootDir()); err != nil {
+		return errorutils.CheckError(err)
+	}
+	err := os.Remove(tj.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return errorutils.CheckError(err)
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	clientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTransferJournal(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer os.Setenv(cliutils.JfrogHomeDirEnv, os.Getenv(cliutils.JfrogHomeDirEnv))
+	os.Setenv(cliutils.JfrogHomeDirEnv, tempDir)
+
+	spec := map[string]string{"pattern": "a/*", "target": "repo/"}
+	journal, err := OpenTransferJournal("rt_upload", "http://localhost/artifactory/", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileInfo := clientutils.FileInfo{FileHashes: &clientutils.FileHashes{Sha1: "123"}, LocalPath: "a/b", ArtifactoryPath: "repo/a/b"}
+	journal.AddCompleted("a/b", fileInfo)
+	if err = journal.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same command and spec read the saved journal.
+	journal, err = OpenTransferJournal("rt_upload", "http://localhost/artifactory/", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed, ok := journal.GetCompleted("a/b")
+	if !ok || completed.Sha1 != "123" || completed.ArtifactoryPath != "repo/a/b" {
+		t.Error("Expected the completed file to be read from the journal, got:", completed)
+	}
+
+	// A different spec gets an empty journal.
+	otherJournal, err := OpenTransferJournal("rt_upload", "http://localhost/artifactory/", map[string]string{"pattern": "c/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = otherJournal.GetCompleted("a/b"); ok {
+		t.Error("Expected an empty journal for a different spec.")
+	}
+
+	if err = journal.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(journal.Path()); !os.IsNotExist(err) {
+		t.Error("Expected the journal to be removed.")
+	}
+}
@@ -29,4 +29,5 @@ type UploadConfiguration struct {
 	Symlink               bool
 	ExplodeArchive        bool
 	Retries               int
+	Resume                bool
 }
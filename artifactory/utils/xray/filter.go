@@ -0,0 +1,83 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"time"
+)
+
+const ignoreExpiryFormat = "2006-01-02"
+
+// A file of issues which were accepted, and are ignored by the scan, such as:
+// {"ignore": [{"cve": "CVE-2019-10744", "component": "lodash:4.17.11", "expires": "2019-12-31", "reason": "Not exploitable"}]}
+type IgnoreFile struct {
+	Ignore []*IgnoreRule `json:"ignore"`
+}
+
+type IgnoreRule struct {
+	// The CVE, or the summary of an issue which has no CVE.
+	Cve string `json:"cve"`
+	// If set, the issue is ignored in this component only.
+	Component string `json:"component,omitempty"`
+	// The rule applies until the end of this date, in YYYY-MM-DD format. If not set, the rule never expires.
+	Expires string `json:"expires,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	expires time.Time
+}
+
+// Reads the ignore file. Rules which expired before the given time are dropped, with a warning.
+func ReadIgnoreFile(filePath string, now time.Time) ([]*IgnoreRule, error) {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	ignoreFile := new(IgnoreFile)
+	if err = json.Unmarshal(content, ignoreFile); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("Failed parsing the ignore file %s: %s", filePath, err.Error()))
+	}
+	var rules []*IgnoreRule
+	for _, rule := range ignoreFile.Ignore {
+		if rule.Cve == "" {
+			return nil, errorutils.CheckError(fmt.Errorf("The rules of the ignore file %s must include a cve.", filePath))
+		}
+		if rule.Expires != "" {
+			if rule.expires, err = time.Parse(ignoreExpiryFormat, rule.Expires); err != nil {
+				return nil, errorutils.CheckError(fmt.Errorf("The expiry date of %s in the ignore file %s must be in YYYY-MM-DD format.", rule.Cve, filePath))
+			}
+			if !now.Before(rule.expires.AddDate(0, 0, 1)) {
+				log.Warn(fmt.Sprintf("The ignore rule of %s expired on %s, so it is no longer ignored.", rule.Cve, rule.Expires))
+				continue
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (rule *IgnoreRule) Match(violation *Violation) bool {
+	return rule.Cve == violation.IssueId() && (rule.Component == "" || rule.Component == violation.Component)
+}
+
+// Splits the violations into those which are reported, and those which are ignored since their severity is lower than the min severity or they match an ignore rule.
+func FilterViolations(violations []*Violation, minSeverity Severity, rules []*IgnoreRule) (reported, ignored []*Violation) {
+	for _, violation := range violations {
+		if violation.Severity < minSeverity || matchesAny(rules, violation) {
+			ignored = append(ignored, violation)
+			continue
+		}
+		reported = append(reported, violation)
+	}
+	return
+}
+
+func matchesAny(rules []*IgnoreRule, violation *Violation) bool {
+	for _, rule := range rules {
+		if rule.Match(violation) {
+			return true
+		}
+	}
+	return false
+}
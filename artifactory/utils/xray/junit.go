@@ -0,0 +1,99 @@
+package xray
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io/ioutil"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// Creates a JUnit XML report of the scan, with a failed test case per reported violation and a skipped test case per ignored violation.
+// If no violations are reported, the report includes a single passing test case, so that CI systems show the scan as passed.
+func CreateJUnitReport(suiteName string, reported, ignored []*Violation) ([]byte, error) {
+	suite := junitTestSuite{Name: suiteName}
+	for _, violation := range reported {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      violationName(violation),
+			ClassName: suiteName,
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("%s severity %s", violation.Severity, violation.Issue.Type),
+				Type:    violation.Severity.String(),
+				Text:    violationDetails(violation),
+			},
+		})
+	}
+	for _, violation := range ignored {
+		suite.Cases = append(suite.Cases, junitTestCase{Name: violationName(violation), ClassName: suiteName, Skipped: &junitSkipped{Message: "Ignored"}})
+	}
+	if len(reported) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{Name: "Xray scan", ClassName: suiteName})
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Failures = len(reported)
+	suite.Skipped = len(ignored)
+	content, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func WriteJUnitReport(filePath, suiteName string, reported, ignored []*Violation) error {
+	content, err := CreateJUnitReport(suiteName, reported, ignored)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filePath, content, 0644))
+}
+
+func violationName(violation *Violation) string {
+	if violation.Component == "" {
+		return violation.IssueId()
+	}
+	return violation.IssueId() + " in " + violation.Component
+}
+
+func violationDetails(violation *Violation) string {
+	details := []string{"Summary: " + violation.Issue.Summary}
+	if violation.Issue.Description != "" && violation.Issue.Description != violation.Issue.Summary {
+		details = append(details, "Description: "+violation.Issue.Description)
+	}
+	if violation.Path != "" {
+		details = append(details, "Path: "+violation.Path)
+	}
+	if violation.Watch != "" {
+		details = append(details, "Watch: "+violation.Watch)
+	}
+	return strings.Join(details, "\n")
+}
@@ -0,0 +1,95 @@
+package xray
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestCreateJUnitReport(t *testing.T) {
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations := result.Violations()
+	content, err := CreateJUnitReport("b/1", violations[:3], violations[3:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := new(junitTestSuites)
+	if err = xml.Unmarshal(content, report); err != nil {
+		t.Fatal(err)
+	}
+	suite := report.Suites[0]
+	if suite.Tests != 4 || suite.Failures != 3 || suite.Skipped != 1 {
+		t.Errorf("Unexpected suite counts: tests %d, failures %d, skipped %d", suite.Tests, suite.Failures, suite.Skipped)
+	}
+	if suite.Cases[0].Name != "CVE-2 in b:1" || suite.Cases[0].Failure == nil || suite.Cases[3].Skipped == nil {
+		t.Errorf("Unexpected test cases: %v", suite.Cases)
+	}
+
+	// With no violations, a single passing test case is reported.
+	content, err = CreateJUnitReport("b/1", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report = new(junitTestSuites)
+	if err = xml.Unmarshal(content, report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Suites[0].Cases) != 1 || report.Suites[0].Cases[0].Failure != nil {
+		t.Errorf("Expected a single passing test case, got %v", report.Suites[0].Cases)
+	}
+}
+
+func TestCreateSarifReport(t *testing.T) {
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := CreateSarifReport("b/1", result.Violations(), result.Summary.Url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := new(sarifReport)
+	if err = json.Unmarshal(content, report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Version != sarifVersion || len(report.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF report: %s", content)
+	}
+	run := report.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("Expected 3 rules, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(run.Results))
+	}
+	first := run.Results[0]
+	if first.RuleId != "CVE-2" || first.Level != "error" || first.Locations[0].PhysicalLocation.ArtifactLocation.Uri != "libs/b.jar" {
+		t.Errorf("Unexpected first result: %v", first)
+	}
+	if run.Results[3].Level != "note" {
+		t.Errorf("Expected the low severity result to be a note, got %s", run.Results[3].Level)
+	}
+	// A violation with no impacted artifact is located in the build.
+	if location := run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.Uri; location != "b/1" {
+		t.Errorf("Expected the license violation to be located in the build, got %s", location)
+	}
+	if run.Tool.Driver.Rules[0].FullDescription != nil {
+		t.Errorf("Expected no full description for an issue with no description, got %v", run.Tool.Driver.Rules[0].FullDescription)
+	}
+}
+
+func TestViolationsTable(t *testing.T) {
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(ViolationsTable(result.Violations()), "\n")
+	// An issue with no CVE is identified by its summary.
+	if fields := strings.Fields(lines[3]); len(fields) < 3 || fields[0] != "High" || fields[2] != "License" {
+		t.Errorf("Unexpected license violation row: %s", lines[3])
+	}
+}
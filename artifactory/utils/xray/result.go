@@ -0,0 +1,182 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"sort"
+	"strings"
+)
+
+// The result of an Xray build scan.
+type ScanResult struct {
+	Summary  Summary   `json:"summary,omitempty"`
+	Alerts   []Alert   `json:"alerts,omitempty"`
+	Licenses []License `json:"licenses,omitempty"`
+}
+
+type Summary struct {
+	TotalAlerts int    `json:"total_alerts,omitempty"`
+	FailBuild   bool   `json:"fail_build,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Url         string `json:"more_details_url,omitempty"`
+}
+
+// The issues found by an Xray watch.
+type Alert struct {
+	TopSeverity string  `json:"top_severity,omitempty"`
+	WatchName   string  `json:"watch_name,omitempty"`
+	Created     string  `json:"created,omitempty"`
+	Issues      []Issue `json:"issues,omitempty"`
+}
+
+type Issue struct {
+	Severity          string             `json:"severity,omitempty"`
+	Type              string             `json:"type,omitempty"`
+	Provider          string             `json:"provider,omitempty"`
+	Created           string             `json:"created,omitempty"`
+	Summary           string             `json:"summary,omitempty"`
+	Description       string             `json:"description,omitempty"`
+	Cve               string             `json:"cve,omitempty"`
+	ImpactedArtifacts []ImpactedArtifact `json:"impacted_artifacts,omitempty"`
+}
+
+type ImpactedArtifact struct {
+	Name          string         `json:"name,omitempty"`
+	DisplayName   string         `json:"display_name,omitempty"`
+	Path          string         `json:"path,omitempty"`
+	PkgType       string         `json:"pkg_type,omitempty"`
+	Sha256        string         `json:"sha256,omitempty"`
+	Sha1          string         `json:"sha1,omitempty"`
+	Depth         int            `json:"depth,omitempty"`
+	ParentSha     string         `json:"parent_sha,omitempty"`
+	InfectedFiles []InfectedFile `json:"infected_files,omitempty"`
+}
+
+type InfectedFile struct {
+	Name        string `json:"name,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Sha256      string `json:"sha256,omitempty"`
+	Depth       int    `json:"depth,omitempty"`
+	ParentSha   string `json:"parent_sha,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	PkgType     string `json:"pkg_type,omitempty"`
+}
+
+// The licenses of the components of the build.
+type License struct {
+	Name        string   `json:"name,omitempty"`
+	FullName    string   `json:"full_name,omitempty"`
+	MoreInfoUrl []string `json:"more_info_url,omitempty"`
+	Components  []string `json:"components,omitempty"`
+}
+
+func ParseScanResult(content []byte) (*ScanResult, error) {
+	result := new(ScanResult)
+	if err := json.Unmarshal(content, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result, nil
+}
+
+type Severity int
+
+const (
+	Unknown Severity = iota
+	Low
+	Medium
+	High
+	Critical
+)
+
+var severityNames = []string{"Unknown", "Low", "Medium", "High", "Critical"}
+
+// Older Xray versions name the severities Minor and Major.
+var severityAliases = map[string]Severity{"minor": Low, "major": High}
+
+func ParseSeverity(severity string) (Severity, error) {
+	for i, name := range severityNames {
+		if strings.EqualFold(severity, name) {
+			return Severity(i), nil
+		}
+	}
+	if alias, ok := severityAliases[strings.ToLower(severity)]; ok {
+		return alias, nil
+	}
+	return Unknown, errorutils.CheckError(fmt.Errorf("Unsupported severity '%s'. Supported values: %s.", severity, strings.Join(severityNames[Low:], ", ")))
+}
+
+func (severity Severity) String() string {
+	return severityNames[severity]
+}
+
+func (severity Severity) MarshalText() ([]byte, error) {
+	return []byte(severity.String()), nil
+}
+
+// A single issue found in a single component of the build.
+type Violation struct {
+	Severity Severity `json:"severity"`
+	Issue    *Issue   `json:"issue"`
+	// The display name of the impacted artifact, or its name if it has no display name.
+	Component string `json:"component,omitempty"`
+	// The path of the impacted artifact.
+	Path  string `json:"path,omitempty"`
+	Watch string `json:"watch,omitempty"`
+}
+
+// Returns an ID of the issue, which is its CVE or, if it has no CVE, its summary.
+func (violation *Violation) IssueId() string {
+	if violation.Issue.Cve != "" {
+		return violation.Issue.Cve
+	}
+	return violation.Issue.Summary
+}
+
+// Returns a violation for each issue and impacted artifact, sorted by descending severity, issue and component.
+func (result *ScanResult) Violations() []*Violation {
+	var violations []*Violation
+	for i := range result.Alerts {
+		alert := &result.Alerts[i]
+		for j := range alert.Issues {
+			issue := &alert.Issues[j]
+			// Unknown severities are kept as Unknown, rather than failing the scan.
+			severity, _ := ParseSeverity(issue.Severity)
+			if len(issue.ImpactedArtifacts) == 0 {
+				violations = append(violations, &Violation{Severity: severity, Issue: issue, Watch: alert.WatchName})
+			}
+			for _, artifact := range issue.ImpactedArtifacts {
+				component := artifact.DisplayName
+				if component == "" {
+					component = artifact.Name
+				}
+				violations = append(violations, &Violation{Severity: severity, Issue: issue, Component: component, Path: artifact.Path, Watch: alert.WatchName})
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Severity != violations[j].Severity {
+			return violations[i].Severity > violations[j].Severity
+		}
+		if violations[i].IssueId() != violations[j].IssueId() {
+			return violations[i].IssueId() < violations[j].IssueId()
+		}
+		return violations[i].Component < violations[j].Component
+	})
+	return violations
+}
+
+// The JSON output of a build scan - the result of Xray, with the violations reported and ignored after filtering them locally.
+type scanOutput struct {
+	Result   json.RawMessage `json:"result"`
+	Reported []*Violation    `json:"reported"`
+	Ignored  []*Violation    `json:"ignored"`
+}
+
+func CreateJsonOutput(result []byte, reported, ignored []*Violation) ([]byte, error) {
+	output := scanOutput{Result: result, Reported: []*Violation{}, Ignored: []*Violation{}}
+	output.Reported = append(output.Reported, reported...)
+	output.Ignored = append(output.Ignored, ignored...)
+	content, err := json.MarshalIndent(output, "", "  ")
+	return content, errorutils.CheckError(err)
+}
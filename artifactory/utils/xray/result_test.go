@@ -0,0 +1,133 @@
+package xray
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const scanResultJson = `{
+  "summary": {"total_alerts": 1, "fail_build": true, "message": "Build failed", "more_details_url": "http://xray/builds/b/1"},
+  "alerts": [{
+    "top_severity": "Critical",
+    "watch_name": "watch",
+    "issues": [
+      {"severity": "Minor", "type": "security", "summary": "Low issue", "cve": "CVE-1", "impacted_artifacts": [{"name": "a", "display_name": "a:1"}]},
+      {"severity": "Critical", "type": "security", "summary": "Critical issue", "cve": "CVE-2", "impacted_artifacts": [{"name": "b", "display_name": "b:1", "path": "libs/b.jar"}, {"name": "c", "display_name": "c:1"}]},
+      {"severity": "Major", "type": "license", "summary": "License issue"}
+    ]
+  }],
+  "licenses": [{"name": "MIT", "components": ["a:1", "b:1"]}]
+}`
+
+func TestViolations(t *testing.T) {
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations := result.Violations()
+	expected := []struct {
+		severity  Severity
+		id        string
+		component string
+	}{
+		{Critical, "CVE-2", "b:1"},
+		{Critical, "CVE-2", "c:1"},
+		{High, "License issue", ""},
+		{Low, "CVE-1", "a:1"},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %d", len(expected), len(violations))
+	}
+	for i, violation := range violations {
+		if violation.Severity != expected[i].severity || violation.IssueId() != expected[i].id || violation.Component != expected[i].component {
+			t.Errorf("Violation %d: expected %v, got %s %s %s", i, expected[i], violation.Severity, violation.IssueId(), violation.Component)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := map[string]Severity{"low": Low, "Minor": Low, "MEDIUM": Medium, "major": High, "critical": Critical}
+	for name, expected := range tests {
+		severity, err := ParseSeverity(name)
+		if err != nil {
+			t.Error(err)
+		}
+		if severity != expected {
+			t.Errorf("Severity %s: expected %s, got %s", name, expected, severity)
+		}
+	}
+	if _, err := ParseSeverity("severe"); err == nil {
+		t.Error("Expected an error for an unsupported severity")
+	}
+}
+
+func TestFilterViolations(t *testing.T) {
+	log.SetDefaultLogger()
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tempDir, err := ioutil.TempDir("", "xray")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	ignoreFile := filepath.Join(tempDir, "ignore.json")
+	content := `{"ignore": [
+		{"cve": "CVE-2", "component": "c:1", "expires": "2019-12-31"},
+		{"cve": "License issue", "expires": "2019-11-30"}
+	]}`
+	if err = ioutil.WriteFile(ignoreFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The second rule expired the day before.
+	rules, err := ReadIgnoreFile(ignoreFile, time.Date(2019, 12, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(rules))
+	}
+
+	reported, ignored := FilterViolations(result.Violations(), Medium, rules)
+	if len(reported) != 2 || reported[0].Component != "b:1" || reported[1].IssueId() != "License issue" {
+		t.Errorf("Unexpected reported violations: %v", reported)
+	}
+	if len(ignored) != 2 {
+		t.Errorf("Expected 2 ignored violations, got %d", len(ignored))
+	}
+}
+
+func TestCreateJsonOutput(t *testing.T) {
+	result, err := ParseScanResult([]byte(scanResultJson))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations := result.Violations()
+	content, err := CreateJsonOutput([]byte(scanResultJson), violations[:1], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := new(struct {
+		Result   *ScanResult
+		Reported []map[string]interface{}
+		Ignored  []map[string]interface{}
+	})
+	if err = json.Unmarshal(content, output); err != nil {
+		t.Fatal(err)
+	}
+	if output.Result.Summary.Message != "Build failed" {
+		t.Errorf("Expected the result of Xray in the output, got %+v", output.Result)
+	}
+	if len(output.Reported) != 1 || output.Reported[0]["severity"] != "Critical" || output.Reported[0]["component"] != "b:1" {
+		t.Errorf("Unexpected reported violations: %v", output.Reported)
+	}
+	if output.Ignored == nil || len(output.Ignored) != 0 {
+		t.Errorf("Expected an empty list of ignored violations, got %v", output.Ignored)
+	}
+}
@@ -0,0 +1,114 @@
+package xray
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io/ioutil"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "JFrog Xray"
+)
+
+type sarifReport struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string        `json:"id"`
+	ShortDescription sarifMessage  `json:"shortDescription"`
+	FullDescription  *sarifMessage `json:"fullDescription,omitempty"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// Creates a SARIF 2.1.0 report of the reported violations, with a rule per issue and a result per violation.
+// The location of a violation is its impacted artifact or, if it has none, the scanned build.
+func CreateSarifReport(build string, reported []*Violation, moreDetailsUrl string) ([]byte, error) {
+	driver := sarifDriver{Name: toolName, InformationUri: moreDetailsUrl, Rules: []sarifRule{}}
+	results := []sarifResult{}
+	rules := make(map[string]bool)
+	for _, violation := range reported {
+		ruleId := violation.IssueId()
+		if !rules[ruleId] {
+			rules[ruleId] = true
+			rule := sarifRule{Id: ruleId, ShortDescription: sarifMessage{Text: violation.Issue.Summary}}
+			if violation.Issue.Description != "" {
+				rule.FullDescription = &sarifMessage{Text: violation.Issue.Description}
+			}
+			driver.Rules = append(driver.Rules, rule)
+		}
+		result := sarifResult{RuleId: ruleId, Level: sarifLevel(violation.Severity), Message: sarifMessage{Text: violationName(violation) + ": " + violation.Issue.Summary}}
+		location := violation.Path
+		if location == "" {
+			location = violation.Component
+		}
+		if location == "" {
+			location = build
+		}
+		result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: location}}}}
+		results = append(results, result)
+	}
+	report := sarifReport{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}}}
+	content, err := json.MarshalIndent(report, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+func WriteSarifReport(filePath, build string, reported []*Violation, moreDetailsUrl string) error {
+	content, err := CreateSarifReport(build, reported, moreDetailsUrl)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filePath, content, 0644))
+}
+
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case Critical, High:
+		return "error"
+	case Medium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,41 @@
+package xray
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+const maxSummaryLength = 80
+
+// Renders the violations as a table, sorted by descending severity.
+func ViolationsTable(violations []*Violation) string {
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SEVERITY\tTYPE\tISSUE\tCOMPONENT\tWATCH\tSUMMARY")
+	for _, violation := range violations {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", violation.Severity, violation.Issue.Type, violation.IssueId(), violation.Component, violation.Watch, truncate(violation.Issue.Summary))
+	}
+	writer.Flush()
+	return table.String()
+}
+
+// Renders the licenses of the build's components as a table.
+func LicensesTable(licenses []License) string {
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "LICENSE\tCOMPONENTS")
+	for _, license := range licenses {
+		fmt.Fprintf(writer, "%s\t%d\n", license.Name, len(license.Components))
+	}
+	writer.Flush()
+	return table.String()
+}
+
+func truncate(summary string) string {
+	summary = strings.Join(strings.Fields(summary), " ")
+	if len(summary) <= maxSummaryLength {
+		return summary
+	}
+	return summary[:maxSummaryLength-3] + "..."
+}
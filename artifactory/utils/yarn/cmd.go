@@ -0,0 +1,32 @@
+package yarn
+
+import (
+	"io"
+	"os/exec"
+)
+
+func (config *YarnConfig) GetCmd() *exec.Cmd {
+	var cmd []string
+	cmd = append(cmd, config.Yarn)
+	cmd = append(cmd, config.Command...)
+	return exec.Command(cmd[0], cmd[1:]...)
+}
+
+func (config *YarnConfig) GetEnv() map[string]string {
+	return map[string]string{}
+}
+
+func (config *YarnConfig) GetStdWriter() io.WriteCloser {
+	return config.StrWriter
+}
+
+func (config *YarnConfig) GetErrWriter() io.WriteCloser {
+	return config.ErrWriter
+}
+
+type YarnConfig struct {
+	Yarn      string
+	Command   []string
+	StrWriter io.WriteCloser
+	ErrWriter io.WriteCloser
+}
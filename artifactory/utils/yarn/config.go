@@ -0,0 +1,71 @@
+package yarn
+
+import (
+	"fmt"
+	"strings"
+)
+
+const ClassicConfigFileName = ".yarnrc"
+const BerryConfigFileName = ".yarnrc.yml"
+
+// The .yarnrc.yml keys which are overridden in order to resolve packages from Artifactory.
+var berryRegistryKeys = []string{"npmRegistryServer", "npmAlwaysAuth", "npmAuthIdent", "npmAuthToken"}
+
+// Returns the value of _auth from npm auth configuration in .npmrc format, such as "_auth = xyz\nalways-auth = true".
+func ExtractAuth(npmAuth string) string {
+	for _, line := range strings.Split(npmAuth, "\n") {
+		keyVal := strings.SplitN(line, "=", 2)
+		if len(keyVal) == 2 && strings.TrimSpace(keyVal[0]) == "_auth" {
+			return strings.TrimSpace(keyVal[1])
+		}
+	}
+	return ""
+}
+
+// Adds the registry and its auth to the content of a Yarn Classic .yarnrc file.
+// Settings which appear later in the file override earlier ones.
+func CreateClassicConfig(content []byte, registry, auth string) []byte {
+	var config []string
+	if existing := strings.TrimSpace(string(content)); existing != "" {
+		config = append(config, existing)
+	}
+	config = append(config, fmt.Sprintf("registry %q", registry))
+	if auth != "" {
+		// Auth is scoped to the registry, which Yarn Classic refers to without its protocol, and with a trailing slash.
+		scope := registry
+		if index := strings.Index(registry, "//"); index >= 0 {
+			scope = registry[index:]
+		}
+		if !strings.HasSuffix(scope, "/") {
+			scope += "/"
+		}
+		config = append(config, fmt.Sprintf("%q %q", scope+":_auth", auth), fmt.Sprintf("%q true", scope+":always-auth"))
+	}
+	return []byte(strings.Join(config, "\n") + "\n")
+}
+
+// Adds the registry and its auth to the content of a Yarn Berry .yarnrc.yml file.
+// Duplicate keys are not allowed in YAML, so the registry settings of the existing file are removed.
+func CreateBerryConfig(content []byte, registry, auth string) []byte {
+	var config []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if line != "" && !isBerryRegistryLine(line) {
+			config = append(config, line)
+		}
+	}
+	config = append(config, fmt.Sprintf("npmRegistryServer: %q", registry))
+	if auth != "" {
+		config = append(config, "npmAlwaysAuth: true", fmt.Sprintf("npmAuthIdent: %q", auth))
+	}
+	return []byte(strings.Join(config, "\n") + "\n")
+}
+
+// Returns true if the line sets one of the top level registry keys.
+func isBerryRegistryLine(line string) bool {
+	for _, key := range berryRegistryKeys {
+		if strings.HasPrefix(line, key+":") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package yarn
+
+import "testing"
+
+func TestExtractAuth(t *testing.T) {
+	tests := map[string]string{
+		"_auth = YWRtaW46cGFzc3dvcmQ=\nalways-auth = true": "YWRtaW46cGFzc3dvcmQ=",
+		"_auth=YWRtaW46cGFzc3dvcmQ=\nemail = a@b.c":        "YWRtaW46cGFzc3dvcmQ=",
+		"always-auth = true":                               "",
+	}
+	for npmAuth, expected := range tests {
+		if actual := ExtractAuth(npmAuth); actual != expected {
+			t.Errorf("ExtractAuth(%q) => '%s', want '%s'", npmAuth, actual, expected)
+		}
+	}
+}
+
+func TestCreateClassicConfig(t *testing.T) {
+	actual := CreateClassicConfig([]byte("network-timeout 600000\n"), "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "YWRtaW46cGFzc3dvcmQ=")
+	expected := `network-timeout 600000
+registry "https://acme.jfrog.io/artifactory/api/npm/npm-virtual"
+"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_auth" "YWRtaW46cGFzc3dvcmQ="
+"//acme.jfrog.io/artifactory/api/npm/npm-virtual/:always-auth" true
+`
+	if string(actual) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestCreateBerryConfig(t *testing.T) {
+	existing := `yarnPath: .yarn/releases/yarn-3.2.0.cjs
+npmRegistryServer: "https://registry.yarnpkg.com"
+npmScopes:
+  acme:
+    npmRegistryServer: "https://npm.acme.com"
+`
+	actual := CreateBerryConfig([]byte(existing), "https://acme.jfrog.io/artifactory/api/npm/npm-virtual", "YWRtaW46cGFzc3dvcmQ=")
+	expected := `yarnPath: .yarn/releases/yarn-3.2.0.cjs
+npmScopes:
+  acme:
+    npmRegistryServer: "https://npm.acme.com"
+npmRegistryServer: "https://acme.jfrog.io/artifactory/api/npm/npm-virtual"
+npmAlwaysAuth: true
+npmAuthIdent: "YWRtaW46cGFzc3dvcmQ="
+`
+	if string(actual) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
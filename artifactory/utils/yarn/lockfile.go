@@ -0,0 +1,264 @@
+package yarn
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const LockfileName = "yarn.lock"
+
+// A package resolved in yarn.lock.
+type Package struct {
+	Name    string
+	Version string
+	// The sha1 checksum of the package tarball. Only Yarn Classic lockfiles record it.
+	Sha1 string
+	// The dependencies of the package, mapped to their version ranges.
+	Dependencies map[string]string
+}
+
+func (p *Package) Id() string {
+	return p.Name + ":" + p.Version
+}
+
+// The packages of a yarn.lock file, mapped by the descriptors which resolve to them, such as lodash@^4.17.0.
+type Lockfile struct {
+	descriptors map[string]*Package
+	packages    map[string]*Package
+}
+
+func ReadLockfile(projectDir string) (*Lockfile, error) {
+	log.Debug("Reading", filepath.Join(projectDir, LockfileName))
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, LockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+// Parses a yarn.lock file of Yarn Classic or of Yarn Berry.
+// Packages which are not resolved from a registry, such as workspaces, links and patches, are skipped.
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := &Lockfile{descriptors: make(map[string]*Package), packages: make(map[string]*Package)}
+	var err error
+	// Yarn Berry lockfiles are YAML documents, which start with their metadata.
+	if bytes.Contains(content, []byte("\n__metadata:")) || bytes.HasPrefix(content, []byte("__metadata:")) {
+		err = lockfile.parseBerry(content)
+	} else {
+		err = lockfile.parseClassic(content)
+	}
+	return lockfile, err
+}
+
+// Returns the packages of the lockfile, sorted by their IDs.
+func (lf *Lockfile) Packages() []*Package {
+	var packages []*Package
+	for _, pkg := range lf.packages {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Id() < packages[j].Id()
+	})
+	return packages
+}
+
+// Returns the package which the dependency name and version range resolve to, or nil if the lockfile doesn't contain it.
+func (lf *Lockfile) Resolve(name, versionRange string) *Package {
+	if pkg, ok := lf.descriptors[name+"@"+versionRange]; ok {
+		return pkg
+	}
+	// Yarn Berry adds the default npm: protocol to the descriptors.
+	return lf.descriptors[name+"@npm:"+versionRange]
+}
+
+// Returns the scopes of the packages which the project depends on, directly or transitively.
+// The scope of a package is production if a production dependency of the project requires it, and development if a development dependency does.
+func (lf *Lockfile) Scopes(production, development map[string]string) map[*Package][]string {
+	scopes := make(map[*Package][]string)
+	lf.addScope(production, "production", scopes)
+	lf.addScope(development, "development", scopes)
+	return scopes
+}
+
+func (lf *Lockfile) addScope(dependencies map[string]string, scope string, scopes map[*Package][]string) {
+	for name, versionRange := range dependencies {
+		pkg := lf.Resolve(name, versionRange)
+		if pkg == nil {
+			continue
+		}
+		packageScopes := scopes[pkg]
+		if len(packageScopes) > 0 && packageScopes[len(packageScopes)-1] == scope {
+			continue
+		}
+		scopes[pkg] = append(packageScopes, scope)
+		lf.addScope(pkg.Dependencies, scope, scopes)
+	}
+}
+
+func (lf *Lockfile) add(descriptors []string, pkg *Package) {
+	if existing, ok := lf.packages[pkg.Id()]; ok {
+		pkg = existing
+	} else {
+		lf.packages[pkg.Id()] = pkg
+	}
+	for _, descriptor := range descriptors {
+		lf.descriptors[descriptor] = pkg
+	}
+}
+
+func (lf *Lockfile) parseClassic(content []byte) error {
+	var descriptors []string
+	var pkg *Package
+	var dependencies map[string]string
+	addCurrent := func() {
+		if pkg != nil && pkg.Version != "" && isRegistryDescriptor(descriptors[0]) {
+			lf.add(descriptors, pkg)
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			addCurrent()
+			descriptors = nil
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				descriptors = append(descriptors, unquote(strings.TrimSpace(descriptor)))
+			}
+			name, versionRange := splitDescriptor(descriptors[0])
+			// An alias, such as alias@npm:name@^1.0.0, resolves to the aliased package.
+			if aliased := strings.TrimPrefix(versionRange, "npm:"); aliased != versionRange && len(aliased) > 1 && strings.Contains(aliased[1:], "@") {
+				name, _ = splitDescriptor(aliased)
+			}
+			pkg = &Package{Name: name, Dependencies: make(map[string]string)}
+			dependencies = nil
+		case pkg == nil:
+			continue
+		case indent == 2:
+			key, value := splitClassicField(trimmed)
+			dependencies = nil
+			switch key {
+			case "version":
+				pkg.Version = value
+			case "resolved":
+				if index := strings.LastIndex(value, "#"); index >= 0 {
+					pkg.Sha1 = value[index+1:]
+				}
+			case "dependencies:", "optionalDependencies:":
+				dependencies = pkg.Dependencies
+			}
+		case dependencies != nil:
+			key, value := splitClassicField(trimmed)
+			dependencies[key] = value
+		}
+	}
+	addCurrent()
+	return errorutils.CheckError(scanner.Err())
+}
+
+type berryEntry struct {
+	Version      string            `yaml:"version"`
+	Resolution   string            `yaml:"resolution"`
+	Dependencies map[string]string `yaml:"dependencies"`
+}
+
+func (lf *Lockfile) parseBerry(content []byte) error {
+	entries := make(map[string]berryEntry)
+	if err := yaml.Unmarshal(content, &entries); err != nil {
+		return errorutils.CheckError(err)
+	}
+	for key, entry := range entries {
+		if key == "__metadata" || entry.Version == "" || entry.Resolution == "" || !isRegistryDescriptor(entry.Resolution) {
+			continue
+		}
+		var descriptors []string
+		for _, descriptor := range strings.Split(key, ",") {
+			descriptors = append(descriptors, strings.TrimSpace(descriptor))
+		}
+		name, _ := splitDescriptor(entry.Resolution)
+		dependencies := entry.Dependencies
+		if dependencies == nil {
+			dependencies = make(map[string]string)
+		}
+		lf.add(descriptors, &Package{Name: name, Version: entry.Version, Dependencies: dependencies})
+	}
+	return nil
+}
+
+// Splits a descriptor such as @scope/name@^1.0.0 into the package name and the version range.
+func splitDescriptor(descriptor string) (name, versionRange string) {
+	if descriptor == "" {
+		return "", ""
+	}
+	index := strings.Index(descriptor[1:], "@")
+	if index < 0 {
+		return descriptor, ""
+	}
+	return descriptor[:index+1], descriptor[index+2:]
+}
+
+// Descriptors of packages which are not downloaded from the npm registry use protocols such as workspace:, link: or file:.
+func isRegistryDescriptor(descriptor string) bool {
+	_, versionRange := splitDescriptor(descriptor)
+	if strings.HasPrefix(versionRange, "npm:") {
+		return true
+	}
+	return !strings.Contains(versionRange, ":") && !strings.Contains(versionRange, "/")
+}
+
+// Splits a Yarn Classic lockfile field, such as 'version "1.0.0"', into its key and value.
+func splitClassicField(field string) (key, value string) {
+	if strings.HasPrefix(field, `"`) {
+		if index := strings.Index(field[1:], `"`); index >= 0 {
+			return field[1 : index+1], unquote(strings.TrimSpace(field[index+2:]))
+		}
+	}
+	fields := strings.SplitN(field, " ", 2)
+	if len(fields) == 1 {
+		return fields[0], ""
+	}
+	return fields[0], unquote(strings.TrimSpace(fields[1]))
+}
+
+func unquote(value string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(value, `"`), `"`)
+}
+
+type projectDependencies struct {
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+// Returns the production and development dependencies declared in the package.json of the project, mapped to their version ranges.
+func ReadProjectDependencies(projectDir string) (production, development map[string]string, err error) {
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	project := new(projectDependencies)
+	if err = json.Unmarshal(content, project); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	production = make(map[string]string)
+	for name, versionRange := range project.Dependencies {
+		production[name] = versionRange
+	}
+	for name, versionRange := range project.OptionalDependencies {
+		production[name] = versionRange
+	}
+	return production, project.DevDependencies, nil
+}
@@ -0,0 +1,132 @@
+package yarn
+
+import (
+	"reflect"
+	"testing"
+)
+
+const classicLockfile = `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+  version "7.10.4"
+  resolved "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/@babel/code-frame/-/code-frame-7.10.4.tgz#168da1a36e90da68ae8d49c0f1b48c7c6249213a"
+  integrity sha512-vG6SvB6oYEhvgisZNFRmRCUkLz11c7rp+tbNTynGqc6mS1d5ATd/sGyV6W0KZZnXRKMTzZDRgQT3Ou9jhpAfUg==
+  dependencies:
+    "@babel/highlight" "^7.10.4"
+
+"@babel/highlight@^7.10.4":
+  version "7.10.4"
+  resolved "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/@babel/highlight/-/highlight-7.10.4.tgz#7d1bdfd65753538fabe6c38596cdb76d9ac60143"
+  dependencies:
+    js-tokens "^4.0.0"
+
+js-tokens@^4.0.0:
+  version "4.0.0"
+  resolved "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/js-tokens/-/js-tokens-4.0.0.tgz#19203fb59991df98e3a287050d4647cdeaf32499"
+
+local-lib@file:../local-lib:
+  version "1.0.0"
+
+tokens@npm:js-tokens@^4.0.0:
+  version "4.0.0"
+  resolved "https://acme.jfrog.io/artifactory/api/npm/npm-virtual/js-tokens/-/js-tokens-4.0.0.tgz#19203fb59991df98e3a287050d4647cdeaf32499"
+`
+
+const berryLockfile = `# This file is generated by running "yarn install" inside your project.
+# Manual changes might be lost - proceed with caution!
+
+__metadata:
+  version: 4
+  cacheKey: 7
+
+"@babel/code-frame@npm:^7.0.0, @babel/code-frame@npm:^7.10.4":
+  version: 7.10.4
+  resolution: "@babel/code-frame@npm:7.10.4"
+  dependencies:
+    "@babel/highlight": ^7.10.4
+  checksum: feb4543c8a509fe30f0f6e8d7aa84f82b41148b963b826cd330e34986f649a85cb63b2f13dd4effdf434ac555d16f14940b8ea5f4433297c2f5ff85486ded019
+  languageName: node
+  linkType: hard
+
+"@babel/highlight@npm:^7.10.4":
+  version: 7.10.4
+  resolution: "@babel/highlight@npm:7.10.4"
+  dependencies:
+    js-tokens: ^4.0.0
+  languageName: node
+  linkType: hard
+
+"app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "app@workspace:."
+  dependencies:
+    "@babel/code-frame": ^7.0.0
+  languageName: unknown
+  linkType: soft
+
+"js-tokens@npm:^4.0.0":
+  version: 4.0.0
+  resolution: "js-tokens@npm:4.0.0"
+  languageName: node
+  linkType: hard
+`
+
+func TestParseLockfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		sha1     string
+	}{
+		{"classic", classicLockfile, []string{"@babel/code-frame:7.10.4", "@babel/highlight:7.10.4", "js-tokens:4.0.0"}, "168da1a36e90da68ae8d49c0f1b48c7c6249213a"},
+		{"berry", berryLockfile, []string{"@babel/code-frame:7.10.4", "@babel/highlight:7.10.4", "js-tokens:4.0.0"}, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lockfile, err := ParseLockfile([]byte(test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, pkg := range lockfile.Packages() {
+				ids = append(ids, pkg.Id())
+			}
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Errorf("Expected packages %v, got %v", test.expected, ids)
+			}
+			pkg := lockfile.Resolve("@babel/code-frame", "^7.10.4")
+			if pkg == nil {
+				t.Fatal("Expected @babel/code-frame@^7.10.4 to be resolved")
+			}
+			if pkg.Sha1 != test.sha1 {
+				t.Errorf("Expected sha1 '%s', got '%s'", test.sha1, pkg.Sha1)
+			}
+			if pkg.Dependencies["@babel/highlight"] != "^7.10.4" {
+				t.Errorf("Unexpected dependencies %v", pkg.Dependencies)
+			}
+		})
+	}
+}
+
+func TestLockfileScopes(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(classicLockfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopes := lockfile.Scopes(map[string]string{"@babel/code-frame": "^7.0.0"}, map[string]string{"js-tokens": "^4.0.0", "missing": "^1.0.0"})
+	expected := map[string][]string{
+		"@babel/code-frame:7.10.4": {"production"},
+		"@babel/highlight:7.10.4":  {"production"},
+		"js-tokens:4.0.0":          {"production", "development"},
+	}
+	if len(scopes) != len(expected) {
+		t.Errorf("Expected %d packages with scopes, got %d", len(expected), len(scopes))
+	}
+	for pkg, packageScopes := range scopes {
+		if !reflect.DeepEqual(packageScopes, expected[pkg.Id()]) {
+			t.Errorf("Expected the scopes of %s to be %v, got %v", pkg.Id(), expected[pkg.Id()], packageScopes)
+		}
+	}
+}
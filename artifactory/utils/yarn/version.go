@@ -0,0 +1,40 @@
+package yarn
+
+import (
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+func Version(executablePath string) (string, error) {
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close()
+	var yarnError error
+
+	versionCmdConfig := &YarnConfig{
+		Yarn:      executablePath,
+		Command:   []string{"--version"},
+		StrWriter: pipeWriter,
+		ErrWriter: nil,
+	}
+	go func() {
+		yarnError = gofrogcmd.RunCmd(versionCmdConfig)
+	}()
+
+	data, err := ioutil.ReadAll(pipeReader)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+
+	if yarnError != nil {
+		return "", errorutils.CheckError(yarnError)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Yarn 2 and above (Yarn Berry) are configured by .yarnrc.yml, while Yarn 1 (Yarn Classic) is configured by .yarnrc.
+func IsClassic(yarnVersion string) bool {
+	return strings.HasPrefix(yarnVersion, "0.") || strings.HasPrefix(yarnVersion, "1.")
+}
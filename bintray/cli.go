@@ -9,6 +9,7 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/jfrog/jfrog-cli-go/bintray/commands"
+	"github.com/jfrog/jfrog-cli-go/bintray/helpers"
 	accesskeysdoc "github.com/jfrog/jfrog-cli-go/docs/bintray/accesskeys"
 	configdocs "github.com/jfrog/jfrog-cli-go/docs/bintray/config"
 	"github.com/jfrog/jfrog-cli-go/docs/bintray/downloadfile"
@@ -310,6 +311,41 @@ func getStreamFlags() []cli.Flag {
 			Value: "",
 			Usage: "[Optional] List of events type in the form of \"value1;value2;...\" leave empty to include all.` `",
 		},
+		cli.StringFlag{
+			Name:  "filter",
+			Value: "",
+			Usage: "[Optional] List of event field predicates in the form of \".field.path==value;...\". Only events matching all the predicates are included. The supported operators are ==, !=, <, <=, >, >= and =~ for regular expressions. Values are JSON, such as \".type==\\\"download\\\"\".` `",
+		},
+		cli.StringFlag{
+			Name:  "checkpoint-file",
+			Value: "",
+			Usage: "[Optional] Path to a file in which the reconnect ID and the time of the last event are saved. A restarted stream resumes from the checkpoint. Events are delivered at least once, so an event written right before the stream stopped may be written again.` `",
+		},
+		cli.StringFlag{
+			Name:  "output-file",
+			Value: "",
+			Usage: "[Optional] Path to a file to which the events are appended, instead of the standard output.` `",
+		},
+		cli.StringFlag{
+			Name:  "output-file-max-size",
+			Value: "",
+			Usage: "[Default: 100] Max size in MB of the output file. Once reached, the file is rotated.` `",
+		},
+		cli.StringFlag{
+			Name:  "output-file-max-backups",
+			Value: "",
+			Usage: "[Default: 5] Number of rotated output files to keep.` `",
+		},
+		cli.StringFlag{
+			Name:  "exec",
+			Value: "",
+			Usage: "[Optional] Command to run for each event, with the event as its standard input, instead of writing the events to the standard output.` `",
+		},
+		cli.StringFlag{
+			Name:  "webhook",
+			Value: "",
+			Usage: "[Optional] URL to which each event is posted, instead of writing the events to the standard output.` `",
+		},
 	}...)
 }
 
@@ -898,8 +934,52 @@ func stream(c *cli.Context) {
 		BintrayDetails: bintrayDetails,
 		Subject:        c.Args().Get(0),
 		Include:        c.String("include"),
+		Filter:         c.String("filter"),
+		CheckpointFile: c.String("checkpoint-file"),
+	}
+	sink, err := createStreamSink(c)
+	cliutils.ExitOnErr(err)
+	err = commands.Stream(streamDetails, sink)
+	sink.Close()
+	cliutils.ExitOnErr(err)
+}
+
+// The events are written to the standard output, unless other sinks are set.
+func createStreamSink(c *cli.Context) (helpers.Sink, error) {
+	var sinks helpers.MultiSink
+	if c.String("output-file") != "" {
+		maxSize, err := cliutils.GetIntFlagValue(c, "output-file-max-size", 100)
+		if err != nil {
+			return nil, err
+		}
+		maxBackups, err := cliutils.GetIntFlagValue(c, "output-file-max-backups", 5)
+		if err != nil {
+			return nil, err
+		}
+		fileSink, err := helpers.NewFileSink(c.String("output-file"), int64(maxSize)*1024*1024, maxBackups)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, fileSink)
+	}
+	if c.String("exec") != "" {
+		execSink, err := helpers.NewExecSink(c.String("exec"))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, execSink)
+	}
+	if c.String("webhook") != "" {
+		webhookSink, err := helpers.NewWebhookSink(c.String("webhook"))
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, webhookSink)
+	}
+	if len(sinks) == 0 {
+		return helpers.NewWriterSink(os.Stdout), nil
 	}
-	commands.Stream(streamDetails, os.Stdout)
+	return sinks, nil
 }
 
 func gpgSignVersion(c *cli.Context) {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-go/bintray/helpers"
 	"github.com/jfrog/jfrog-client-go/bintray/auth"
-	"io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"net/http"
 	"strings"
 	"time"
@@ -15,12 +15,18 @@ const timeout = 90
 const timeoutDuration = timeout * time.Second
 const onErrorReconnectDuration = 3 * time.Second
 
-func Stream(streamDetails *StreamDetails, writer io.Writer) {
+// Writes the events of the stream to the sink. Returns only if the stream can't be started, or if an event couldn't be written to the sink.
+// In the latter case, the event isn't saved to the checkpoint, so it is sent again once the stream is resumed from the checkpoint.
+func Stream(streamDetails *StreamDetails, sink helpers.Sink) error {
 	var resp *http.Response
 	var connected bool
 	lastServerInteraction := time.Now()
-	streamManager := createStreamManager(streamDetails)
+	streamManager, err := createStreamManager(streamDetails)
+	if err != nil {
+		return err
+	}
 
+	sinkErr := make(chan error, 1)
 	go func() {
 		for {
 			connected = false
@@ -32,26 +38,46 @@ func Stream(streamDetails *StreamDetails, writer io.Writer) {
 			}
 			lastServerInteraction = time.Now()
 			connected = true
-			streamManager.ReadStream(resp, writer, &lastServerInteraction)
+			if err := streamManager.ReadStream(resp, sink, &lastServerInteraction); err != nil {
+				resp.Body.Close()
+				sinkErr <- err
+				return
+			}
 		}
 	}()
 
 	for true {
 		if !connected {
-			time.Sleep(timeoutDuration)
+			if err = sleepUnlessFailed(timeoutDuration, sinkErr); err != nil {
+				return err
+			}
 			continue
 		}
 		if time.Since(lastServerInteraction) < timeoutDuration {
-			time.Sleep(timeoutDuration - time.Now().Sub(lastServerInteraction))
+			if err = sleepUnlessFailed(timeoutDuration-time.Now().Sub(lastServerInteraction), sinkErr); err != nil {
+				return err
+			}
 			continue
 		}
 		if resp != nil {
 			resp.Body.Close()
-			time.Sleep(timeoutDuration)
+			if err = sleepUnlessFailed(timeoutDuration, sinkErr); err != nil {
+				return err
+			}
 			continue
 		}
 	}
-	return
+	return nil
+}
+
+// Sleeps for the duration. Returns early with the error if writing to the sink failed meanwhile.
+func sleepUnlessFailed(duration time.Duration, sinkErr chan error) error {
+	select {
+	case err := <-sinkErr:
+		return err
+	case <-time.After(duration):
+		return nil
+	}
 }
 
 func buildIncludeFilterMap(filterPattern string) map[string]struct{} {
@@ -67,15 +93,34 @@ func buildIncludeFilterMap(filterPattern string) map[string]struct{} {
 	return result
 }
 
-func createStreamManager(streamDetails *StreamDetails) *helpers.StreamManager {
-	return &helpers.StreamManager{
+func createStreamManager(streamDetails *StreamDetails) (*helpers.StreamManager, error) {
+	fieldFilters, err := helpers.ParseFieldFilters(streamDetails.Filter)
+	if err != nil {
+		return nil, err
+	}
+	streamManager := &helpers.StreamManager{
 		Url:               fmt.Sprintf(streamUrl, streamDetails.BintrayDetails.GetApiUrl(), streamDetails.Subject),
 		HttpClientDetails: streamDetails.BintrayDetails.CreateHttpClientDetails(),
-		IncludeFilter:     buildIncludeFilterMap(streamDetails.Include)}
+		IncludeFilter:     buildIncludeFilterMap(streamDetails.Include),
+		FieldFilters:      fieldFilters}
+	if streamDetails.CheckpointFile == "" {
+		return streamManager, nil
+	}
+	if streamManager.Checkpoint, err = helpers.LoadCheckpoint(streamDetails.CheckpointFile); err != nil {
+		return nil, err
+	}
+	if streamManager.Checkpoint.ReconnectId != "" {
+		log.Info("Resuming the stream from the checkpoint at", streamDetails.CheckpointFile)
+		streamManager.ReconnectId = streamManager.Checkpoint.ReconnectId
+	}
+	return streamManager, nil
 }
 
 type StreamDetails struct {
 	BintrayDetails auth.BintrayDetails
 	Subject        string
 	Include        string
+	// Field filters in the form of ".field.path==value;...".
+	Filter         string
+	CheckpointFile string
 }
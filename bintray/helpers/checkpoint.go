@@ -0,0 +1,111 @@
+package helpers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"time"
+)
+
+// The field of the stream events which holds their time.
+const eventTimeField = "time"
+
+// The position of the stream, which allows a restarted stream to resume from where it stopped.
+// The events don't have IDs, so the events sent at the time of the last event are identified by the hashes of their content.
+// An event is saved to the checkpoint only after it was written to the sink. Therefore, events are delivered at least once:
+// an event written right before the stream stopped, and not yet saved, is written again when the stream resumes.
+type Checkpoint struct {
+	ReconnectId     string   `json:"reconnectId,omitempty"`
+	LastEventTime   string   `json:"lastEventTime,omitempty"`
+	LastEventHashes []string `json:"lastEventHashes,omitempty"`
+	filePath        string
+	// The number of times each of the saved events is expected to be sent again when the stream resumes.
+	replayed map[string]int
+}
+
+// Reads the checkpoint file. If the file doesn't exist, an empty checkpoint is returned, which is saved to the file.
+func LoadCheckpoint(filePath string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{filePath: filePath}
+	exists, err := fileutils.IsFileExists(filePath, false)
+	if err != nil || !exists {
+		return checkpoint, err
+	}
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, checkpoint); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	checkpoint.replayed = make(map[string]int)
+	for _, hash := range checkpoint.LastEventHashes {
+		checkpoint.replayed[hash]++
+	}
+	return checkpoint, nil
+}
+
+// Writes the checkpoint atomically, so that a crash never leaves a partial checkpoint.
+func (checkpoint *Checkpoint) Save() error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return ioutils.WriteFileAtomically(checkpoint.filePath, content)
+}
+
+// Returns true if the event was handled before the stream restarted.
+// That is, if it is older than the last event, or was sent at the same time and its hash was saved.
+// Each saved hash matches a single event, so identical events sent at the same time are all delivered.
+func (checkpoint *Checkpoint) checkHandled(event map[string]interface{}) bool {
+	lastEventTime, err := time.Parse(time.RFC3339Nano, checkpoint.LastEventTime)
+	if err != nil {
+		return false
+	}
+	eventTime, err := getEventTime(event)
+	if err != nil || eventTime.After(lastEventTime) {
+		return false
+	}
+	if eventTime.Before(lastEventTime) {
+		return true
+	}
+	hash := getEventHash(event)
+	if checkpoint.replayed[hash] == 0 {
+		return false
+	}
+	checkpoint.replayed[hash]--
+	return true
+}
+
+// Sets the event as the last handled event.
+// Returns false if the event has no time or is older than the last event, and therefore isn't saved.
+func (checkpoint *Checkpoint) setHandled(event map[string]interface{}) bool {
+	eventTime, err := getEventTime(event)
+	if err != nil {
+		return false
+	}
+	if lastEventTime, err := time.Parse(time.RFC3339Nano, checkpoint.LastEventTime); err == nil && eventTime.Before(lastEventTime) {
+		return false
+	}
+	lastEventTime := eventTime.Format(time.RFC3339Nano)
+	if lastEventTime != checkpoint.LastEventTime {
+		checkpoint.LastEventTime = lastEventTime
+		checkpoint.LastEventHashes = nil
+	}
+	checkpoint.LastEventHashes = append(checkpoint.LastEventHashes, getEventHash(event))
+	return true
+}
+
+func getEventTime(event map[string]interface{}) (time.Time, error) {
+	value, _ := event[eventTimeField].(string)
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// The event is marshaled with its keys sorted, so the same event always has the same hash.
+func getEventHash(event map[string]interface{}) string {
+	content, _ := json.Marshal(event)
+	hash := sha1.Sum(content)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,226 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The operators of the field filters. Two characters operators are listed first, so that they're matched before their prefixes.
+var filterOperators = []string{"==", "!=", "=~", "<=", ">=", "<", ">"}
+
+// A jq-like predicate on a field of the stream events, in the form of '.path.to.field <operator> <JSON value>', such as '.type == "download"'.
+// Array elements are accessed by their index, as in '.files[0]'. The =~ operator matches a regular expression.
+// A path without an operator matches the events in which the field exists, and isn't null or false.
+type FieldFilter struct {
+	expression string
+	path       []interface{}
+	operator   string
+	value      interface{}
+	regexp     *regexp.Regexp
+}
+
+// Parses a list of filters in the form of "filter1;filter2;...". A ';' inside a quoted value doesn't separate filters.
+func ParseFieldFilters(filters string) ([]*FieldFilter, error) {
+	var fieldFilters []*FieldFilter
+	for _, expression := range splitFilters(filters) {
+		if strings.TrimSpace(expression) == "" {
+			continue
+		}
+		filter, err := ParseFieldFilter(expression)
+		if err != nil {
+			return nil, err
+		}
+		fieldFilters = append(fieldFilters, filter)
+	}
+	return fieldFilters, nil
+}
+
+func splitFilters(filters string) []string {
+	var expressions []string
+	var current strings.Builder
+	inQuotes, escaped := false, false
+	for _, c := range filters {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ';' && !inQuotes:
+			expressions = append(expressions, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	return append(expressions, current.String())
+}
+
+func ParseFieldFilter(expression string) (*FieldFilter, error) {
+	filter := &FieldFilter{expression: strings.TrimSpace(expression)}
+	pathEnd := strings.IndexAny(filter.expression, " =!<>")
+	if pathEnd < 0 {
+		pathEnd = len(filter.expression)
+	}
+	path, err := parseFieldPath(filter.expression[:pathEnd])
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("Invalid filter '%s': %s", expression, err.Error()))
+	}
+	filter.path = path
+	rest := strings.TrimSpace(filter.expression[pathEnd:])
+	if rest == "" {
+		return filter, nil
+	}
+	for _, operator := range filterOperators {
+		if strings.HasPrefix(rest, operator) {
+			filter.operator = operator
+			break
+		}
+	}
+	if filter.operator == "" {
+		return nil, errorutils.CheckError(fmt.Errorf("Invalid filter '%s': unsupported operator. Supported operators: %s", expression, strings.Join(filterOperators, ", ")))
+	}
+	rawValue := strings.TrimSpace(strings.TrimPrefix(rest, filter.operator))
+	if err = json.Unmarshal([]byte(rawValue), &filter.value); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("Invalid filter '%s': the value must be JSON, such as a quoted string or a number.", expression))
+	}
+	if filter.operator == "=~" {
+		pattern, ok := filter.value.(string)
+		if !ok {
+			return nil, errorutils.CheckError(fmt.Errorf("Invalid filter '%s': the value of =~ must be a quoted regular expression.", expression))
+		}
+		if filter.regexp, err = regexp.Compile(pattern); err != nil {
+			return nil, errorutils.CheckError(fmt.Errorf("Invalid filter '%s': %s", expression, err.Error()))
+		}
+	}
+	return filter, nil
+}
+
+// Parses a path such as '.repo.name' or '.files[0].path' to its keys and indices.
+func parseFieldPath(path string) ([]interface{}, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, errors.New("the field path must start with '.'")
+	}
+	var segments []interface{}
+	for _, part := range strings.Split(path[1:], ".") {
+		key := part
+		var indices []interface{}
+		if bracket := strings.Index(part, "["); bracket >= 0 {
+			key = part[:bracket]
+			for _, index := range strings.Split(part[bracket+1:], "[") {
+				if !strings.HasSuffix(index, "]") {
+					return nil, errors.New("missing ']' in the field path")
+				}
+				i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+				if err != nil || i < 0 {
+					return nil, errors.New("array indices must be non-negative numbers")
+				}
+				indices = append(indices, i)
+			}
+		}
+		if key != "" {
+			segments = append(segments, key)
+		} else if len(indices) == 0 && len(path) > 1 {
+			return nil, errors.New("empty field name in the field path")
+		}
+		segments = append(segments, indices...)
+	}
+	return segments, nil
+}
+
+// Returns the value of the field in the event, or nil if it doesn't exist.
+func (filter *FieldFilter) fieldValue(event map[string]interface{}) interface{} {
+	var value interface{} = event
+	for _, segment := range filter.path {
+		switch key := segment.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			value = object[key]
+		case int:
+			array, ok := value.([]interface{})
+			if !ok || key >= len(array) {
+				return nil
+			}
+			value = array[key]
+		}
+	}
+	return value
+}
+
+func (filter *FieldFilter) Match(event map[string]interface{}) bool {
+	value := filter.fieldValue(event)
+	switch filter.operator {
+	case "":
+		return value != nil && value != false
+	case "==":
+		return reflect.DeepEqual(value, filter.value)
+	case "!=":
+		return !reflect.DeepEqual(value, filter.value)
+	case "=~":
+		s, ok := value.(string)
+		return ok && filter.regexp.MatchString(s)
+	}
+	comparison, ok := compare(value, filter.value)
+	if !ok {
+		return false
+	}
+	switch filter.operator {
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	default:
+		return comparison >= 0
+	}
+}
+
+func (filter *FieldFilter) String() string {
+	return filter.expression
+}
+
+// Compares numbers numerically and strings lexicographically. Returns false if the values can't be compared.
+func compare(a, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
+
+// Returns true if the event matches all the filters.
+func MatchFieldFilters(filters []*FieldFilter, event map[string]interface{}) bool {
+	for _, filter := range filters {
+		if !filter.Match(event) {
+			return false
+		}
+	}
+	return true
+}
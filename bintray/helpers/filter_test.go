@@ -0,0 +1,63 @@
+package helpers
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testEvent = `{"type":"download","time":"2019-05-01T10:00:00.000Z","size":1024,"path":"org/repo/pkg/1.0/pkg.jar","tags":["a","b"],"repo":{"name":"repo","private":false}}`
+
+func TestFieldFilters(t *testing.T) {
+	var event map[string]interface{}
+	if err := json.Unmarshal([]byte(testEvent), &event); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		filter   string
+		expected bool
+	}{
+		{`.type == "download"`, true},
+		{`.type=="upload"`, false},
+		{`.type != "upload"`, true},
+		{`.size > 1000`, true},
+		{`.size <= 1000`, false},
+		{`.time >= "2019-05-01T00:00:00Z"`, true},
+		{`.path =~ "\\.jar$"`, true},
+		{`.path =~ "^other/"`, false},
+		{`.repo.name == "repo"`, true},
+		{`.repo.private`, false},
+		{`.repo.missing`, false},
+		{`.tags[1] == "b"`, true},
+		{`.tags[2] == "c"`, false},
+		{`.size > "1000"`, false},
+	}
+	for _, test := range tests {
+		filter, err := ParseFieldFilter(test.filter)
+		if err != nil {
+			t.Errorf("Parsing %s: %s", test.filter, err.Error())
+			continue
+		}
+		if filter.Match(event) != test.expected {
+			t.Errorf("Expected %s to return %v", test.filter, test.expected)
+		}
+	}
+
+	filters, err := ParseFieldFilters(`.type=="download";.path=~"pkg;1"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters) != 2 || filters[1].String() != `.path=~"pkg;1"` {
+		t.Errorf("Unexpected filters: %v", filters)
+	}
+	if MatchFieldFilters(filters, event) {
+		t.Error("Expected the event not to match all the filters.")
+	}
+}
+
+func TestParseFieldFilterErrors(t *testing.T) {
+	for _, filter := range []string{`type == "download"`, `.type = "download"`, `.type == download`, `.path =~ 1`, `.path =~ "["`, `.tags[x] == 1`} {
+		if _, err := ParseFieldFilter(filter); err == nil {
+			t.Errorf("Expected parsing %s to fail", filter)
+		}
+	}
+}
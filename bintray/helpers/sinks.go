@@ -0,0 +1,202 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/mattn/go-shellwords"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+// A destination of the stream events. Each event is written as a single line of JSON.
+type Sink interface {
+	Write(event []byte) error
+	Close() error
+}
+
+// Returns the event followed by a new line, without changing the event, which is shared by the sinks.
+func eventLine(event []byte) []byte {
+	line := make([]byte, len(event), len(event)+1)
+	copy(line, event)
+	return append(line, '\n')
+}
+
+// Writes the events to a writer, such as the standard output.
+type WriterSink struct {
+	writer io.Writer
+}
+
+func NewWriterSink(writer io.Writer) *WriterSink {
+	return &WriterSink{writer: writer}
+}
+
+func (ws *WriterSink) Write(event []byte) error {
+	_, err := ws.writer.Write(eventLine(event))
+	return errorutils.CheckError(err)
+}
+
+func (ws *WriterSink) Close() error {
+	return nil
+}
+
+// Appends the events to a file. Once the file reaches its max size, it is rotated:
+// the file is renamed to <path>.1, the previous <path>.1 is renamed to <path>.2, and so on, up to the max number of backups.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	fs := &FileSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	return fs, fs.open()
+}
+
+func (fs *FileSink) open() error {
+	file, err := os.OpenFile(fs.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errorutils.CheckError(err)
+	}
+	fs.file = file
+	fs.size = info.Size()
+	return nil
+}
+
+func (fs *FileSink) Write(event []byte) error {
+	line := eventLine(event)
+	if fs.size > 0 && fs.size+int64(len(line)) > fs.maxSize {
+		if err := fs.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := fs.file.Write(line)
+	fs.size += int64(n)
+	return errorutils.CheckError(err)
+}
+
+func (fs *FileSink) rotate() error {
+	log.Debug("Rotating", fs.path)
+	if err := fs.file.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if fs.maxBackups == 0 {
+		if err := os.Remove(fs.path); err != nil {
+			return errorutils.CheckError(err)
+		}
+		return fs.open()
+	}
+	for i := fs.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(fs.backupPath(i), fs.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+	if err := os.Rename(fs.path, fs.backupPath(1)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return fs.open()
+}
+
+func (fs *FileSink) backupPath(index int) string {
+	return fs.path + "." + strconv.Itoa(index)
+}
+
+func (fs *FileSink) Close() error {
+	return errorutils.CheckError(fs.file.Close())
+}
+
+// Runs a command for each event, with the event as the standard input of the command.
+type ExecSink struct {
+	args []string
+}
+
+func NewExecSink(command string) (*ExecSink, error) {
+	args, err := shellwords.Parse(command)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(args) == 0 {
+		return nil, errorutils.CheckError(errors.New("The command to run for each event is empty."))
+	}
+	return &ExecSink{args: args}, nil
+}
+
+func (es *ExecSink) Write(event []byte) error {
+	cmd := exec.Command(es.args[0], es.args[1:]...)
+	cmd.Stdin = bytes.NewReader(eventLine(event))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+func (es *ExecSink) Close() error {
+	return nil
+}
+
+// Posts each event to a URL.
+type WebhookSink struct {
+	url               string
+	client            *httpclient.HttpClient
+	httpClientDetails httputils.HttpClientDetails
+}
+
+func NewWebhookSink(url string) (*WebhookSink, error) {
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		return nil, err
+	}
+	httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/json"}}
+	return &WebhookSink{url: url, client: client, httpClientDetails: httpClientDetails}, nil
+}
+
+func (whs *WebhookSink) Write(event []byte) error {
+	resp, body, err := whs.client.SendPost(whs.url, event, whs.httpClientDetails)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errorutils.CheckError(fmt.Errorf("Posting the event to %s failed: %s %s", whs.url, resp.Status, string(body)))
+	}
+	return nil
+}
+
+func (whs *WebhookSink) Close() error {
+	return nil
+}
+
+// Writes each event to all the sinks. An event is written to all the sinks, even if writing it to one of them fails.
+type MultiSink []Sink
+
+func (ms MultiSink) Write(event []byte) error {
+	var firstErr error
+	for _, sink := range ms {
+		if err := sink.Write(event); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (ms MultiSink) Close() error {
+	var firstErr error
+	for _, sink := range ms {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
 	"github.com/jfrog/jfrog-client-go/httpclient"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -17,20 +18,32 @@ import (
 
 const BINTRAY_RECONNECT_HEADER = "X-Bintray-Stream-Reconnect-Id"
 
+// The number of times an event is written to the sink before the stream is stopped.
+const sinkWriteAttempts = 4
+
+// The time to wait before writing an event to the sink again. The time is doubled after each failed attempt.
+var sinkRetryInterval = time.Second
+
 type StreamManager struct {
 	HttpClientDetails httputils.HttpClientDetails
 	Url               string
 	IncludeFilter     map[string]struct{}
+	FieldFilters      []*FieldFilter
 	ReconnectId       string
+	// If set, the reconnect ID and the last event are saved to the checkpoint.
+	Checkpoint *Checkpoint
 }
 
-func (sm *StreamManager) ReadStream(resp *http.Response, writer io.Writer, lastServerInteraction *time.Time) {
+// Writes the events of the stream to the sink, until the stream ends.
+// Returns an error if an event couldn't be written to the sink. The event isn't saved to the checkpoint in that case,
+// so the stream should be stopped, to be resumed from the checkpoint once the sink is fixed.
+func (sm *StreamManager) ReadStream(resp *http.Response, sink Sink, lastServerInteraction *time.Time) error {
 	ioReader := resp.Body
 	bodyReader := bufio.NewReader(ioReader)
-	sm.handleStream(bodyReader, writer, lastServerInteraction)
+	return sm.handleStream(bodyReader, sink, lastServerInteraction)
 }
 
-func (sm *StreamManager) handleStream(ioReader io.Reader, writer io.Writer, lastServerInteraction *time.Time) {
+func (sm *StreamManager) handleStream(ioReader io.Reader, sink Sink, lastServerInteraction *time.Time) error {
 	bodyReader := bufio.NewReader(ioReader)
 	pReader, pWriter := io.Pipe()
 	defer pWriter.Close()
@@ -51,23 +64,71 @@ func (sm *StreamManager) handleStream(ioReader io.Reader, writer io.Writer, last
 		}
 	}()
 	streamDecoder := json.NewDecoder(pReader)
-	streamEncoder := json.NewEncoder(writer)
-	sm.parseStream(streamDecoder, streamEncoder)
+	return sm.parseStream(streamDecoder, sink)
 }
 
-func (sm *StreamManager) parseStream(streamDecoder *json.Decoder, streamEncoder *json.Encoder) error {
+// Returns once the stream ends, or with an error if an event couldn't be written to the sink.
+func (sm *StreamManager) parseStream(streamDecoder *json.Decoder, sink Sink) error {
 	for {
 		var decodedJson map[string]interface{}
 		if e := streamDecoder.Decode(&decodedJson); e != nil {
 			log.Debug(e)
-			return e
+			return nil
+		}
+		if sm.Checkpoint != nil && sm.Checkpoint.checkHandled(decodedJson) {
+			continue
 		}
-		if _, ok := sm.IncludeFilter[decodedJson["type"].(string)]; ok || len(sm.IncludeFilter) == 0 {
-			if e := streamEncoder.Encode(&decodedJson); e != nil {
-				log.Debug(e)
+		if sm.isIncluded(decodedJson) {
+			content, e := json.Marshal(&decodedJson)
+			if e != nil {
+				return errorutils.CheckError(e)
+			}
+			if e = writeToSink(sink, content); e != nil {
 				return e
 			}
 		}
+		sm.saveLastEvent(decodedJson)
+	}
+}
+
+// Writes the event to the sink, retrying with a growing interval if the sink fails.
+func writeToSink(sink Sink, event []byte) error {
+	interval := sinkRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := sink.Write(event)
+		if err == nil {
+			return nil
+		}
+		if attempt == sinkWriteAttempts {
+			log.Error(fmt.Sprintf("Failed writing an event to the sink after %d attempts: %s", attempt, err.Error()))
+			return err
+		}
+		log.Warn(fmt.Sprintf("Failed writing an event to the sink, retrying in %s: %s", interval, err.Error()))
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+func (sm *StreamManager) isIncluded(event map[string]interface{}) bool {
+	eventType, _ := event["type"].(string)
+	if _, ok := sm.IncludeFilter[eventType]; !ok && len(sm.IncludeFilter) > 0 {
+		return false
+	}
+	return MatchFieldFilters(sm.FieldFilters, event)
+}
+
+// Called after the event was written to the sink, or filtered out.
+// The event is saved to the checkpoint including if it was filtered out, since it was handled as well.
+func (sm *StreamManager) saveLastEvent(event map[string]interface{}) {
+	if sm.Checkpoint == nil || !sm.Checkpoint.setHandled(event) {
+		return
+	}
+	sm.saveCheckpoint()
+}
+
+func (sm *StreamManager) saveCheckpoint() {
+	if err := sm.Checkpoint.Save(); err != nil {
+		log.Warn("Failed saving the stream checkpoint:", err.Error())
 	}
 }
 
@@ -100,12 +161,23 @@ func (sm *StreamManager) Connect() (bool, *http.Response) {
 		msgBody, _ := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		if resp.StatusCode > 400 && resp.StatusCode < 500 {
+			// The reconnect ID loaded from the checkpoint may have expired. In that case, a new stream is opened.
+			if sm.isReconnection() && sm.Checkpoint != nil {
+				log.Warn("Failed resuming the stream from the checkpoint, events sent since the stream stopped may be missing. Opening a new stream.")
+				sm.ReconnectId = ""
+				delete(sm.HttpClientDetails.Headers, BINTRAY_RECONNECT_HEADER)
+				return false, resp
+			}
 			cliutils.ExitOnErr(errors.New(string(msgBody)))
 		}
 		return false, resp
 
 	}
 	sm.ReconnectId = resp.Header.Get(BINTRAY_RECONNECT_HEADER)
+	if sm.Checkpoint != nil && sm.Checkpoint.ReconnectId != sm.ReconnectId {
+		sm.Checkpoint.ReconnectId = sm.ReconnectId
+		sm.saveCheckpoint()
+	}
 	log.Debug("Connected.")
 	return true, resp
 }
@@ -0,0 +1,157 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testStream = `{"type":"download","time":"2019-05-01T10:00:00Z","path":"a.jar"}
+{"type":"upload","time":"2019-05-01T10:00:01Z","path":"b.jar"}
+{"type":"download","time":"2019-05-01T10:00:02Z","path":"c.zip"}
+{"type":"upload","time":"2019-05-01T10:00:02Z","path":"e.jar"}
+`
+
+func TestParseStreamWithCheckpoint(t *testing.T) {
+	log.SetDefaultLogger()
+	tempDir, err := ioutil.TempDir("", "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	checkpointPath := filepath.Join(tempDir, "checkpoint.json")
+	checkpoint, err := LoadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filters, err := ParseFieldFilters(`.path =~ "\\.jar$"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := &StreamManager{IncludeFilter: map[string]struct{}{"download": {}}, FieldFilters: filters, Checkpoint: checkpoint}
+	output := new(bytes.Buffer)
+	sm.parseStream(json.NewDecoder(strings.NewReader(testStream)), NewWriterSink(output))
+	if output.String() != `{"path":"a.jar","time":"2019-05-01T10:00:00Z","type":"download"}`+"\n" {
+		t.Errorf("Unexpected output: %s", output.String())
+	}
+
+	// The checkpoint holds the last events, even though they were filtered out.
+	checkpoint, err = LoadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.LastEventTime != "2019-05-01T10:00:02Z" || len(checkpoint.LastEventHashes) != 2 {
+		t.Errorf("Expected the last event time and the hashes of its events to be saved, got %+v", checkpoint)
+	}
+
+	// Once resumed, the events of the checkpoint are skipped, including the events sent at the time of the last event.
+	// An identical event sent again at that time is a new event.
+	sm = &StreamManager{Checkpoint: checkpoint}
+	output.Reset()
+	resumedStream := testStream + `{"type":"download","time":"2019-05-01T10:00:02Z","path":"c.zip"}
+{"type":"upload","time":"2019-05-01T10:00:03Z","path":"d.jar"}`
+	sm.parseStream(json.NewDecoder(strings.NewReader(resumedStream)), NewWriterSink(output))
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "c.zip") || !strings.Contains(lines[1], "d.jar") {
+		t.Errorf("Unexpected output: %s", output.String())
+	}
+}
+
+// Fails the given number of writes, then writes the events to the output.
+type failingSink struct {
+	failures int
+	output   bytes.Buffer
+}
+
+func (fs *failingSink) Write(event []byte) error {
+	if fs.failures > 0 {
+		fs.failures--
+		return errors.New("sink failure")
+	}
+	_, err := fs.output.Write(eventLine(event))
+	return err
+}
+
+func (fs *failingSink) Close() error {
+	return nil
+}
+
+func TestParseStreamWithFailingSink(t *testing.T) {
+	log.SetDefaultLogger()
+	previousInterval := sinkRetryInterval
+	sinkRetryInterval = time.Millisecond
+	defer func() { sinkRetryInterval = previousInterval }()
+	tempDir, err := ioutil.TempDir("", "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	checkpointPath := filepath.Join(tempDir, "checkpoint.json")
+	checkpoint, err := LoadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed write is retried.
+	sink := &failingSink{failures: sinkWriteAttempts - 1}
+	sm := &StreamManager{IncludeFilter: map[string]struct{}{"download": {}}, Checkpoint: checkpoint}
+	if err = sm.parseStream(json.NewDecoder(strings.NewReader(testStream)), sink); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(sink.output.String()), "\n"); len(lines) != 2 {
+		t.Errorf("Expected the 2 download events to be written, got: %s", sink.output.String())
+	}
+
+	// Once the retries are exhausted, the stream is stopped and the event isn't saved to the checkpoint.
+	sink = &failingSink{failures: sinkWriteAttempts}
+	sm = &StreamManager{Checkpoint: checkpoint}
+	resumedStream := testStream + `{"type":"upload","time":"2019-05-01T10:00:03Z","path":"d.jar"}`
+	if err = sm.parseStream(json.NewDecoder(strings.NewReader(resumedStream)), sink); err == nil {
+		t.Fatal("Expected an error for the failing sink.")
+	}
+	if checkpoint, err = LoadCheckpoint(checkpointPath); err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.LastEventTime != "2019-05-01T10:00:02Z" {
+		t.Errorf("Expected the failed event not to be saved to the checkpoint, got %+v", checkpoint)
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	path := filepath.Join(tempDir, "events.json")
+	sink, err := NewFileSink(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range []string{"event-1", "event-2", "event-3", "event-4"} {
+		if err = sink.Write([]byte(event)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Each event fills the file, so the oldest event was dropped with the third backup.
+	expected := map[string]string{path: "event-4\n", path + ".1": "event-3\n", path + ".2": "event-2\n"}
+	for filePath, content := range expected {
+		actual, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != content {
+			t.Errorf("Expected %s to contain %q, got %q", filePath, content, string(actual))
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package builddiff
+
+const Description = "Compare two builds - their modules' artifacts and dependencies, properties and VCS details."
+
+var Usage = []string{"jfrog rt bdf [command options] <build name> <build number A> [build name B] <build number B>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number A
+		The number of the build to compare from.
+
+	build name B
+		The name of the build to compare to. If not specified, the first build name is used.
+
+	build number B
+		The number of the build to compare to.`
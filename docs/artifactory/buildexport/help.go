@@ -0,0 +1,15 @@
+package buildexport
+
+const Description = "Export a locally collected build-info to a portable bundle file."
+
+var Usage = []string{"jfrog rt bex <build name> <build number> <bundle path>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.
+
+	bundle path
+		Path of the bundle file to create. The bundle includes the build-info collected locally by the build commands,
+		and can be imported on another machine using the build-import command.`
@@ -0,0 +1,9 @@
+package buildimport
+
+const Description = "Import a build-info bundle, created by the build-export command, to the locally collected builds."
+
+var Usage = []string{"jfrog rt bim [command options] <bundle path>"}
+
+const Arguments string = `	bundle path
+		Path of the bundle file to import. The build name and number are read from the bundle.
+		Once imported, the build can be published using the build-publish command.`
@@ -0,0 +1,9 @@
+package builduploadfile
+
+const Description = "Publish a build-info file, written by the build-publish command with the --output-file option."
+
+var Usage = []string{"jfrog rt buf [command options] <build-info file>"}
+
+const Arguments string = `	build-info file
+		Path to the build-info file. The build name and number are read from the file.
+		The environment variables in the build-info are filtered using the --env-include and --env-exclude options before it is published.`
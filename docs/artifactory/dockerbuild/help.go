@@ -0,0 +1,11 @@
+package dockerbuild
+
+const Description = "Docker build and push, while collecting the base images of the Dockerfile as build dependencies."
+
+var Usage = []string{"jfrog rt docker-build [command options] <image tag> <target repo>"}
+
+const Arguments string = `	image tag
+		Docker image tag to build and push.
+	target repo
+		Target repository in Artifactory.
+`
@@ -0,0 +1,13 @@
+package dockerpromote
+
+const Description = "Promote a docker image from one repository to another."
+
+var Usage = []string{"jfrog rt docker-promote [command options] <source image> <source repo> <target repo>"}
+
+const Arguments string = `	source image
+		Docker image to promote, in the form of image-name:tag. If the tag is omitted, latest is used.
+	source repo
+		Source repository in Artifactory.
+	target repo
+		Target repository in Artifactory.
+`
@@ -2,9 +2,10 @@ package gopublish
 
 const Description = "Publish go package and/or its dependencies to Artifactory"
 
-var Usage = []string{`jfrog rt gp [command options] <target repository> <project version>`}
+var Usage = []string{`jfrog rt gp [command options] <target repository> <project version>`,
+	`jfrog rt gp --all-tags [command options] <target repository>`}
 
 const Arguments string = `	target repository
 		Target repository in Artifactory.
 	project version
-		Package version to be published.`
+		Package version to be published. Not used with the --all-tags option.`
@@ -0,0 +1,7 @@
+package pipdepstree
+
+const Description = "Show the dependency tree of the installed Python environment."
+
+var Usage = []string{`jfrog rt pdt [command options]`}
+
+const Arguments string = ``
@@ -0,0 +1,10 @@
+package pipinstall
+
+const Description = "Run pip install, resolving the packages from an Artifactory PyPI repository."
+
+var Usage = []string{`jfrog rt pipi [command options] <pip arguments> <repository name>`}
+
+const Arguments string = `	pip arguments
+		Arguments and options for the pip install command, in the form of "arg1 arg2 arg3". Collecting build-info requires pip 22.2 or higher.
+	repository name
+		The source PyPI repository. Can be a local, remote or virtual PyPI repository.`
@@ -0,0 +1,10 @@
+package pippublish
+
+const Description = "Deploys wheels and source distributions to the designated PyPI repository."
+
+var Usage = []string{`jfrog rt pipp [command options] <files pattern> <repository name>`}
+
+const Arguments string = `	files pattern
+		Glob pattern of the distribution files to deploy, such as "dist/*".
+	repository name
+		The destination PyPI repository. Can be a local repository or a virtual repository with a 'Default Deployment Repository'.`
@@ -0,0 +1,7 @@
+package specschema
+
+const Description = "Print the JSON Schema of the File Spec."
+
+var Usage = []string{"jfrog rt spec schema"}
+
+const Arguments string = ""
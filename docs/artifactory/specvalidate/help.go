@@ -0,0 +1,10 @@
+package specvalidate
+
+const Description = "Validate a File Spec offline, without contacting Artifactory."
+
+var Usage = []string{"jfrog rt spec validate [command options] <spec path>"}
+
+const Arguments string = `	spec path
+		Path to a JSON or YAML File Spec. Included specs are resolved relative to this spec.
+		Without the --command option, the spec is validated for every command which accepts a File Spec,
+		and the validation fails only if the spec is invalid for all of them.`
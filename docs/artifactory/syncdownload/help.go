@@ -0,0 +1,13 @@
+package syncdownload
+
+const Description = "Mirror a path in Artifactory to a local directory."
+
+var Usage = []string{"jfrog rt sd [command options] <source path> <local directory>"}
+
+const Arguments string = `	source path
+		Specifies the source path in Artifactory in the following format: <repository name>/<repository path>.
+		Only files which are missing in the local directory, or which have a different checksum, are downloaded.
+
+	local directory
+		Specifies the local directory to which the source path should be mirrored.
+		If the --delete option is set, files under this directory which don't exist in Artifactory are deleted.`
@@ -0,0 +1,13 @@
+package syncupload
+
+const Description = "Mirror a local directory to a path in Artifactory."
+
+var Usage = []string{"jfrog rt su [command options] <local directory> <target path>"}
+
+const Arguments string = `	local directory
+		Specifies the local directory which should be mirrored to Artifactory.
+		Only files which are missing in Artifactory, or which have a different checksum, are uploaded.
+
+	target path
+		Specifies the target path in Artifactory in the following format: <repository name>/<repository path>.
+		If the --delete option is set, files under this path which don't exist in the local directory are deleted.`
@@ -0,0 +1,10 @@
+package yarn
+
+const Description = "Run yarn, resolving the packages from an Artifactory npm repository."
+
+var Usage = []string{`jfrog rt yarn [command options] <yarn arguments> <repository name>`}
+
+const Arguments string = `	yarn arguments
+		Arguments and options for the yarn command, in the form of "arg1 arg2 arg3".
+	repository name
+		The source npm repository. Can be a local, remote or virtual npm repository.`
@@ -21,6 +21,21 @@ const GlobalEnvVars string = `	JFROG_CLI_LOG_LEVEL
 		[Default: The operating system's temp directory]
 		Defines the temp directory used by JFrog CLI.
 
+	JFROG_CLI_ENCRYPTION_KEY
+		[Default: None]
+		If set, the passwords, API keys and tokens stored in the JFrog CLI config file are encrypted using this key.
+		Existing plain text config files are encrypted the next time they are read.
+
+	JFROG_CLI_ENCRYPTION_KEY_FILE
+		[Default: None]
+		Path to a file containing the key used to encrypt the JFrog CLI config file.
+		Used if JFROG_CLI_ENCRYPTION_KEY is not set.
+
+	JFROG_CLI_LOCK_TIMEOUT
+		[Default: 120]
+		The number of seconds JFrog CLI waits for a lock held by another JFrog CLI process,
+		such as the lock of the config file or of a build collected locally, before failing.
+
 	CI
 		[Default: false]
 		If true, disables progress bar on the supporting commands.
@@ -27,6 +27,9 @@ const (
 	JfrogHomeDirEnv       = "JFROG_CLI_HOME_DIR"
 	JFrogCliErrorHandling = "JFROG_CLI_ERROR_HANDLING"
 	JFrogCliTempDir       = "JFROG_CLI_TEMP_DIR"
+	EncryptionKeyEnv      = "JFROG_CLI_ENCRYPTION_KEY"
+	EncryptionKeyFileEnv  = "JFROG_CLI_ENCRYPTION_KEY_FILE"
+	LockTimeout           = "JFROG_CLI_LOCK_TIMEOUT"
 	CI                    = "CI"
 	// Deprecated:
 	JfrogHomeEnv = "JFROG_CLI_HOME"
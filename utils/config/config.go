@@ -189,6 +189,16 @@ func SaveBintrayConf(details *BintrayDetails) error {
 
 func saveConfig(config *ConfigV1) error {
 	config.Version = cliutils.GetConfigVersion()
+	masterKey, err := getMasterKey()
+	if err != nil {
+		return err
+	}
+	if masterKey != nil {
+		config, err = encryptConfig(config, masterKey)
+		if err != nil {
+			return err
+		}
+	}
 	b, err := json.Marshal(&config)
 	if err != nil {
 		return errorutils.CheckError(err)
@@ -232,8 +242,24 @@ func readConf() (*ConfigV1, error) {
 		return new(ConfigV1), nil
 	}
 	content, err = convertIfNecessary(content)
+	if err != nil {
+		return nil, err
+	}
 	err = json.Unmarshal(content, &config)
-	return config, err
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if config.Enc {
+		masterKey, err := getMasterKey()
+		if err != nil {
+			return nil, err
+		}
+		err = decryptConfig(config, masterKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
 }
 
 // The configuration schema can change between versions, therefore we need to convert old versions to the new schema.
@@ -257,8 +283,27 @@ func convertIfNecessary(content []byte) ([]byte, error) {
 		result = configV0.Convert()
 		err = saveConfig(result)
 		content, err = json.Marshal(&result)
+		return content, err
+	}
+	return encryptIfNecessary(content)
+}
+
+// If encryption is enabled and the config file is still stored in plain text, save it encrypted.
+func encryptIfNecessary(content []byte) ([]byte, error) {
+	masterKey, err := getMasterKey()
+	if err != nil || masterKey == nil {
+		return content, err
 	}
-	return content, err
+	encrypted, err := jsonparser.GetBoolean(content, "enc")
+	if err == nil && encrypted {
+		return content, nil
+	}
+	config := new(ConfigV1)
+	err = json.Unmarshal(content, config)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, saveConfig(config)
 }
 
 func GetJfrogHomeDir() (string, error) {
@@ -312,6 +357,10 @@ type ConfigV1 struct {
 	Bintray        *BintrayDetails        `json:"bintray,omitempty"`
 	MissionControl *MissionControlDetails `json:"MissionControl,omitempty"`
 	Version        string                 `json:"Version,omitempty"`
+	// True if the secrets in this config are encrypted.
+	Enc bool `json:"enc,omitempty"`
+	// The salt of the key which encrypted the secrets, encoded in base64.
+	Salt string `json:"salt,omitempty"`
 }
 
 type ConfigV0 struct {
@@ -344,6 +393,8 @@ type ArtifactoryDetails struct {
 	ServerId       string            `json:"serverId,omitempty"`
 	IsDefault      bool              `json:"isDefault,omitempty"`
 	InsecureTls    bool              `json:"-"`
+	// An external executable which returns the credentials for this server, instead of storing them in the config file.
+	CredentialsHelper string `json:"credentialsHelper,omitempty"`
 	// Deprecated, use password option instead.
 	ApiKey string `json:"apiKey,omitempty"`
 }
@@ -407,6 +458,10 @@ func (artifactoryDetails *ArtifactoryDetails) SshAuthHeaderSet() bool {
 }
 
 func (artifactoryDetails *ArtifactoryDetails) CreateArtAuthConfig() (auth.ArtifactoryDetails, error) {
+	artifactoryDetails, err := artifactoryDetails.ResolveCredentials()
+	if err != nil {
+		return nil, err
+	}
 	artAuth := auth.NewArtifactoryDetails()
 	artAuth.SetUrl(artifactoryDetails.Url)
 	artAuth.SetSshUrl(artifactoryDetails.SshUrl)
@@ -3,7 +3,13 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -192,3 +198,181 @@ func assertionHelper(configV1 *ConfigV1, t *testing.T) {
 		t.Error(errors.New("Password shouldn't change."))
 	}
 }
+
+func TestEncryptConfig(t *testing.T) {
+	config := &ConfigV1{
+		Artifactory:    []*ArtifactoryDetails{{Url: "http://localhost:8080/artifactory/", User: "user", Password: "password", AccessToken: "token"}},
+		Bintray:        &BintrayDetails{User: "user", Key: "api-key"},
+		MissionControl: &MissionControlDetails{User: "user", Password: "password"},
+	}
+	masterKey := []byte("master-key")
+	encrypted, err := encryptConfig(config, masterKey)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if !encrypted.Enc || config.Enc || encrypted.Salt == "" {
+		t.Error("Only the encrypted copy should be marked as encrypted, with the salt of its key.")
+	}
+	if encrypted.Artifactory[0].Password == "password" || encrypted.Bintray.Key == "api-key" || encrypted.MissionControl.Password == "password" {
+		t.Error("Secrets should be encrypted.")
+	}
+	if encrypted.Artifactory[0].User != "user" || encrypted.Artifactory[0].Url != config.Artifactory[0].Url {
+		t.Error("Non secret fields shouldn't change.")
+	}
+	if config.Artifactory[0].Password != "password" {
+		t.Error("The original config shouldn't change.")
+	}
+
+	if err = decryptConfig(encrypted, []byte("wrong-key")); err == nil {
+		t.Error("Decrypting with a wrong key should fail.")
+	}
+	if err = decryptConfig(encrypted, nil); err == nil {
+		t.Error("Decrypting without a key should fail.")
+	}
+	if err = decryptConfig(encrypted, masterKey); err != nil {
+		t.Error(err.Error())
+	}
+	if !reflect.DeepEqual(config, encrypted) {
+		t.Error("Decrypted config should be equal to the original config.")
+	}
+}
+
+func TestSaveAndReadEncryptedConfig(t *testing.T) {
+	defer setTestEnv(t, "master-key")()
+	config := &ConfigV1{Artifactory: []*ArtifactoryDetails{{Url: "http://localhost:8080/artifactory/", ServerId: "server", User: "user", Password: "password", ApiKey: "api-key"}}}
+	if err := saveConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	content := readTestConfigFile(t)
+	if strings.Contains(content, `"password": "password"`) || strings.Contains(content, `"api-key"`) {
+		t.Error("Expected the secrets to be encrypted in the config file, got:", content)
+	}
+
+	readConfig, err := readConf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readConfig.Enc || readConfig.Salt != "" || !reflect.DeepEqual(readConfig.Artifactory, config.Artifactory) {
+		t.Errorf("Expected the config read to be equal to the config saved, got %+v", readConfig.Artifactory[0])
+	}
+
+	os.Setenv(cliutils.EncryptionKeyEnv, "wrong-key")
+	if _, err = readConf(); err == nil {
+		t.Error("Expected reading the config with a wrong key to fail.")
+	}
+}
+
+func TestEncryptPlainConfig(t *testing.T) {
+	defer setTestEnv(t, "")()
+	config := &ConfigV1{Artifactory: []*ArtifactoryDetails{{Url: "http://localhost:8080/artifactory/", ServerId: "server", User: "user", Password: "password"}}}
+	if err := saveConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	if content := readTestConfigFile(t); !strings.Contains(content, `"password": "password"`) {
+		t.Error("Expected the config to be saved in plain text, got:", content)
+	}
+
+	// Once the key is set, the plain config is encrypted when read.
+	os.Setenv(cliutils.EncryptionKeyEnv, "master-key")
+	readConfig, err := readConf()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readConfig.Artifactory[0].Password != "password" {
+		t.Error("Expected the password to be read, got:", readConfig.Artifactory[0].Password)
+	}
+	if content := readTestConfigFile(t); strings.Contains(content, `"password": "password"`) || !strings.Contains(content, `"enc": true`) {
+		t.Error("Expected the config file to be encrypted, got:", content)
+	}
+}
+
+// Sets the home dir to a temp dir and the encryption key to the given key. Returns a function which restores the environment.
+func setTestEnv(t *testing.T, masterKey string) func() {
+	homeDir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restoreFuncs []func()
+	for name, value := range map[string]string{cliutils.JfrogHomeDirEnv: homeDir, cliutils.EncryptionKeyEnv: masterKey, cliutils.EncryptionKeyFileEnv: ""} {
+		previous, exists := os.LookupEnv(name)
+		os.Setenv(name, value)
+		restoreFuncs = append(restoreFuncs, func(name string) func() {
+			return func() {
+				if exists {
+					os.Setenv(name, previous)
+				} else {
+					os.Unsetenv(name)
+				}
+			}
+		}(name))
+	}
+	return func() {
+		for _, restore := range restoreFuncs {
+			restore()
+		}
+		os.RemoveAll(homeDir)
+	}
+}
+
+func readTestConfigFile(t *testing.T) string {
+	confFilePath, err := getConfFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(confFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestParseHelperCredentials(t *testing.T) {
+	credentials, err := parseHelperCredentials([]byte(`{"accessToken": "token"}`+"\n"), "helper")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if credentials.AccessToken != "token" {
+		t.Error("Expected access token 'token', got: " + credentials.AccessToken)
+	}
+	credentials, err = parseHelperCredentials([]byte(`{"user": "user", "password": "password"}`), "helper")
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if credentials.User != "user" || credentials.Password != "password" {
+		t.Error("Unexpected user and password: " + credentials.User + ", " + credentials.Password)
+	}
+	if _, err = parseHelperCredentials([]byte(`{"user": "user"}`), "helper"); err == nil {
+		t.Error("Expected an error for missing password.")
+	}
+	if _, err = parseHelperCredentials([]byte(`not json`), "helper"); err == nil {
+		t.Error("Expected an error for invalid JSON.")
+	}
+}
+
+func TestResolveCredentialsPrecedence(t *testing.T) {
+	log.SetLogger(log.NewLogger(log.INFO, nil))
+	// The helper doesn't exist, so it fails if it runs.
+	helper := filepath.Join("non", "existing", "helper")
+
+	// Credentials given explicitly are used as is, without running the helper.
+	for _, details := range []*ArtifactoryDetails{
+		{Url: "http://localhost:8080/artifactory/", AccessToken: "token", CredentialsHelper: helper},
+		{Url: "http://localhost:8080/artifactory/", User: "user", Password: "password", CredentialsHelper: helper},
+		{Url: "http://localhost:8080/artifactory/", ApiKey: "api-key", CredentialsHelper: helper},
+	} {
+		resolved, err := details.ResolveCredentials()
+		if err != nil {
+			t.Error("Expected the explicit credentials to be used, got:", err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(resolved, details) {
+			t.Errorf("Expected the details to be returned as is, got %+v", resolved)
+		}
+	}
+
+	// Otherwise, the credentials are taken from the helper.
+	details := &ArtifactoryDetails{Url: "http://localhost:8080/artifactory/", CredentialsHelper: helper}
+	if _, err := details.ResolveCredentials(); err == nil || !strings.Contains(err.Error(), "Credentials helper") {
+		t.Error("Expected the credentials helper to run, got:", err)
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"os"
+	"os/exec"
+)
+
+// The credentials returned by a credentials helper, in JSON format, through its standard output.
+type helperCredentials struct {
+	User        string `json:"user,omitempty"`
+	Password    string `json:"password,omitempty"`
+	AccessToken string `json:"accessToken,omitempty"`
+}
+
+// Returns a copy of the details, including the credentials returned by the configured credentials helper.
+// If no credentials helper is configured, or the details already include credentials, the details are returned as is.
+// The credentials are never stored in the details themselves, so that they are not persisted to the config file.
+func (artifactoryDetails *ArtifactoryDetails) ResolveCredentials() (*ArtifactoryDetails, error) {
+	if artifactoryDetails.CredentialsHelper == "" || artifactoryDetails.HasCredentials() {
+		return artifactoryDetails, nil
+	}
+	credentials, err := runCredentialsHelper(artifactoryDetails.CredentialsHelper, artifactoryDetails.Url)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *artifactoryDetails
+	resolved.User = credentials.User
+	resolved.Password = credentials.Password
+	resolved.AccessToken = credentials.AccessToken
+	resolved.ApiKey = ""
+	return &resolved, nil
+}
+
+// Returns true if credentials were set explicitly, rather than by a credentials helper.
+func (artifactoryDetails *ArtifactoryDetails) HasCredentials() bool {
+	return artifactoryDetails.User != "" || artifactoryDetails.Password != "" || artifactoryDetails.ApiKey != "" ||
+		artifactoryDetails.AccessToken != "" || artifactoryDetails.SshKeyPath != ""
+}
+
+// Runs the credentials helper executable with the server URL as its only argument, and parses the credentials from its output.
+func runCredentialsHelper(helper, url string) (*helperCredentials, error) {
+	log.Debug("Running credentials helper:", helper, url)
+	var stdout bytes.Buffer
+	cmd := exec.Command(helper, url)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errorutils.CheckError(errors.New("Credentials helper '" + helper + "' failed: " + err.Error()))
+	}
+	return parseHelperCredentials(stdout.Bytes(), helper)
+}
+
+func parseHelperCredentials(output []byte, helper string) (*helperCredentials, error) {
+	credentials := new(helperCredentials)
+	if err := json.Unmarshal(bytes.TrimSpace(output), credentials); err != nil {
+		return nil, errorutils.CheckError(errors.New("Credentials helper '" + helper + "' returned an invalid JSON: " + err.Error()))
+	}
+	if credentials.AccessToken == "" && (credentials.User == "" || credentials.Password == "") {
+		return nil, errorutils.CheckError(errors.New("Credentials helper '" + helper + "' should return either an access token or a user and a password."))
+	}
+	return credentials, nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/crypto/scrypt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Encrypted values are stored in the config file with this prefix.
+const encryptedValuePrefix = "enc:"
+
+// The parameters of the scrypt key derivation. The salt is generated whenever the config is encrypted, and stored in the config file.
+const (
+	saltSize = 16
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+	keySize  = 32
+)
+
+// Returns the master key used to encrypt the secrets stored in the config file.
+// The key is taken from the JFROG_CLI_ENCRYPTION_KEY environment variable, or read from the file which path is set in JFROG_CLI_ENCRYPTION_KEY_FILE.
+// Returns nil if none of them are set, meaning the config file should be stored in plain text.
+func getMasterKey() ([]byte, error) {
+	if key := os.Getenv(cliutils.EncryptionKeyEnv); key != "" {
+		return []byte(key), nil
+	}
+	keyFile := os.Getenv(cliutils.EncryptionKeyFileEnv)
+	if keyFile == "" {
+		return nil, nil
+	}
+	content, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	content = []byte(strings.TrimSpace(string(content)))
+	if len(content) == 0 {
+		return nil, errorutils.CheckError(errors.New("The encryption key file " + keyFile + " is empty."))
+	}
+	return content, nil
+}
+
+// The master key may be of any length. Derive a 32 bytes key for AES-256 from it, using the salt stored in the config.
+func deriveKey(masterKey, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key(masterKey, salt, scryptN, scryptR, scryptP, keySize)
+	return key, errorutils.CheckError(err)
+}
+
+// Returns a copy of the config, with all secret fields encrypted using a key derived from the master key.
+func encryptConfig(config *ConfigV1, masterKey []byte) (*ConfigV1, error) {
+	encrypted, err := cloneConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, saltSize)
+	if _, err = io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	key, err := deriveKey(masterKey, salt)
+	if err != nil {
+		return nil, err
+	}
+	err = handleSecrets(encrypted, func(value string) (string, error) {
+		return encrypt(value, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	encrypted.Enc = true
+	encrypted.Salt = base64.StdEncoding.EncodeToString(salt)
+	return encrypted, nil
+}
+
+// Decrypts all secret fields of the config in place.
+func decryptConfig(config *ConfigV1, masterKey []byte) error {
+	if masterKey == nil {
+		return errorutils.CheckError(errors.New("The config file is encrypted. Set the " + cliutils.EncryptionKeyEnv + " or " + cliutils.EncryptionKeyFileEnv + " environment variable to read it."))
+	}
+	salt, err := base64.StdEncoding.DecodeString(config.Salt)
+	if err != nil || len(salt) == 0 {
+		return errorutils.CheckError(errors.New("Failed to decrypt the config file: the salt of the encryption key is missing."))
+	}
+	key, err := deriveKey(masterKey, salt)
+	if err != nil {
+		return err
+	}
+	err = handleSecrets(config, func(value string) (string, error) {
+		return decrypt(value, key)
+	})
+	if err != nil {
+		return err
+	}
+	config.Enc = false
+	config.Salt = ""
+	return nil
+}
+
+// Applies the given function on every secret field of the config.
+func handleSecrets(config *ConfigV1, handler func(string) (string, error)) error {
+	var secrets []*string
+	for _, details := range config.Artifactory {
+		secrets = append(secrets, &details.Password, &details.ApiKey, &details.AccessToken, &details.SshPassphrase)
+	}
+	if config.Bintray != nil {
+		secrets = append(secrets, &config.Bintray.Key)
+	}
+	if config.MissionControl != nil {
+		secrets = append(secrets, &config.MissionControl.Password)
+	}
+	for _, secret := range secrets {
+		if *secret == "" {
+			continue
+		}
+		value, err := handler(*secret)
+		if err != nil {
+			return err
+		}
+		*secret = value
+	}
+	return nil
+}
+
+func cloneConfig(config *ConfigV1) (*ConfigV1, error) {
+	content, err := json.Marshal(config)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	clone := new(ConfigV1)
+	err = json.Unmarshal(content, clone)
+	return clone, errorutils.CheckError(err)
+}
+
+func encrypt(value string, key []byte) (string, error) {
+	gcm, err := createGcm(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decrypt(value string, key []byte) (string, error) {
+	// Values edited manually after the config was encrypted are kept as is.
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	gcm, err := createGcm(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errorutils.CheckError(errors.New("Failed to decrypt the config file: encrypted value is too short."))
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errorutils.CheckError(errors.New("Failed to decrypt the config file. Make sure the encryption key is correct."))
+	}
+	return string(plain), nil
+}
+
+func createGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	return gcm, errorutils.CheckError(err)
+}
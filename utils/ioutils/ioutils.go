@@ -6,7 +6,9 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"golang.org/x/crypto/ssh/terminal"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"syscall"
 )
@@ -70,6 +72,29 @@ func CopyFile(src, dst string, fileMode os.FileMode) error {
 	return errorutils.CheckError(os.Chmod(dst, fileMode))
 }
 
+// The prefix of the temp files written by WriteFileAtomically. Readers of a dir which is being written to may skip these files.
+const AtomicWriteTempPrefix = ".tmp-"
+
+// Writes the content to a temp file in the dir of the file, and renames it to the file once it is complete.
+// Readers get either the previous or the new content of the file, and an interrupted write never leaves a partial file.
+func WriteFileAtomically(filePath string, content []byte) error {
+	tempFile, err := ioutil.TempFile(filepath.Dir(filePath), AtomicWriteTempPrefix+filepath.Base(filePath))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = tempFile.Write(content)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), filePath)
+	}
+	if err != nil {
+		os.Remove(tempFile.Name())
+	}
+	return errorutils.CheckError(err)
+}
+
 func DoubleWinPathSeparator(filePath string) string {
 	return strings.Replace(filePath, "\\", "\\\\", -1)
 }
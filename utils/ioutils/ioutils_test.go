@@ -0,0 +1,37 @@
+package ioutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomically(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "ioutils")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	filePath := filepath.Join(tempDir, "file.json")
+	for _, content := range []string{"first", "second"} {
+		if err = WriteFileAtomically(filePath, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != content {
+			t.Errorf("Expected %q, got %q", content, string(actual))
+		}
+	}
+	// No temp files are left in the dir.
+	files, err := ioutil.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected only the written file in the dir, got %d files", len(files))
+	}
+}
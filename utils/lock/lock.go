@@ -1,232 +1,200 @@
 package lock
 
 import (
-	"errors"
 	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/utils/config"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io/ioutil"
 	"os"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
-type Lock struct {
-	// The current time when the lock was created
-	currentTime int64
-	// The full path to the lock file.
-	fileName string
-	pid      int
-}
+// Locks are advisory locks of the operating system on lock files.
+// The operating system releases a lock once its file is closed, including when the process holding it exits,
+// so a process which crashes doesn't leave a stale lock behind.
 
-type Locks []Lock
+type Mode int
 
-func (locks Locks) Len() int {
-	return len(locks)
-}
+const (
+	// Only one process can hold an exclusive lock.
+	Exclusive Mode = iota
+	// Many processes can hold a shared lock, as long as no process holds an exclusive lock.
+	Shared
+)
 
-func (locks Locks) Swap(i, j int) {
-	locks[i], locks[j] = locks[j], locks[i]
-}
+const (
+	lockFileName     = "jfrog-cli.lck"
+	defaultTimeout   = 2 * time.Minute
+	lockPollInterval = 100 * time.Millisecond
+)
 
-func (locks Locks) Less(i, j int) bool {
-	return locks[i].currentTime < locks[j].currentTime
+type Lock struct {
+	// The full path to the lock file.
+	fileName string
+	mode     Mode
+	// The open lock file, while the lock is held.
+	file *os.File
 }
 
-// Creating a new lock object.
-func (lock *Lock) CreateNewLockFile() error {
-	lock.currentTime = time.Now().UnixNano()
-	folderName, err := CreateLockDir()
-	if err != nil {
-		return err
-	}
-	pid := os.Getpid()
-	lock.pid = pid
-	err = lock.CreateFile(folderName, pid)
-	if err != nil {
-		return err
-	}
-	return nil
+// Returns a lock on the file, which is acquired by calling Lock().
+func NewLock(fileName string, mode Mode) *Lock {
+	return &Lock{fileName: fileName, mode: mode}
 }
 
+// The lock files of older versions, which are named by the PID and time of their process, are kept in the 'lock' dir.
+// A different dir is used, so that these versions don't fail parsing the lock file.
 func CreateLockDir() (string, error) {
-	return config.CreateDirInJfrogHome("lock")
+	return config.CreateDirInJfrogHome("locks")
 }
 
-func (lock *Lock) CreateFile(folderName string, pid int) error {
-	// We are creating an empty file with the pid and current time part of the name
-	lock.fileName = filepath.Join(folderName, "jfrog-cli.conf.lck."+strconv.Itoa(pid)+"."+strconv.FormatInt(lock.currentTime, 10))
-	log.Debug("Creating lock file: ", lock.fileName)
-	file, err := os.OpenFile(lock.fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
-	if err != nil {
-		return errorutils.CheckError(err)
-	}
-
-	if err = file.Close(); err != nil {
-		return errorutils.CheckError(err)
-	}
-	return nil
-}
-
-// Try to acquire a lock
+// Acquires the lock. If another process holds the lock, waits for it up to the timeout set by the JFROG_CLI_LOCK_TIMEOUT env var.
 func (lock *Lock) Lock() error {
-	filesList, err := lock.getListOfFiles()
+	timeout, err := getTimeout()
 	if err != nil {
 		return err
 	}
-	i := 0
-	// Trying 1200 times to acquire a lock
-	for i <= 1200 {
-		// If only one file, means that the process that is running is the one that created the file.
-		// We can continue
-		if len(filesList) == 1 {
-			return nil
-		}
-
-		locks, err := lock.getLocks(filesList)
+	deadline := time.Now().Add(timeout)
+	for {
+		file, err := lock.tryLockFile()
 		if err != nil {
 			return err
 		}
-		// If the first timestamp in the sorted locks slice is equal to this timestamp
-		// means that the lock can be acquired
-		if locks[0].currentTime == lock.currentTime {
-			// Edge case, if at the same time (by the nano seconds) two different process created two files.
-			// We are checking the PID to know which process can run.
-			if locks[0].pid != lock.pid {
-				err := lock.removeOtherLockOrWait(locks[0], &filesList)
-				if err != nil {
-					return err
-				}
-			} else {
-				log.Debug("Lock has been acquired for", lock.fileName)
-				return nil
-			}
-		} else {
-			err := lock.removeOtherLockOrWait(locks[0], &filesList)
-			if err != nil {
-				return err
-			}
+		if file != nil {
+			lock.file = file
+			break
 		}
-		i++
+		if time.Now().After(deadline) {
+			return errorutils.CheckError(lock.createTimeoutError(timeout))
+		}
+		time.Sleep(lockPollInterval)
+	}
+	log.Debug("Lock has been acquired for", lock.fileName)
+	// The PID of the process holding the lock is written to the lock file, so that processes waiting for the lock can report it.
+	if err = lock.file.Truncate(0); err == nil {
+		_, err = lock.file.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
 	}
-	return errors.New("Lock hasn't been acquired.")
+	if err != nil {
+		log.Debug("Failed writing the PID to the lock file:", err.Error())
+	}
+	return nil
 }
 
-// Checks if other lock file still exists.
-// Or the process that created the lock still running.
-func (lock *Lock) removeOtherLockOrWait(otherLock Lock, filesList *[]string) error {
-	// Check if file exists.
-	exists, err := fileutils.IsFileExists(otherLock.fileName, false)
+// Opens the lock file and tries to lock it. Returns nil if the lock is held by another process.
+// The process holding the lock may remove the lock file. A lock acquired on the removed file is released, to be acquired again on a new lock file.
+func (lock *Lock) tryLockFile() (*os.File, error) {
+	file, err := os.OpenFile(lock.fileName, os.O_RDWR|os.O_CREATE, 0666)
 	if err != nil {
-		return err
+		return nil, errorutils.CheckError(err)
 	}
-
-	if !exists {
-		// Process already finished. Update the list.
-		*filesList, err = lock.getListOfFiles()
-		if err != nil {
-			return err
+	locked, err := tryLock(file, lock.mode)
+	if err == nil && locked {
+		var removed bool
+		if removed, err = isRemoved(file, lock.fileName); err == nil && !removed {
+			return file, nil
 		}
-		return nil
+		unlock(file)
 	}
-	log.Debug("Lock hasn't been acquired.")
+	file.Close()
+	return nil, errorutils.CheckError(err)
+}
 
-	// Check if the process is running.
-	// There are two implementation of the 'isProcessRunning'.
-	// One for Windows and one for Unix based systems.
-	running, err := isProcessRunning(otherLock.pid)
+func isRemoved(file *os.File, fileName string) (bool, error) {
+	openFileInfo, err := file.Stat()
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	if !running {
-		log.Debug(fmt.Sprintf("Removing lock file %s since the creating process is no longer running", otherLock.fileName))
-		err := otherLock.Unlock()
-		// Update list of files
-		*filesList, err = lock.getListOfFiles()
-		if err != nil {
-			return err
-		}
-		return nil
+	fileInfo, err := os.Stat(fileName)
+	if os.IsNotExist(err) {
+		return true, nil
 	}
-	// Other process is running. Wait.
-	time.Sleep(100 * time.Millisecond)
-	return nil
-}
-
-func (lock *Lock) getListOfFiles() ([]string, error) {
-	// Listing all the files in the lock directory
-	filesList, err := fileutils.ListFiles(filepath.Dir(lock.fileName), false)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
-	return filesList, nil
+	return !os.SameFile(openFileInfo, fileInfo), nil
 }
 
-// Returns a list of all available locks.
-func (lock *Lock) getLocks(filesList []string) (Locks, error) {
-	// Slice of all the timestamps that currently the lock directory has
-	var files Locks
-	for _, path := range filesList {
-		fileName := filepath.Base(path)
-		splitted := strings.Split(fileName, ".")
-
-		if len(splitted) != 5 {
-			return nil, errorutils.CheckError(fmt.Errorf("Failed while parsing the file name: %s located at: %s. Expecting a different format.", fileName, path))
+func (lock *Lock) createTimeoutError(timeout time.Duration) error {
+	holder := "another process"
+	if content, err := ioutil.ReadFile(lock.fileName); err == nil {
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(content))); err == nil {
+			holder = "process " + strconv.Itoa(pid)
 		}
-		// Last element is the timestamp.
-		time, err := strconv.ParseInt(splitted[4], 10, 64)
-		if err != nil {
-			return nil, errorutils.CheckError(err)
-		}
-		pid, err := strconv.Atoi(splitted[3])
-		if err != nil {
-			return nil, errorutils.CheckError(err)
-		}
-		file := Lock{
-			currentTime: time,
-			pid:         pid,
-			fileName:    path,
-		}
-		files = append(files, file)
 	}
-	sort.Sort(files)
-	return files, nil
+	return fmt.Errorf("Lock %s hasn't been acquired within %s, since it is held by %s. The timeout can be set using the %s env var.", lock.fileName, timeout, holder, cliutils.LockTimeout)
 }
 
-// Removes the lock file so other process can continue.
+// Releases the lock so other process can continue.
 func (lock *Lock) Unlock() error {
+	if lock.file == nil {
+		return nil
+	}
 	log.Debug("Releasing lock: ", lock.fileName)
-	exists, err := fileutils.IsFileExists(lock.fileName, false)
-	if err != nil {
-		return err
+	err := unlock(lock.file)
+	if closeErr := lock.file.Close(); err == nil {
+		err = closeErr
 	}
+	lock.file = nil
+	return errorutils.CheckError(err)
+}
 
-	if exists {
-		err = os.Remove(lock.fileName)
-		if err != nil {
-			return errorutils.CheckError(err)
-		}
+// Removes the lock file and releases the lock. Processes waiting for the lock acquire it on a new lock file.
+// On Windows, the lock file isn't removed while other processes have it open, so it is kept.
+func (lock *Lock) UnlockAndRemove() error {
+	if lock.file == nil {
+		return nil
 	}
-	return nil
+	if err := os.Remove(lock.fileName); err != nil {
+		log.Debug("Failed removing the lock file:", err.Error())
+	}
+	return lock.Unlock()
 }
 
-func CreateLock() (Lock, error) {
-	lockFile := new(Lock)
-	err := lockFile.CreateNewLockFile()
+// The timeout is set in seconds.
+func getTimeout() (time.Duration, error) {
+	value := os.Getenv(cliutils.LockTimeout)
+	if value == "" {
+		return defaultTimeout, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, errorutils.CheckError(fmt.Errorf("The value of %s must be a non-negative number of seconds, got '%s'.", cliutils.LockTimeout, value))
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
 
+// Acquires an exclusive lock, shared by the JFrog CLI processes which use its home dir.
+func CreateLock() (Lock, error) {
+	folderName, err := CreateLockDir()
 	if err != nil {
-		return *lockFile, err
+		return Lock{}, err
 	}
+	return createLock(filepath.Join(folderName, lockFileName), Exclusive)
+}
 
-	// Trying to acquire a lock for the running process.
-	err = lockFile.Lock()
-	if err != nil {
-		return *lockFile, errorutils.CheckError(err)
+// Acquires a lock in the given directory, rather than in the lock directory of the JFrog home.
+// The lock is only shared with the other locks created in the same directory.
+func CreateLockInDir(dirPath string, mode Mode) (Lock, error) {
+	if err := os.MkdirAll(dirPath, 0777); err != nil {
+		return Lock{}, errorutils.CheckError(err)
 	}
-	return *lockFile, nil
+	return createLock(filepath.Join(dirPath, lockFileName), mode)
+}
+
+// Acquires a lock on the given file, creating the file and its parent dir if needed.
+func CreateLockFile(filePath string, mode Mode) (Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		return Lock{}, errorutils.CheckError(err)
+	}
+	return createLock(filePath, mode)
+}
+
+func createLock(fileName string, mode Mode) (Lock, error) {
+	lockFile := NewLock(fileName, mode)
+	err := lockFile.Lock()
+	return *lockFile, err
 }
@@ -1,11 +1,13 @@
 package lock
 
 import (
-	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
 	"github.com/jfrog/jfrog-cli-go/utils/log"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
-	"math"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -14,176 +16,161 @@ func init() {
 	log.SetDefaultLogger()
 }
 
-/*
-	The lock mechanism prefers earlier lock requests. If two locks requests have same time stamps, it'll take the one with the smaller PID first.
-	Here we test the functionality of a real process with a real PID and a dummy process with MaxInt pid.
-*/
-func TestLockSmallerPid(t *testing.T) {
-	// First creating the first lock object with special pid number that doesn't exists.
-	firstLock, _ := getLock(math.MaxInt32, t)
-	// Creating a second lock object with the running PID
-	secondLock, folderName := getLock(os.Getpid(), t)
-
-	// Confirming that only two locks are located in the lock directory
-	files, err := fileutils.ListFiles(folderName, false)
+func TestExclusiveLock(t *testing.T) {
+	defer setTimeout("0")()
+	dirPath := createTempDir(t)
+	defer os.RemoveAll(dirPath)
+	firstLock, err := CreateLockInDir(dirPath, Exclusive)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 
-	if len(files) != 2 {
-		t.Error("Expected 2 files but got ", len(files))
+	// The lock is held, so the second lock is expected to fail, naming the holding process.
+	_, err = CreateLockInDir(dirPath, Exclusive)
+	if err == nil {
+		t.Fatal("Expected the second lock to fail, since the first lock is held.")
 	}
-
-	// Performing lock. This should work since the first lock PID is not running. The Lock() will remove it.
-	err = secondLock.Lock()
-	if err != nil {
-		t.Error(err)
+	if !strings.Contains(err.Error(), "process "+strconv.Itoa(os.Getpid())) {
+		t.Error("Expected the error to name the PID of the holding process, got:", err.Error())
 	}
-	// Unlocking to remove the lock file.
-	err = secondLock.Unlock()
-	if err != nil {
-		t.Error(err)
+	if _, err = CreateLockInDir(dirPath, Shared); err == nil {
+		t.Error("Expected a shared lock to fail, since an exclusive lock is held.")
 	}
 
-	// If timestamp equals, secondLock.Lock() is not expected to delete first lock's file, since os.Getpid() < math.MaxInt32.
-	if firstLock.currentTime == secondLock.currentTime {
-		err = firstLock.Unlock()
-		if err != nil {
-			t.Error(err)
-		}
+	if err = firstLock.Unlock(); err != nil {
+		t.Fatal(err)
 	}
-
-	// Confirming that no locks are located in the lock directory
-	files, err = fileutils.ListFiles(folderName, false)
+	secondLock, err := CreateLockInDir(dirPath, Exclusive)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
-	if len(files) != 0 {
-		t.Error("Expected 0 files but got", len(files), files)
+	if err = secondLock.Unlock(); err != nil {
+		t.Error(err)
 	}
 }
 
-/*
-	The lock mechanism prefers earlier lock requests. If two locks requests have same time stamps, it'll take the one with the smaller PID first.
-	Here we test the functionality of a real process with a real PID and a dummy process with -1 pid.
-*/
-func TestLockBiggerPid(t *testing.T) {
-	// First creating the first lock object with special pid number that doesn't exists.
-	getLock(-1, t)
-	// Creating a second lock object with the running PID
-	secondLock, folderName := getLock(os.Getpid(), t)
-
-	// Confirming that only two locks are located in the lock directory
-	files, err := fileutils.ListFiles(folderName, false)
+func TestSharedLock(t *testing.T) {
+	defer setTimeout("0")()
+	dirPath := createTempDir(t)
+	defer os.RemoveAll(dirPath)
+	firstLock, err := CreateLockInDir(dirPath, Shared)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
-
-	if len(files) != 2 {
-		t.Error("Expected 2 files but got ", len(files), files)
-	}
-
-	// Performing lock. This should work since the first lock PID is not running. The Lock() will remove it.
-	err = secondLock.Lock()
+	defer firstLock.Unlock()
+	secondLock, err := CreateLockInDir(dirPath, Shared)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
-	// Unlocking to remove the lock file.
-	err = secondLock.Unlock()
-	if err != nil {
-		t.Error(err)
+	defer secondLock.Unlock()
+	if _, err = CreateLockInDir(dirPath, Exclusive); err == nil {
+		t.Error("Expected an exclusive lock to fail, since shared locks are held.")
 	}
+}
 
-	// Confirming that no locks are located in the lock directory
-	files, err = fileutils.ListFiles(folderName, false)
+// Locks in different dirs don't wait for each other.
+func TestLocksInDifferentDirs(t *testing.T) {
+	defer setTimeout("0")()
+	dirPath := createTempDir(t)
+	defer os.RemoveAll(dirPath)
+	firstLock, err := CreateLockInDir(filepath.Join(dirPath, "first"), Exclusive)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
-	if len(files) != 0 {
-		t.Error("Expected 0 files but got", len(files), files)
+	defer firstLock.Unlock()
+	secondLock, err := CreateLockInDir(filepath.Join(dirPath, "second"), Exclusive)
+	if err != nil {
+		t.Fatal(err)
 	}
+	defer secondLock.Unlock()
 }
 
 func TestUnlock(t *testing.T) {
-	lock := new(Lock)
-	err := lock.CreateNewLockFile()
-	if err != nil {
+	dirPath := createTempDir(t)
+	defer os.RemoveAll(dirPath)
+	// Unlocking a lock which wasn't acquired has no effect.
+	if err := NewLock(filepath.Join(dirPath, lockFileName), Exclusive).Unlock(); err != nil {
 		t.Error(err)
 	}
 
-	exists, err := fileutils.IsFileExists(lock.fileName, false)
+	lock, err := CreateLockInDir(dirPath, Exclusive)
 	if err != nil {
-		t.Error(err)
-	}
-
-	if !exists {
-		t.Errorf("File %s is missing", lock.fileName)
+		t.Fatal(err)
 	}
-
-	lock.Unlock()
-
-	exists, err = fileutils.IsFileExists(lock.fileName, false)
-	if err != nil {
+	if err = lock.Unlock(); err != nil {
 		t.Error(err)
 	}
-
-	if exists {
-		t.Errorf("File %s exists, but it should have been removed by Unlock", lock.fileName)
+	// Unlocking twice has no effect.
+	if err = lock.Unlock(); err != nil {
+		t.Error(err)
 	}
 }
 
-func TestCreateFile(t *testing.T) {
-	pid := os.Getpid()
-	lock, folderName := getLock(pid, t)
-
-	exists, err := fileutils.IsFileExists(lock.fileName, false)
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
+// A process waiting for a lock whose file was removed acquires the lock on a new lock file.
+func TestUnlockAndRemove(t *testing.T) {
+	defer setTimeout("5")()
+	dirPath := createTempDir(t)
+	defer os.RemoveAll(dirPath)
+	filePath := filepath.Join(dirPath, "locks", "file.lck")
+	firstLock, err := CreateLockFile(filePath, Exclusive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquired := make(chan error)
+	var secondLock Lock
+	go func() {
+		var err error
+		secondLock, err = CreateLockFile(filePath, Exclusive)
+		acquired <- err
+	}()
+	time.Sleep(2 * lockPollInterval)
+	if err = firstLock.UnlockAndRemove(); err != nil {
+		t.Fatal(err)
+	}
+	if err = <-acquired; err != nil {
+		t.Fatal(err)
+	}
+	defer secondLock.Unlock()
+	os.Setenv(cliutils.LockTimeout, "0")
+	if _, err = CreateLockFile(filePath, Exclusive); err == nil {
+		t.Error("Expected the lock to be held by the second lock.")
 	}
+}
 
-	if !exists {
-		t.Error("Lock wan't created.")
-		t.FailNow()
+func TestGetTimeout(t *testing.T) {
+	defer setTimeout("")()
+	timeout, err := getTimeout()
+	if err != nil || timeout != defaultTimeout {
+		t.Errorf("Expected the default timeout %s, got %s, %v", defaultTimeout, timeout, err)
 	}
-
-	files, err := fileutils.ListFiles(folderName, false)
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
+	os.Setenv(cliutils.LockTimeout, "5")
+	timeout, err = getTimeout()
+	if err != nil || timeout.Seconds() != 5 {
+		t.Errorf("Expected a timeout of 5 seconds, got %s, %v", timeout, err)
 	}
-
-	if len(files) != 1 {
-		t.Error(fmt.Errorf("Expected one file, got %d.", len(files)))
-		t.FailNow()
+	os.Setenv(cliutils.LockTimeout, "five")
+	if _, err = getTimeout(); err == nil {
+		t.Error("Expected an error for an invalid timeout.")
 	}
+}
 
-	if files[0] != lock.fileName {
-		t.Error(fmt.Errorf("Expected filename %s, got %s", lock.fileName, files[0]))
-	}
-	// Removing the created lock file
-	err = lock.Unlock()
-	if err != nil {
-		t.Error(err)
+// Sets the lock timeout. Returns a function which restores the previous timeout.
+func setTimeout(value string) func() {
+	previous, exists := os.LookupEnv(cliutils.LockTimeout)
+	os.Setenv(cliutils.LockTimeout, value)
+	return func() {
+		if exists {
+			os.Setenv(cliutils.LockTimeout, previous)
+		} else {
+			os.Unsetenv(cliutils.LockTimeout)
+		}
 	}
 }
 
-func getLock(pid int, t *testing.T) (Lock, string) {
-	currentTime := time.Now().UnixNano()
-	lock := Lock{
-		pid:         pid,
-		currentTime: currentTime,
-	}
-	folderName, err := CreateLockDir()
-	if err != nil {
-		t.Error(err)
-		t.FailNow()
-	}
-	err = lock.CreateFile(folderName, pid)
+func createTempDir(t *testing.T) string {
+	dirPath, err := ioutil.TempDir("", "lock")
 	if err != nil {
-		t.Error(err)
-		t.FailNow()
+		t.Fatal(err)
 	}
-
-	return lock, folderName
+	return dirPath
 }
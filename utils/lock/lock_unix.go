@@ -0,0 +1,26 @@
+// +build linux darwin freebsd
+
+package lock
+
+import (
+	"os"
+	"syscall"
+)
+
+// This file will be compiled only on unix systems.
+// Tries to acquire the lock using flock, without blocking. Returns false if another process holds the lock.
+func tryLock(file *os.File, mode Mode) (bool, error) {
+	how := syscall.LOCK_EX
+	if mode == Shared {
+		how = syscall.LOCK_SH
+	}
+	err := syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlock(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,51 @@
+package lock
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// This file will be compiled on windows.
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+const (
+	lockfileFailImmediately = 0x1
+	lockfileExclusiveLock   = 0x2
+	// ERROR_LOCK_VIOLATION - another process holds the lock.
+	errorLockViolation syscall.Errno = 33
+)
+
+// The locks of windows prevent other processes from reading the locked bytes.
+// A byte far beyond the end of the file is locked, so that the PID written to the file can be read.
+func lockedRange() *syscall.Overlapped {
+	return &syscall.Overlapped{Offset: 0xFFFFFFFF, OffsetHigh: 0x7FFFFFFF}
+}
+
+// Tries to acquire the lock using LockFileEx, without blocking. Returns false if another process holds the lock.
+func tryLock(file *os.File, mode Mode) (bool, error) {
+	flags := uintptr(lockfileFailImmediately)
+	if mode == Exclusive {
+		flags |= lockfileExclusiveLock
+	}
+	r, _, err := procLockFileEx.Call(file.Fd(), flags, 0, 1, 0, uintptr(unsafe.Pointer(lockedRange())))
+	if r != 0 {
+		return true, nil
+	}
+	if err == errorLockViolation {
+		return false, nil
+	}
+	return false, err
+}
+
+func unlock(file *os.File) error {
+	r, _, err := procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(lockedRange())))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
@@ -37,6 +37,11 @@ func (summary *Summary) Marshal() ([]byte, error) {
 type Summary struct {
 	Status StatusType `json:"status"`
 	Totals *Totals    `json:"totals"`
+	// The following fields are populated only when the JSON output format is requested.
+	Command string   `json:"command,omitempty"`
+	Paths   []string `json:"paths,omitempty"`
+	Modules []string `json:"modules,omitempty"`
+	Error   string   `json:"error,omitempty"`
 }
 
 type Totals struct {
@@ -45,6 +45,10 @@ func offlineUpdateFlags() []cli.Flag {
 			Name:  "version",
 			Usage: "[Optional] Xray API version.` `",
 		},
+		cli.BoolFlag{
+			Name:  "incremental",
+			Usage: "[Default: false] Set to true to download only the updates published since the last offline update downloaded by this machine.` `",
+		},
 	}
 }
 
@@ -55,8 +59,12 @@ func getOfflineUpdatesFlag(c *cli.Context) (flags *commands.OfflineUpdatesFlags,
 	if len(flags.License) < 1 {
 		cliutils.ExitOnErr(errors.New("The --license-id option is mandatory."))
 	}
+	flags.Incremental = c.Bool("incremental")
 	from := c.String("from")
 	to := c.String("to")
+	if flags.Incremental && (len(from) > 0 || len(to) > 0) {
+		cliutils.ExitOnErr(errors.New("The --incremental option can't be used together with the --from and --to options."))
+	}
 	if len(to) > 0 && len(from) < 1 {
 		cliutils.ExitOnErr(errors.New("The --from option is mandatory, when the --to option is sent."))
 	}
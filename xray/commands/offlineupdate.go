@@ -11,12 +11,12 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -28,6 +28,11 @@ const (
 )
 
 func OfflineUpdate(flags *OfflineUpdatesFlags) error {
+	if flags.Incremental {
+		if err := setIncrementalDates(flags); err != nil {
+			return err
+		}
+	}
 	updatesUrl, err := buildUpdatesUrl(flags)
 	if err != nil {
 		return err
@@ -36,14 +41,13 @@ func OfflineUpdate(flags *OfflineUpdatesFlags) error {
 	if err != nil {
 		return err
 	}
-	zipSuffix := "_" + strconv.FormatInt(lastUpdate, 10)
 	xrayTempDir, err := getXrayTempDir()
 	if err != nil {
 		return err
 	}
 	if len(vulnerabilities) > 0 {
 		log.Info("Downloading vulnerabilities...")
-		err := saveData(xrayTempDir, "vuln", zipSuffix, vulnerabilities)
+		err := saveData(xrayTempDir, "vuln", lastUpdate, vulnerabilities)
 		if err != nil {
 			return err
 		}
@@ -53,7 +57,7 @@ func OfflineUpdate(flags *OfflineUpdatesFlags) error {
 
 	if len(components) > 0 {
 		log.Info("Downloading components...")
-		err := saveData(xrayTempDir, "comp", zipSuffix, components)
+		err := saveData(xrayTempDir, "comp", lastUpdate, components)
 		if err != nil {
 			return err
 		}
@@ -61,6 +65,23 @@ func OfflineUpdate(flags *OfflineUpdatesFlags) error {
 		log.Info("There are no new components.")
 	}
 
+	return saveOfflineUpdateState(lastUpdate)
+}
+
+// Sets the dates to download the updates published since the last update which was downloaded.
+// If no update was downloaded yet, all the updates are downloaded.
+func setIncrementalDates(flags *OfflineUpdatesFlags) error {
+	state, err := loadOfflineUpdateState()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		log.Info("No previous offline update was found. Downloading all the updates.")
+		return nil
+	}
+	flags.From = state.LastUpdate
+	flags.To = time.Now().UnixNano() / int64(time.Millisecond)
+	log.Info("Downloading the updates published since", time.Unix(0, flags.From*int64(time.Millisecond)).Format(time.RFC3339))
 	return nil
 }
 
@@ -119,44 +140,54 @@ func getXrayTempDir() (string, error) {
 	return xrayDir, nil
 }
 
-func saveData(xrayTmpDir, filesPrefix, zipSuffix string, urlsList []string) error {
-	dataDir, err := ioutil.TempDir(xrayTmpDir, filesPrefix)
+// The files are downloaded to a dir named by the files prefix and the last update, so that an interrupted download of the same updates is resumed.
+func saveData(xrayTmpDir, filesPrefix string, lastUpdate int64, urlsList []string) error {
+	zipName := filesPrefix + "_" + strconv.FormatInt(lastUpdate, 10)
+	dataDir := filepath.Join(xrayTmpDir, zipName)
+	if err := removeStaleDataDirs(xrayTmpDir, filesPrefix, dataDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dataDir, 0777); err != nil {
+		return errorutils.CheckError(err)
+	}
+	manifest, err := loadManifest(dataDir, lastUpdate)
+	if err != nil {
+		return err
+	}
+	client, err := httpclient.ClientBuilder().Build()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if cerr := os.RemoveAll(dataDir); cerr != nil && err == nil {
-			err = cerr
-		}
-	}()
 	for _, url := range urlsList {
 		fileName, err := createXrayFileNameFromUrl(url)
 		if err != nil {
 			return err
 		}
-		log.Info("Downloading", url)
-		client, err := httpclient.ClientBuilder().Build()
+		downloaded, err := isDownloaded(dataDir, manifest.getFile(fileName))
 		if err != nil {
 			return err
 		}
-
-		details := &httpclient.DownloadFileDetails{
-			FileName:      fileName,
-			DownloadPath:  url,
-			LocalPath:     dataDir,
-			LocalFileName: fileName}
-		_, err = client.DownloadFile(details, "", httputils.HttpClientDetails{}, 3, false)
+		if downloaded {
+			log.Info("Skipping", fileName+", which was already downloaded.")
+			continue
+		}
+		log.Info("Downloading", url)
+		file, err := downloadXrayFile(client, url, dataDir, fileName)
 		if err != nil {
 			return err
 		}
+		manifest.setFile(file)
+		if err = manifest.save(dataDir); err != nil {
+			return err
+		}
 	}
 	log.Info("Zipping files.")
-	err = fileutils.ZipFolderFiles(dataDir, filesPrefix+zipSuffix+".zip")
+	err = fileutils.ZipFolderFiles(dataDir, zipName+".zip")
 	if err != nil {
 		return err
 	}
 	log.Info("Done zipping files.")
-	return nil
+	return errorutils.CheckError(os.RemoveAll(dataDir))
 }
 
 func createXrayFileNameFromUrl(url string) (fileName string, err error) {
@@ -219,10 +250,11 @@ func getFilesList(updatesUrl string, flags *OfflineUpdatesFlags) (vulnerabilitie
 }
 
 type OfflineUpdatesFlags struct {
-	License string
-	From    int64
-	To      int64
-	Version string
+	License     string
+	From        int64
+	To          int64
+	Version     string
+	Incremental bool
 }
 
 type FilesList struct {
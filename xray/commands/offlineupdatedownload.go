@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	manifestFileName = "manifest.json"
+	// The suffix of files which are being downloaded. Their download is resumed by the next run.
+	partFileSuffix = ".part"
+	// The number of times a failed download of a file is retried. Each retry resumes the download from where it failed.
+	downloadRetries = 3
+)
+
+// The manifest is written into the offline update zip, listing its files and their checksums.
+// While downloading, it also records the files which were already downloaded and verified, so that an interrupted download is resumed.
+type offlineUpdateManifest struct {
+	LastUpdate int64                `json:"lastUpdate"`
+	Files      []*offlineUpdateFile `json:"files"`
+}
+
+type offlineUpdateFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256"`
+	Sha1   string `json:"sha1"`
+	Md5    string `json:"md5"`
+}
+
+func loadManifest(dataDir string, lastUpdate int64) (*offlineUpdateManifest, error) {
+	manifest := &offlineUpdateManifest{LastUpdate: lastUpdate}
+	manifestPath := filepath.Join(dataDir, manifestFileName)
+	exists, err := fileutils.IsFileExists(manifestPath, false)
+	if err != nil || !exists {
+		return manifest, err
+	}
+	content, err := fileutils.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return manifest, nil
+}
+
+func (manifest *offlineUpdateManifest) save(dataDir string) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return ioutils.WriteFileAtomically(filepath.Join(dataDir, manifestFileName), content)
+}
+
+func (manifest *offlineUpdateManifest) getFile(name string) *offlineUpdateFile {
+	for _, file := range manifest.Files {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
+func (manifest *offlineUpdateManifest) setFile(downloadedFile *offlineUpdateFile) {
+	for i, file := range manifest.Files {
+		if file.Name == downloadedFile.Name {
+			manifest.Files[i] = downloadedFile
+			return
+		}
+	}
+	manifest.Files = append(manifest.Files, downloadedFile)
+}
+
+// Returns true if the file was already downloaded by a previous run, and wasn't changed since.
+func isDownloaded(dataDir string, file *offlineUpdateFile) (bool, error) {
+	if file == nil {
+		return false, nil
+	}
+	filePath := filepath.Join(dataDir, file.Name)
+	exists, err := fileutils.IsFileExists(filePath, false)
+	if err != nil || !exists {
+		return false, err
+	}
+	details, err := calcFileDetails(filePath)
+	if err != nil {
+		return false, err
+	}
+	return details.Sha256 == file.Sha256, nil
+}
+
+// The checksums are calculated here, since the sha256 checksum isn't calculated by fileutils.
+func calcFileDetails(filePath string) (*offlineUpdateFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	sha256Hash, sha1Hash, md5Hash := sha256.New(), sha1.New(), md5.New()
+	size, err := io.Copy(io.MultiWriter(sha256Hash, sha1Hash, md5Hash), file)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &offlineUpdateFile{
+		Name:   filepath.Base(filePath),
+		Size:   size,
+		Sha256: hex.EncodeToString(sha256Hash.Sum(nil)),
+		Sha1:   hex.EncodeToString(sha1Hash.Sum(nil)),
+		Md5:    hex.EncodeToString(md5Hash.Sum(nil)),
+	}, nil
+}
+
+// Downloads the file to the data dir, resuming a previous download of the file if it was interrupted.
+// Once downloaded, the file is verified against the checksums the server sent.
+func downloadXrayFile(client *httpclient.HttpClient, url, dataDir, fileName string) (*offlineUpdateFile, error) {
+	partPath := filepath.Join(dataDir, fileName+partFileSuffix)
+	header, retriable, err := downloadXrayFilePart(client, url, partPath, fileName)
+	for retry := 1; err != nil && retriable && retry <= downloadRetries; retry++ {
+		log.Warn(fmt.Sprintf("%s. Retrying (%d/%d).", err.Error(), retry, downloadRetries))
+		header, retriable, err = downloadXrayFilePart(client, url, partPath, fileName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := calcFileDetails(partPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyChecksums(header, details); err != nil {
+		os.Remove(partPath)
+		return nil, err
+	}
+	if err = os.Rename(partPath, filepath.Join(dataDir, fileName)); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	details.Name = fileName
+	return details, nil
+}
+
+// Downloads the rest of the file to the part file, from the end of its content.
+// Returns the header of the response, and whether a failure is worth retrying.
+func downloadXrayFilePart(client *httpclient.HttpClient, url, partPath, fileName string) (http.Header, bool, error) {
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+	}
+	httpClientDetails := httputils.HttpClientDetails{Headers: map[string]string{}}
+	if offset > 0 {
+		log.Info(fmt.Sprintf("Resuming the download of %s from byte %d.", fileName, offset))
+		httpClientDetails.Headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+	}
+	body, resp, err := client.ReadRemoteFile(url, httpClientDetails)
+	if err != nil {
+		return nil, true, err
+	}
+	flags := os.O_WRONLY | os.O_CREATE
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server sent the whole file, rather than the requested range.
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		body = resp.Body
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		log.Debug("The partial download of", fileName, "can't be resumed. Downloading it again.")
+		if err = os.Remove(partPath); err != nil {
+			return nil, false, errorutils.CheckError(err)
+		}
+		return downloadXrayFilePart(client, url, partPath, fileName)
+	default:
+		resp.Body.Close()
+		retriable := resp.StatusCode >= http.StatusInternalServerError
+		return nil, retriable, errorutils.CheckError(fmt.Errorf("Downloading %s failed: %s", fileName, resp.Status))
+	}
+	defer body.Close()
+
+	file, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return nil, false, errorutils.CheckError(err)
+	}
+	_, err = io.Copy(file, body)
+	closeErr := file.Close()
+	if err != nil {
+		return nil, true, errorutils.CheckError(fmt.Errorf("Downloading %s failed: %s", fileName, err.Error()))
+	}
+	if closeErr != nil {
+		return nil, false, errorutils.CheckError(closeErr)
+	}
+	return resp.Header, false, nil
+}
+
+// Verifies the checksums of the downloaded file against the checksum headers of the response.
+func verifyChecksums(header http.Header, actual *offlineUpdateFile) error {
+	fileName := strings.TrimSuffix(actual.Name, partFileSuffix)
+	expectedChecksums := []struct {
+		name, expected, actual string
+	}{
+		{"sha256", header.Get("X-Checksum-Sha256"), actual.Sha256},
+		{"sha1", header.Get("X-Checksum-Sha1"), actual.Sha1},
+		{"md5", header.Get("X-Checksum-Md5"), actual.Md5},
+	}
+	verified := false
+	for _, checksum := range expectedChecksums {
+		if checksum.expected == "" {
+			continue
+		}
+		if !strings.EqualFold(checksum.expected, checksum.actual) {
+			return errorutils.CheckError(fmt.Errorf("The %s checksum of %s is %s, while the expected checksum is %s. Please run the command again to download it.", checksum.name, fileName, checksum.actual, checksum.expected))
+		}
+		verified = true
+	}
+	if !verified {
+		log.Warn("The server didn't send the checksums of", fileName+", so it isn't verified.")
+	}
+	return nil
+}
+
+// Removes the data dirs of previous downloads which weren't completed, and can't be resumed since the updates changed.
+func removeStaleDataDirs(xrayTmpDir, filesPrefix, dataDir string) error {
+	dirs, err := filepath.Glob(filepath.Join(xrayTmpDir, filesPrefix+"_*"))
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	for _, dir := range dirs {
+		if dir == dataDir {
+			continue
+		}
+		log.Debug("Removing", dir)
+		if err = os.RemoveAll(dir); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/jfrog/jfrog-cli-go/utils/cliutils"
+	"github.com/jfrog/jfrog-cli-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/httpclient"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var testUpdateContent = bytes.Repeat([]byte("offline-update-data "), 100)
+
+func createTestUpdatesServer(sha256Header string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Checksum-Sha256", sha256Header)
+		http.ServeContent(w, r, "update.zip", time.Time{}, bytes.NewReader(testUpdateContent))
+	}))
+}
+
+func TestDownloadXrayFileResume(t *testing.T) {
+	log.SetDefaultLogger()
+	checksum := sha256.Sum256(testUpdateContent)
+	server := createTestUpdatesServer(hex.EncodeToString(checksum[:]))
+	defer server.Close()
+	dataDir, err := ioutil.TempDir("", "offlineupdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+	// A previous download was interrupted after the first 500 bytes.
+	if err = ioutil.WriteFile(filepath.Join(dataDir, "update.zip"+partFileSuffix), testUpdateContent[:500], 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := downloadXrayFile(client, server.URL+"/2019-05/update.zip", dataDir, "update.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dataDir, "update.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, testUpdateContent) || file.Size != int64(len(testUpdateContent)) {
+		t.Errorf("The resumed download doesn't match the file on the server: got %d bytes", len(content))
+	}
+
+	manifest := &offlineUpdateManifest{LastUpdate: 1}
+	manifest.setFile(file)
+	if err = manifest.save(dataDir); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err = loadManifest(dataDir, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	downloaded, err := isDownloaded(dataDir, manifest.getFile("update.zip"))
+	if err != nil || !downloaded {
+		t.Error("Expected the file to be recorded as downloaded.", err)
+	}
+}
+
+func TestDownloadXrayFileChecksumMismatch(t *testing.T) {
+	log.SetDefaultLogger()
+	server := createTestUpdatesServer("0000")
+	defer server.Close()
+	dataDir, err := ioutil.TempDir("", "offlineupdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = downloadXrayFile(client, server.URL+"/2019-05/update.zip", dataDir, "update.zip"); err == nil {
+		t.Fatal("Expected the download to fail, since the checksum doesn't match.")
+	}
+	files, err := ioutil.ReadDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Error("Expected the corrupted download to be removed.")
+	}
+}
+
+func TestDownloadXrayFileRetry(t *testing.T) {
+	log.SetDefaultLogger()
+	checksum := sha256.Sum256(testUpdateContent)
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("Range"))
+		switch len(requests) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			// The connection is closed after the first 500 bytes.
+			w.Header().Set("Content-Length", strconv.Itoa(len(testUpdateContent)))
+			w.Write(testUpdateContent[:500])
+		default:
+			w.Header().Set("X-Checksum-Sha256", hex.EncodeToString(checksum[:]))
+			http.ServeContent(w, r, "update.zip", time.Time{}, bytes.NewReader(testUpdateContent))
+		}
+	}))
+	defer server.Close()
+	dataDir, err := ioutil.TempDir("", "offlineupdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dataDir)
+	client, err := httpclient.ClientBuilder().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = downloadXrayFile(client, server.URL+"/2019-05/update.zip", dataDir, "update.zip"); err != nil {
+		t.Fatal(err)
+	}
+	// The last retry resumes the download from where the previous one failed.
+	if expected := []string{"", "", "bytes=500-"}; !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected the requests %q, got %q", expected, requests)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dataDir, "update.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, testUpdateContent) {
+		t.Errorf("The retried download doesn't match the file on the server: got %d bytes", len(content))
+	}
+}
+
+func TestOfflineUpdateState(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "offlineupdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(homeDir)
+	previousHome, exists := os.LookupEnv(cliutils.JfrogHomeDirEnv)
+	os.Setenv(cliutils.JfrogHomeDirEnv, homeDir)
+	defer func() {
+		if exists {
+			os.Setenv(cliutils.JfrogHomeDirEnv, previousHome)
+		} else {
+			os.Unsetenv(cliutils.JfrogHomeDirEnv)
+		}
+	}()
+
+	flags := &OfflineUpdatesFlags{Incremental: true}
+	if err := setIncrementalDates(flags); err != nil {
+		t.Fatal(err)
+	}
+	if flags.From != 0 || flags.To != 0 {
+		t.Error("Expected all the updates to be downloaded when no state exists.")
+	}
+	if err := saveOfflineUpdateState(2000); err != nil {
+		t.Fatal(err)
+	}
+	// An older update doesn't override the state.
+	if err := saveOfflineUpdateState(1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := setIncrementalDates(flags); err != nil {
+		t.Fatal(err)
+	}
+	if flags.From != 2000 || flags.To < flags.From {
+		t.Errorf("Unexpected incremental dates: from %d to %d", flags.From, flags.To)
+	}
+}
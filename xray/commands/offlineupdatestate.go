@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"encoding/json"
+	"github.com/jfrog/jfrog-cli-go/utils/config"
+	"github.com/jfrog/jfrog-cli-go/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"path/filepath"
+)
+
+const offlineUpdateStateFileName = "offline-update-state.json"
+
+// The state of the offline updates is kept in the JFrog home, so that incremental updates download only the data published since the last update.
+type offlineUpdateState struct {
+	LastUpdate int64 `json:"lastUpdate"`
+}
+
+func getOfflineUpdateStatePath() (string, error) {
+	xrayDir, err := config.CreateDirInJfrogHome("xray")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(xrayDir, offlineUpdateStateFileName), nil
+}
+
+// Returns nil if no offline update was downloaded yet.
+func loadOfflineUpdateState() (*offlineUpdateState, error) {
+	statePath, err := getOfflineUpdateStatePath()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := fileutils.IsFileExists(statePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := fileutils.ReadFile(statePath)
+	if err != nil {
+		return nil, err
+	}
+	state := new(offlineUpdateState)
+	if err = json.Unmarshal(content, state); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return state, nil
+}
+
+// Saves the last update, unless a later update was already saved, such as when downloading an older range of dates.
+func saveOfflineUpdateState(lastUpdate int64) error {
+	state, err := loadOfflineUpdateState()
+	if err != nil {
+		return err
+	}
+	if state != nil && state.LastUpdate >= lastUpdate {
+		return nil
+	}
+	statePath, err := getOfflineUpdateStatePath()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(&offlineUpdateState{LastUpdate: lastUpdate})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return ioutils.WriteFileAtomically(statePath, content)
+}